@@ -10,14 +10,17 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/saucelabs/saucectl/internal/cmd/apit"
 	"github.com/saucelabs/saucectl/internal/cmd/artifacts"
+	"github.com/saucelabs/saucectl/internal/cmd/cfg"
 	"github.com/saucelabs/saucectl/internal/cmd/completion"
 	"github.com/saucelabs/saucectl/internal/cmd/configure"
 	"github.com/saucelabs/saucectl/internal/cmd/imagerunner"
 	"github.com/saucelabs/saucectl/internal/cmd/ini"
 	"github.com/saucelabs/saucectl/internal/cmd/jobs"
 	"github.com/saucelabs/saucectl/internal/cmd/run"
+	"github.com/saucelabs/saucectl/internal/cmd/sauceignore"
 	"github.com/saucelabs/saucectl/internal/cmd/signup"
 	"github.com/saucelabs/saucectl/internal/cmd/storage"
+	"github.com/saucelabs/saucectl/internal/secret"
 	"github.com/saucelabs/saucectl/internal/segment"
 	"github.com/saucelabs/saucectl/internal/version"
 	"github.com/spf13/cobra"
@@ -68,6 +71,8 @@ func main() {
 		jobs.Command(cmd.PersistentPreRun),
 		imagerunner.Command(cmd.PersistentPreRun),
 		apit.Command(cmd.PersistentPreRun),
+		cfg.Command(cmd.PersistentPreRun),
+		sauceignore.Command(cmd.PersistentPreRun),
 	)
 
 	if err := cmd.Execute(); err != nil {
@@ -90,5 +95,5 @@ func setupLogging(verbose bool, noColor bool) {
 		return time.Now().In(time.Local)
 	}
 
-	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: timeFormat, NoColor: noColor})
+	log.Logger = log.Output(zerolog.ConsoleWriter{Out: secret.NewWriter(os.Stdout), TimeFormat: timeFormat, NoColor: noColor})
 }
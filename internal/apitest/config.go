@@ -2,6 +2,7 @@ package apitest
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/saucelabs/saucectl/internal/config"
@@ -27,6 +28,7 @@ type Project struct {
 	Sauce          config.SauceConfig `yaml:"sauce,omitempty"`
 	RootDir        string             `yaml:"rootDir,omitempty"`
 	Env            map[string]string  `yaml:"env,omitempty"`
+	EnvFile        []string           `yaml:"envFile,omitempty"`
 	EnvFlag        map[string]string  `yaml:"-"`
 }
 
@@ -40,6 +42,11 @@ type Suite struct {
 	Tags           []string          `yaml:"tags,omitempty"`
 	TestMatch      []string          `yaml:"testMatch,omitempty"`
 	Env            map[string]string `yaml:"env,omitempty"`
+	EnvFile        []string          `yaml:"envFile,omitempty"`
+
+	// SuiteTags are the tags that the suite is selected by with --suite-tags. Tags, on the other hand, select the
+	// tests that the suite runs.
+	SuiteTags []string `yaml:"suiteTags,omitempty"`
 
 	// HookID is a technical ID unique to a project that's required by the APIs
 	// that execute API tests. The HookID is retrieved dynamically based on
@@ -59,6 +66,17 @@ func FromFile(cfgPath string) (Project, error) {
 	if err := config.Unmarshal(cfgPath, &p); err != nil {
 		return p, err
 	}
+
+	var err error
+	if p.Env, err = config.ResolveEnvFiles(p.Env, p.EnvFile); err != nil {
+		return p, err
+	}
+	for i := range p.Suites {
+		s := &p.Suites[i]
+		if s.Env, err = config.ResolveEnvFiles(s.Env, s.EnvFile); err != nil {
+			return p, fmt.Errorf("suite '%s': %w", s.Name, err)
+		}
+	}
 	p.ConfigFilePath = cfgPath
 
 	return p, nil
@@ -84,18 +102,11 @@ func SetDefaults(p *Project) {
 		p.RootDir = "."
 	}
 
-	// Apply global env var onto every suite.
+	// Apply global env vars onto every suite.
 	// Precedence: --env flag > root-level env vars > suite-level env vars.
-	for _, env := range []map[string]string{p.Env, p.EnvFlag} {
-		for k, v := range env {
-			for ks := range p.Suites {
-				s := &p.Suites[ks]
-				if s.Env == nil {
-					s.Env = map[string]string{}
-				}
-				s.Env[k] = v
-			}
-		}
+	// Env vars from an envFile have lower precedence than env vars on the same level.
+	for i := range p.Suites {
+		p.Suites[i].Env = config.MergeEnv(p.Suites[i].Env, p.Env, p.EnvFlag)
 	}
 }
 
@@ -123,15 +123,20 @@ type VaultFile struct {
 	IsOpenAPI bool   `json:"isOpenAPI"`
 }
 
-// FilterSuites filters out suites in the project that don't match the given suite name.
-func FilterSuites(p *Project, suiteName string) error {
+// FilterSuites filters out suites in the project that aren't matched by the given selector. Suites are selected by
+// their SuiteTags, since their Tags select the tests that they run.
+func FilterSuites(p *Project, sel config.SuiteSelector) error {
+	var suites []Suite
 	for _, s := range p.Suites {
-		if s.Name == suiteName {
-			p.Suites = []Suite{s}
-			return nil
+		if sel.Match(s.Name, s.SuiteTags) {
+			suites = append(suites, s)
 		}
 	}
-	return fmt.Errorf(msg.SuiteNameNotFound, suiteName)
+	if len(suites) == 0 {
+		return sel.NoMatchError()
+	}
+	p.Suites = suites
+	return nil
 }
 
 // RunProject runs the tests defined in apitest.Project
@@ -624,3 +624,21 @@ func TestRunner_ResolveHookIDs(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterSuites(t *testing.T) {
+	p := Project{Suites: []Suite{
+		{Name: "login", SuiteTags: []string{"smoke"}, Tags: []string{"critical"}},
+		{Name: "checkout", SuiteTags: []string{"smoke", "slow"}},
+		{Name: "search", Tags: []string{"smoke"}},
+	}}
+
+	sel, err := config.NewSuiteSelector(nil, nil, []string{"smoke", "!slow"})
+	assert.NoError(t, err)
+	assert.NoError(t, FilterSuites(&p, sel))
+	// The tags that select tests neither select suites, nor are they affected by the selection.
+	assert.Equal(t, []Suite{{Name: "login", SuiteTags: []string{"smoke"}, Tags: []string{"critical"}}}, p.Suites)
+
+	sel, err = config.NewSuiteSelector(nil, nil, []string{"critical"})
+	assert.NoError(t, err)
+	assert.Error(t, FilterSuites(&p, sel))
+}
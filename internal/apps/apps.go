@@ -49,6 +49,18 @@ func NormalizeStorageReference(ref string) string {
 	return ref
 }
 
+// ParseStorageReference returns the file ID or the filename that ref refers to.
+// ok is false if ref is not a storage reference.
+func ParseStorageReference(ref string) (fileID string, filename string, ok bool) {
+	if m := reFileID.FindStringSubmatch(ref); m != nil && (strings.HasPrefix(ref, "storage:") || ref == m[0]) {
+		return m[reFileID.SubexpIndex("fileID")], "", true
+	}
+	if m := reFilePattern.FindStringSubmatch(ref); m != nil {
+		return "", m[reFilePattern.SubexpIndex("filename")], true
+	}
+	return "", "", false
+}
+
 // Validate validates that app is valid (storageID / File / URL).
 func Validate(kind, app string, validExt []string) error {
 	if IsStorageReference(app) {
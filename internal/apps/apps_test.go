@@ -263,3 +263,55 @@ func TestStandardizeReferenceLink(t *testing.T) {
 		})
 	}
 }
+
+func TestParseStorageReference(t *testing.T) {
+	tests := []struct {
+		name         string
+		ref          string
+		wantFileID   string
+		wantFilename string
+		wantOK       bool
+	}{
+		{
+			name:       "Only ID",
+			ref:        "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+			wantFileID: "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+			wantOK:     true,
+		},
+		{
+			name:       "storage:ID",
+			ref:        "storage:aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+			wantFileID: "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+			wantOK:     true,
+		},
+		{
+			name:       "storage://ID",
+			ref:        "storage://aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+			wantFileID: "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+			wantOK:     true,
+		},
+		{
+			name:         "storage:filename",
+			ref:          "storage:filename=my app.apk",
+			wantFilename: "my app.apk",
+			wantOK:       true,
+		},
+		{
+			name: "local file",
+			ref:  "./apps/aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+		},
+		{
+			name: "unsupported filename",
+			ref:  "storage:filename=app.exe",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fileID, filename, ok := ParseStorageReference(tt.ref)
+			if fileID != tt.wantFileID || filename != tt.wantFilename || ok != tt.wantOK {
+				t.Errorf("ParseStorageReference() = %q, %q, %v; want %q, %q, %v",
+					fileID, filename, ok, tt.wantFileID, tt.wantFilename, tt.wantOK)
+			}
+		})
+	}
+}
@@ -1,13 +1,116 @@
 package archive
 
 import (
+	"fmt"
 	"io"
+
+	"github.com/saucelabs/saucectl/internal/archive/tar"
+	"github.com/saucelabs/saucectl/internal/archive/walk"
+	"github.com/saucelabs/saucectl/internal/archive/zip"
+	"github.com/saucelabs/saucectl/internal/sauceignore"
 )
 
 // Writer represents an archive writer.
 type Writer interface {
 	io.Closer
 
-	// Add adds the src file to the destination dst in the archive.
-	Add(src, dst string) error
+	// Add adds the src file to the destination dst in the archive and returns a count of the files added to the
+	// archive, as well the length of the longest path.
+	Add(src, dst string) (count int, length int, err error)
+}
+
+// Format represents the format of an archive.
+type Format string
+
+// Supported archive formats.
+const (
+	FormatZip    Format = "zip"
+	FormatTarGz  Format = "tar.gz"
+	FormatTarZst Format = "tar.zst"
+)
+
+// Extension returns the file extension of the format, including the leading dot.
+func (f Format) Extension() string {
+	if f == "" {
+		return ".zip"
+	}
+	return "." + string(f)
+}
+
+// Options represents the options for creating archives.
+type Options struct {
+	// Format is the archive format. Defaults to FormatZip.
+	Format Format `yaml:"format,omitempty" json:"format,omitempty"`
+	// Level is the compression level. The valid range depends on the format: 1-9 for zip and tar.gz, 1-22 for
+	// tar.zst. Defaults to the format's default level.
+	Level int `yaml:"level,omitempty" json:"level,omitempty"`
+	// Symlinks defines how symbolic links are archived. Defaults to walk.SymlinkFollow.
+	Symlinks walk.SymlinkPolicy `yaml:"symlinks,omitempty" json:"symlinks,omitempty"`
+	// Permissions defines how permission bits are archived. Defaults to walk.PermissionNormalize.
+	Permissions walk.PermissionPolicy `yaml:"permissions,omitempty" json:"permissions,omitempty"`
+	// LongPaths defines how paths that exceed MaxPathLength are handled. Defaults to LongPathsWarn.
+	LongPaths LongPathPolicy `yaml:"longPaths,omitempty" json:"longPaths,omitempty"`
+	// MaxPathLength is the maximum length of a path in the archive, which is enforced if LongPaths is
+	// LongPathsError. It's not configurable, but depends on where the archive is unpacked.
+	MaxPathLength int `yaml:"-" json:"-"`
+}
+
+// LongPathPolicy defines how paths that are too long are handled.
+type LongPathPolicy string
+
+// Supported long path policies.
+const (
+	// LongPathsWarn warns once the archive has been created.
+	LongPathsWarn LongPathPolicy = "warn"
+	// LongPathsError fails as soon as a path that's too long is encountered.
+	LongPathsError LongPathPolicy = "error"
+)
+
+// walkOptions returns the options for walking the files to be archived.
+func (o Options) walkOptions() walk.Options {
+	opts := walk.Options{Symlinks: o.Symlinks, Permissions: o.Permissions}
+	if o.LongPaths == LongPathsError {
+		opts.MaxPathLength = o.MaxPathLength
+	}
+	return opts
+}
+
+// Validate checks whether the options are valid.
+func (o Options) Validate() error {
+	switch o.LongPaths {
+	case "", LongPathsWarn, LongPathsError:
+	default:
+		return fmt.Errorf("unknown long path policy %q; options: %s, %s", o.LongPaths, LongPathsWarn, LongPathsError)
+	}
+
+	if err := o.walkOptions().Validate(); err != nil {
+		return err
+	}
+
+	w, err := NewWriter(io.Discard, sauceignore.NewMatcher(nil), o)
+	if err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+// NewWriter returns a Writer that archives files to w according to opts.
+func NewWriter(w io.Writer, matcher sauceignore.Matcher, opts Options) (Writer, error) {
+	switch opts.Format {
+	case "", FormatZip:
+		z, err := zip.NewWithLevel(w, matcher, opts.Level)
+		z.Policy = opts.walkOptions()
+		return &z, err
+	case FormatTarGz:
+		t, err := tar.New(w, matcher, tar.Gzip, opts.Level)
+		t.Policy = opts.walkOptions()
+		return &t, err
+	case FormatTarZst:
+		t, err := tar.New(w, matcher, tar.Zstd, opts.Level)
+		t.Policy = opts.walkOptions()
+		return &t, err
+	default:
+		return nil, fmt.Errorf("unknown archive format %q; options: %s, %s, %s", opts.Format,
+			FormatZip, FormatTarGz, FormatTarZst)
+	}
 }
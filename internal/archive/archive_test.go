@@ -0,0 +1,58 @@
+package archive
+
+import (
+	"testing"
+
+	"github.com/saucelabs/saucectl/internal/archive/walk"
+)
+
+func TestOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr bool
+	}{
+		{name: "defaults", opts: Options{}},
+		{name: "zip", opts: Options{Format: FormatZip, Level: 9}},
+		{name: "tar.gz", opts: Options{Format: FormatTarGz, Level: 1}},
+		{name: "tar.zst", opts: Options{Format: FormatTarZst, Level: 19}},
+		{name: "unknown format", opts: Options{Format: "rar"}, wantErr: true},
+		{name: "zip level out of range", opts: Options{Format: FormatZip, Level: 10}, wantErr: true},
+		{name: "tar.zst level out of range", opts: Options{Format: FormatTarZst, Level: 23}, wantErr: true},
+		{name: "policies", opts: Options{Symlinks: walk.SymlinkError, Permissions: walk.PermissionPreserve, LongPaths: LongPathsError}},
+		{name: "unknown symlink policy", opts: Options{Symlinks: "skip"}, wantErr: true},
+		{name: "unknown long path policy", opts: Options{LongPaths: "truncate"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.opts.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFormat_Extension(t *testing.T) {
+	for format, want := range map[Format]string{
+		"":           ".zip",
+		FormatZip:    ".zip",
+		FormatTarGz:  ".tar.gz",
+		FormatTarZst: ".tar.zst",
+	} {
+		if got := format.Extension(); got != want {
+			t.Errorf("Extension() = %s, want %s", got, want)
+		}
+	}
+}
+
+func TestOptions_walkOptions(t *testing.T) {
+	opts := Options{Symlinks: walk.SymlinkPreserve, MaxPathLength: 200}
+	if got := opts.walkOptions(); got != (walk.Options{Symlinks: walk.SymlinkPreserve}) {
+		t.Errorf("walkOptions() = %v; the path length must only be enforced by the error policy", got)
+	}
+
+	opts.LongPaths = LongPathsError
+	if got := opts.walkOptions(); got.MaxPathLength != 200 {
+		t.Errorf("walkOptions().MaxPathLength = %d, want 200", got.MaxPathLength)
+	}
+}
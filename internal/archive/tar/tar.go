@@ -3,15 +3,141 @@ package tar
 import (
 	"archive/tar"
 	"bytes"
+	"compress/gzip"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/rs/zerolog/log"
+	"github.com/saucelabs/saucectl/internal/archive/walk"
 	"github.com/saucelabs/saucectl/internal/sauceignore"
 )
 
+// Compression represents the compression that's applied to a tar archive.
+type Compression string
+
+// Supported compressions.
+const (
+	Gzip Compression = "gzip"
+	Zstd Compression = "zstd"
+)
+
+// modTime is the modification time of every entry in a streamed archive. It's fixed so that archives of identical
+// content are byte-identical, regardless of when they were created.
+var modTime = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Writer archives files as a compressed tar stream and mirrors the behavior of zip.Writer.
+type Writer struct {
+	W *tar.Writer
+	M sauceignore.Matcher
+	// Policy defines how symbolic links, permissions and long paths are handled.
+	Policy walk.Options
+
+	compressor io.WriteCloser
+}
+
+// New returns a new Writer that archives files to w with the given compression. A level of 0 selects the default
+// compression level. Valid levels are 1-9 for gzip, and 1-22 for zstd.
+func New(w io.Writer, matcher sauceignore.Matcher, compression Compression, level int) (Writer, error) {
+	var compressor io.WriteCloser
+	var err error
+
+	switch compression {
+	case Gzip:
+		if level == 0 {
+			level = gzip.DefaultCompression
+		}
+		compressor, err = gzip.NewWriterLevel(w, level)
+	case Zstd:
+		if level < 0 || level > 22 {
+			return Writer{}, fmt.Errorf("zstd: invalid compression level: %d", level)
+		}
+		zlevel := zstd.SpeedDefault
+		if level > 0 {
+			zlevel = zstd.EncoderLevelFromZstd(level)
+		}
+		compressor, err = zstd.NewWriter(w, zstd.WithEncoderLevel(zlevel))
+	default:
+		return Writer{}, fmt.Errorf("unknown compression: %s", compression)
+	}
+	if err != nil {
+		return Writer{}, err
+	}
+
+	return Writer{W: tar.NewWriter(compressor), M: matcher, compressor: compressor}, nil
+}
+
+// Add adds the file at src to the destination dst in the archive and returns a count of
+// the files added to the archive, as well the length of the longest path.
+func (w *Writer) Add(src, dst string) (count int, length int, err error) {
+	err = walk.Walk(src, dst, w.M, w.Policy, func(e walk.Entry) error {
+		if err := w.add(e); err != nil {
+			return err
+		}
+
+		count++
+		if !e.Mode.IsDir() && len(e.Name) > length {
+			length = len(e.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return count, length, nil
+}
+
+// add writes the entry to the archive. The attributes that depend on when and where the file was created, i.e. the
+// modification time and ownership, are stripped.
+func (w *Writer) add(e walk.Entry) error {
+	h := &tar.Header{
+		Name:    e.Name,
+		ModTime: modTime,
+		Mode:    int64(e.Mode.Perm()),
+	}
+
+	switch {
+	case e.Mode.IsDir():
+		h.Typeflag = tar.TypeDir
+	case e.Mode&os.ModeSymlink != 0:
+		h.Typeflag = tar.TypeSymlink
+		h.Linkname = e.Linkname
+	default:
+		h.Typeflag = tar.TypeReg
+		h.Size = e.Size
+	}
+
+	if err := w.W.WriteHeader(h); err != nil {
+		return err
+	}
+	if h.Typeflag != tar.TypeReg {
+		return nil
+	}
+
+	f, err := os.Open(e.Path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// Copy exactly the size that's been announced in the header, even if the file has changed since.
+	_, err = io.CopyN(w.W, f, e.Size)
+	return err
+}
+
+// Close finishes the archive. It doesn't close the underlying writer.
+func (w *Writer) Close() error {
+	if err := w.W.Close(); err != nil {
+		return err
+	}
+	return w.compressor.Close()
+}
+
 // Options represents the options applied when archiving files.
 type Options struct {
 	Permission *Permission
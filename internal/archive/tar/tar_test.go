@@ -2,14 +2,19 @@ package tar
 
 import (
 	archTar "archive/tar"
+	"bytes"
+	"compress/gzip"
 	"io"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/klauspost/compress/zstd"
+
 	"gotest.tools/v3/assert"
 	"gotest.tools/v3/fs"
 
+	"github.com/saucelabs/saucectl/internal/archive/walk"
 	"github.com/saucelabs/saucectl/internal/sauceignore"
 )
 
@@ -120,3 +125,108 @@ func TestArchive(t *testing.T) {
 		})
 	}
 }
+
+func TestWriter_Add(t *testing.T) {
+	dir := fs.NewDir(t, "tests",
+		fs.WithDir("screenshots", fs.WithFile("screenshot1.png", "foo", fs.WithMode(0600))),
+		fs.WithFile("some.foo.js", "foo", fs.WithMode(0755)),
+		fs.WithFile("some.other.bar.js", "bar", fs.WithMode(0600)))
+	defer dir.Remove()
+
+	dirBase := filepath.Base(dir.Path())
+	matcher := sauceignore.NewMatcher([]sauceignore.Pattern{sauceignore.NewPattern("some.other.bar.js")})
+
+	decompressors := map[Compression]func(r io.Reader) (io.Reader, error){
+		Gzip: func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+		Zstd: func(r io.Reader) (io.Reader, error) { return zstd.NewReader(r) },
+	}
+
+	for compression, decompress := range decompressors {
+		t.Run(string(compression), func(t *testing.T) {
+			archive := func() []byte {
+				buf := &bytes.Buffer{}
+				w, err := New(buf, matcher, compression, 0)
+				assert.NilError(t, err)
+				count, length, err := w.Add(dir.Path(), "")
+				assert.NilError(t, err)
+				assert.Equal(t, 4, count)
+				assert.Equal(t, len(dirBase+"/screenshots/screenshot1.png"), length)
+				assert.NilError(t, w.Close())
+				return buf.Bytes()
+			}
+
+			b := archive()
+			assert.DeepEqual(t, b, archive())
+
+			r, err := decompress(bytes.NewReader(b))
+			assert.NilError(t, err)
+
+			modes := map[string]int64{}
+			tr := archTar.NewReader(r)
+			for {
+				h, err := tr.Next()
+				if err == io.EOF {
+					break
+				}
+				assert.NilError(t, err)
+				assert.Equal(t, modTime.Unix(), h.ModTime.Unix())
+				modes[h.Name] = h.Mode
+			}
+
+			assert.DeepEqual(t, map[string]int64{
+				dirBase + "/":                            0755,
+				dirBase + "/screenshots/":                0755,
+				dirBase + "/screenshots/screenshot1.png": 0644,
+				dirBase + "/some.foo.js":                 0755,
+			}, modes)
+		})
+	}
+}
+
+func TestNew_InvalidLevel(t *testing.T) {
+	_, err := New(io.Discard, sauceignore.NewMatcher(nil), Gzip, 10)
+	assert.ErrorContains(t, err, "invalid compression level")
+
+	_, err = New(io.Discard, sauceignore.NewMatcher(nil), Zstd, 23)
+	assert.ErrorContains(t, err, "invalid compression level")
+}
+
+func TestWriter_Add_Policy(t *testing.T) {
+	dir := fs.NewDir(t, "policy",
+		fs.WithDir("project",
+			fs.WithFile("run.sh", "#!/bin/sh", fs.WithMode(0750)),
+		),
+	)
+	defer dir.Remove()
+	if err := os.Symlink("run.sh", dir.Join("project", "start.sh")); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	w, err := New(buf, sauceignore.NewMatcher([]sauceignore.Pattern{}), Gzip, 0)
+	assert.NilError(t, err)
+	w.Policy = walk.Options{Symlinks: walk.SymlinkPreserve, Permissions: walk.PermissionPreserve}
+	_, _, err = w.Add(dir.Join("project", "run.sh"), "project")
+	assert.NilError(t, err)
+	_, _, err = w.Add(dir.Join("project", "start.sh"), "project")
+	assert.NilError(t, err)
+	assert.NilError(t, w.Close())
+
+	gr, err := gzip.NewReader(buf)
+	assert.NilError(t, err)
+
+	tr := archTar.NewReader(gr)
+	headers := map[string]*archTar.Header{}
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NilError(t, err)
+		headers[h.Name] = h
+	}
+
+	assert.Equal(t, int64(0750), headers["project/run.sh"].Mode)
+	assert.Equal(t, byte(archTar.TypeSymlink), headers["project/start.sh"].Typeflag)
+	assert.Equal(t, "run.sh", headers["project/start.sh"].Linkname)
+}
@@ -0,0 +1,231 @@
+// Package walk walks the file tree that's to be archived and applies the policies for symbolic links, permissions
+// and path lengths, so that all archive formats treat files alike.
+package walk
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/saucelabs/saucectl/internal/sauceignore"
+)
+
+// SymlinkPolicy defines how symbolic links are archived.
+type SymlinkPolicy string
+
+// Supported symlink policies.
+const (
+	// SymlinkFollow archives the target of a link as if it were located at the link. This is the default.
+	SymlinkFollow SymlinkPolicy = "follow"
+	// SymlinkPreserve archives the link itself. Links must point to a location within the archive.
+	SymlinkPreserve SymlinkPolicy = "preserve"
+	// SymlinkError fails on any link.
+	SymlinkError SymlinkPolicy = "error"
+)
+
+// PermissionPolicy defines how permission bits are archived.
+type PermissionPolicy string
+
+// Supported permission policies.
+const (
+	// PermissionNormalize archives files with 0644, or 0755 if they are executable by anyone. This is the default.
+	PermissionNormalize PermissionPolicy = "normalize"
+	// PermissionPreserve archives the permission bits as they are.
+	PermissionPreserve PermissionPolicy = "preserve"
+)
+
+// Options represents the policies that are applied while walking.
+type Options struct {
+	Symlinks    SymlinkPolicy
+	Permissions PermissionPolicy
+	// MaxPathLength is the maximum length of a name in the archive. Unlimited if 0.
+	MaxPathLength int
+}
+
+// Validate checks whether the options are valid.
+func (o Options) Validate() error {
+	switch o.Symlinks {
+	case "", SymlinkFollow, SymlinkPreserve, SymlinkError:
+	default:
+		return fmt.Errorf("unknown symlink policy %q; options: %s, %s, %s", o.Symlinks,
+			SymlinkFollow, SymlinkPreserve, SymlinkError)
+	}
+
+	switch o.Permissions {
+	case "", PermissionNormalize, PermissionPreserve:
+	default:
+		return fmt.Errorf("unknown permission policy %q; options: %s, %s", o.Permissions,
+			PermissionNormalize, PermissionPreserve)
+	}
+
+	return nil
+}
+
+// Entry is a file, directory or symbolic link that's to be archived.
+type Entry struct {
+	// Path is the location of the entry on disk. For followed links, it's the location of the link.
+	Path string
+	// Name is the slash separated name of the entry in the archive. Directory names end with a slash.
+	Name string
+	// Mode holds the type (regular file, directory or symbolic link) and the permission bits of the entry.
+	Mode os.FileMode
+	// Size is the size of regular files.
+	Size int64
+	// Linkname is the target of a preserved symbolic link.
+	Linkname string
+}
+
+// IsRegular reports whether the entry is a regular file.
+func (e Entry) IsRegular() bool {
+	return e.Mode.IsRegular()
+}
+
+// Walk walks the file tree rooted at src and calls fn for every entry that isn't ignored by matcher. Entries are
+// named as if src were located in dst. Ignored directories are still traversed if negated patterns may re-include
+// some of their contents.
+func Walk(src, dst string, matcher sauceignore.Matcher, opts Options, fn func(Entry) error) error {
+	return walk(src, dst, matcher, opts, nil, fn)
+}
+
+// walk implements Walk. ancestors are the directories that src is located in, which is used to detect loops.
+func walk(src, dst string, matcher sauceignore.Matcher, opts Options, ancestors []os.FileInfo, fn func(Entry) error) error {
+	finfo, err := os.Lstat(src)
+	if err != nil {
+		return err
+	}
+
+	isLink := finfo.Mode()&os.ModeSymlink != 0
+	isDir := finfo.IsDir()
+	var target os.FileInfo
+	var targetErr error
+	if isLink {
+		// Dangling links are only an error if they are to be followed.
+		if target, targetErr = os.Stat(src); targetErr == nil {
+			isDir = target.IsDir()
+		}
+	}
+
+	segments := strings.Split(src, string(os.PathSeparator))
+	ignored := matcher.Match(segments, isDir)
+	if ignored && !(isDir && matcher.MayReinclude(segments)) {
+		return nil
+	}
+
+	name := path.Join(dst, filepath.Base(src))
+
+	if isLink {
+		switch opts.Symlinks {
+		case SymlinkError:
+			if ignored {
+				return nil
+			}
+			return fmt.Errorf("%s is a symbolic link, which isn't allowed by the symlink policy '%s'", src, SymlinkError)
+		case SymlinkPreserve:
+			if ignored {
+				return nil
+			}
+			return preserveLink(src, name, opts, fn)
+		default:
+			if targetErr != nil {
+				return fmt.Errorf("unable to follow symbolic link %s: %w", src, targetErr)
+			}
+			finfo = target
+		}
+	}
+
+	if isDir {
+		// The trailing slash denotes a directory entry.
+		name += "/"
+	}
+
+	if !ignored {
+		if err := checkLength(name, opts); err != nil {
+			return err
+		}
+
+		log.Debug().Str("name", src).Msg("Adding to archive")
+		e := Entry{Path: src, Name: name, Mode: mode(finfo, opts)}
+		if !isDir {
+			e.Size = finfo.Size()
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+
+	if !isDir {
+		return nil
+	}
+
+	for _, a := range ancestors {
+		if os.SameFile(a, finfo) {
+			return fmt.Errorf("symbolic link loop detected: %s refers to one of its parent directories", src)
+		}
+	}
+
+	files, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+
+	ancestors = append(ancestors, finfo)
+	for _, f := range files {
+		if err := walk(filepath.Join(src, f.Name()), path.Join(dst, filepath.Base(src)), matcher, opts, ancestors, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// preserveLink calls fn with an entry for the symbolic link at src. Links must point to a location within the archive,
+// since they'd be broken once the archive is unpacked otherwise.
+func preserveLink(src, name string, opts Options, fn func(Entry) error) error {
+	linkname, err := os.Readlink(src)
+	if err != nil {
+		return err
+	}
+
+	linkname = filepath.ToSlash(linkname)
+	if path.IsAbs(linkname) || filepath.IsAbs(linkname) {
+		return fmt.Errorf("symbolic link %s points to the absolute path %s, which can't be preserved; use the symlink policy '%s' instead",
+			src, linkname, SymlinkFollow)
+	}
+	if resolved := path.Join(path.Dir(name), linkname); resolved == ".." || strings.HasPrefix(resolved, "../") {
+		return fmt.Errorf("symbolic link %s points outside of the archive (%s), which can't be preserved; use the symlink policy '%s' instead",
+			src, linkname, SymlinkFollow)
+	}
+
+	if err := checkLength(name, opts); err != nil {
+		return err
+	}
+
+	log.Debug().Str("name", src).Str("target", linkname).Msg("Adding symbolic link to archive")
+	return fn(Entry{Path: src, Name: name, Mode: os.ModeSymlink | 0777, Linkname: linkname})
+}
+
+// checkLength fails if the name exceeds the maximum path length.
+func checkLength(name string, opts Options) error {
+	if opts.MaxPathLength > 0 && len(name) > opts.MaxPathLength {
+		return fmt.Errorf("path %s is %d characters long, which exceeds the limit of %d characters",
+			name, len(name), opts.MaxPathLength)
+	}
+	return nil
+}
+
+// mode returns the type and permission bits of the file to be archived.
+func mode(finfo os.FileInfo, opts Options) os.FileMode {
+	typ := finfo.Mode().Type() & os.ModeDir
+
+	if opts.Permissions == PermissionPreserve {
+		return typ | finfo.Mode().Perm()
+	}
+
+	if finfo.IsDir() || finfo.Mode().Perm()&0111 != 0 {
+		return typ | 0755
+	}
+	return typ | 0644
+}
@@ -0,0 +1,264 @@
+package walk
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gotest.tools/v3/fs"
+
+	"github.com/saucelabs/saucectl/internal/sauceignore"
+)
+
+// collect walks src and returns the entries by name.
+func collect(src string, opts Options, patterns ...string) (map[string]Entry, error) {
+	var pp []sauceignore.Pattern
+	for _, p := range patterns {
+		pp = append(pp, sauceignore.NewPattern(p))
+	}
+
+	entries := map[string]Entry{}
+	err := Walk(src, "", sauceignore.NewMatcher(pp), opts, func(e Entry) error {
+		entries[e.Name] = e
+		return nil
+	})
+	return entries, err
+}
+
+// relSymlink creates a symbolic link with a target that's relative to the link.
+func relSymlink(name, target string) fs.PathOp {
+	return func(p fs.Path) error {
+		return os.Symlink(target, filepath.Join(p.Path(), name))
+	}
+}
+
+// newProject creates a project that resembles a pnpm workspace, in which dependencies are symbolic links.
+func newProject(t *testing.T) string {
+	t.Helper()
+
+	dir := fs.NewDir(t, "walk",
+		fs.WithDir("project",
+			fs.WithDir("packages",
+				fs.WithDir("utils", fs.WithFile("index.js", "utils")),
+			),
+			fs.WithDir("node_modules",
+				relSymlink("utils", "../packages/utils"),
+			),
+			fs.WithFile("run.sh", "#!/bin/sh", fs.WithMode(0750)),
+			fs.WithFile("package.json", "{}", fs.WithMode(0600)),
+			relSymlink("config.json", "package.json"),
+		),
+	)
+	t.Cleanup(dir.Remove)
+
+	return dir.Join("project")
+}
+
+func TestWalk_Symlinks(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    SymlinkPolicy
+		want      map[string]Entry
+		wantErr   string
+		ignoreLnk bool
+	}{
+		{
+			name:   "follow",
+			policy: SymlinkFollow,
+			want: map[string]Entry{
+				"project/config.json":                 {Mode: 0644, Size: 2},
+				"project/node_modules/utils/":         {Mode: os.ModeDir | 0755},
+				"project/node_modules/utils/index.js": {Mode: 0644, Size: 5},
+			},
+		},
+		{
+			name:   "default follows",
+			policy: "",
+			want: map[string]Entry{
+				"project/config.json":                 {Mode: 0644, Size: 2},
+				"project/node_modules/utils/":         {Mode: os.ModeDir | 0755},
+				"project/node_modules/utils/index.js": {Mode: 0644, Size: 5},
+			},
+		},
+		{
+			name:   "preserve",
+			policy: SymlinkPreserve,
+			want: map[string]Entry{
+				"project/config.json":        {Mode: os.ModeSymlink | 0777, Linkname: "package.json"},
+				"project/node_modules/utils": {Mode: os.ModeSymlink | 0777, Linkname: "../packages/utils"},
+			},
+		},
+		{
+			name:    "error",
+			policy:  SymlinkError,
+			wantErr: "is a symbolic link",
+		},
+		{
+			name:      "error ignores ignored links",
+			policy:    SymlinkError,
+			ignoreLnk: true,
+			want:      map[string]Entry{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patterns []string
+			if tt.ignoreLnk {
+				patterns = []string{"config.json", "node_modules/"}
+			}
+
+			got, err := collect(newProject(t), Options{Symlinks: tt.policy}, patterns...)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+
+			for name, want := range tt.want {
+				e, ok := got[name]
+				if !assert.True(t, ok, "missing entry %s", name) {
+					continue
+				}
+				assert.Equal(t, want.Mode, e.Mode, name)
+				assert.Equal(t, want.Size, e.Size, name)
+				assert.Equal(t, want.Linkname, e.Linkname, name)
+			}
+			if tt.policy == SymlinkPreserve {
+				assert.NotContains(t, got, "project/node_modules/utils/index.js")
+			}
+			if tt.ignoreLnk {
+				assert.NotContains(t, got, "project/config.json")
+				assert.NotContains(t, got, "project/node_modules/utils")
+			}
+		})
+	}
+}
+
+func TestWalk_SymlinkTargets(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		policy  SymlinkPolicy
+		wantErr string
+	}{
+		{name: "follow loop", target: "..", policy: SymlinkFollow, wantErr: "loop detected"},
+		{name: "preserve loop", target: "..", policy: SymlinkPreserve},
+		{name: "follow dangling", target: "missing", policy: SymlinkFollow, wantErr: "unable to follow"},
+		{name: "preserve dangling", target: "missing", policy: SymlinkPreserve},
+		{name: "follow outside", target: "../../outside", policy: SymlinkFollow},
+		{name: "preserve outside", target: "../../../outside", policy: SymlinkPreserve, wantErr: "points outside of the archive"},
+		{name: "preserve absolute", target: "/etc", policy: SymlinkPreserve, wantErr: "absolute path"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := fs.NewDir(t, "walk",
+				fs.WithDir("outside", fs.WithFile("file.txt", "outside")),
+				fs.WithDir("project",
+					fs.WithDir("sub", relSymlink("link", tt.target)),
+				),
+			)
+			defer dir.Remove()
+
+			_, err := collect(dir.Join("project"), Options{Symlinks: tt.policy})
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestWalk_Permissions(t *testing.T) {
+	tests := []struct {
+		policy PermissionPolicy
+		want   map[string]os.FileMode
+	}{
+		{
+			policy: PermissionNormalize,
+			want: map[string]os.FileMode{
+				"project/":             os.ModeDir | 0755,
+				"project/run.sh":       0755,
+				"project/package.json": 0644,
+			},
+		},
+		{
+			policy: PermissionPreserve,
+			want: map[string]os.FileMode{
+				"project/":             os.ModeDir | 0700,
+				"project/run.sh":       0750,
+				"project/package.json": 0600,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			project := newProject(t)
+			if err := os.Chmod(project, 0700); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := collect(project, Options{Permissions: tt.policy})
+			assert.NoError(t, err)
+			for name, want := range tt.want {
+				assert.Equal(t, want, got[name].Mode, name)
+			}
+		})
+	}
+}
+
+func TestWalk_MaxPathLength(t *testing.T) {
+	longest := len("project/node_modules/utils/index.js")
+
+	tests := []struct {
+		name     string
+		max      int
+		patterns []string
+		wantErr  bool
+	}{
+		{name: "unlimited", max: 0},
+		{name: "within limit", max: longest},
+		{name: "exceeds limit", max: longest - 1, wantErr: true},
+		{name: "long path is ignored", max: longest - 1, patterns: []string{"index.js"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := collect(newProject(t), Options{MaxPathLength: tt.max}, tt.patterns...)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "exceeds the limit")
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestWalk_Reinclude(t *testing.T) {
+	project := newProject(t)
+
+	packages := func(patterns ...string) []string {
+		got, err := collect(project, Options{}, patterns...)
+		assert.NoError(t, err)
+
+		var names []string
+		for name := range got {
+			if strings.HasPrefix(name, "project/packages") {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"project/packages/utils/index.js"}, packages("packages/", "!**/utils/index.js"))
+	// Negated patterns without a slash don't reach into ignored directories, which therefore aren't walked.
+	assert.Empty(t, packages("packages/", "!index.js"))
+}
+
+func TestOptions_Validate(t *testing.T) {
+	assert.NoError(t, Options{}.Validate())
+	assert.NoError(t, Options{Symlinks: SymlinkPreserve, Permissions: PermissionPreserve}.Validate())
+	assert.Error(t, Options{Symlinks: "skip"}.Validate())
+	assert.Error(t, Options{Permissions: "strict"}.Validate())
+}
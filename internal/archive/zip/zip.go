@@ -2,22 +2,30 @@ package zip
 
 import (
 	"archive/zip"
+	"compress/flate"
 	"fmt"
 	"io"
 	"os"
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
-	"github.com/rs/zerolog/log"
+	"github.com/saucelabs/saucectl/internal/archive/walk"
 	"github.com/saucelabs/saucectl/internal/sauceignore"
 )
 
+// modTime is the modification time of every entry in the archive. It's fixed so that archives of identical content
+// are byte-identical, regardless of when they were created. It's the earliest time representable in the zip format.
+var modTime = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+
 // Writer is a wrapper around zip.Writer and implements zip archiving for archive.Writer.
 type Writer struct {
 	W       *zip.Writer
 	M       sauceignore.Matcher
 	ZipFile *os.File
+	// Policy defines how symbolic links, permissions and long paths are handled.
+	Policy walk.Options
 }
 
 // NewFileWriter returns a new Writer that archives files to name.
@@ -27,17 +35,41 @@ func NewFileWriter(name string, matcher sauceignore.Matcher) (Writer, error) {
 		return Writer{}, err
 	}
 
-	w := Writer{W: zip.NewWriter(f), M: matcher, ZipFile: f}
+	w := Writer{W: newZipWriter(f, flate.DefaultCompression), M: matcher, ZipFile: f}
 
 	return w, nil
 }
 
 // New returns a new Writer that archives files to the specified io.Writer.
 func New(f io.Writer, matcher sauceignore.Matcher) (Writer, error) {
-	w := Writer{W: zip.NewWriter(f), M: matcher}
+	w := Writer{W: newZipWriter(f, flate.DefaultCompression), M: matcher}
+	return w, nil
+}
+
+// NewWithLevel returns a new Writer that archives files to the specified io.Writer and compresses them with the
+// given level. A level of 0 selects the default compression level, otherwise valid levels are 1-9.
+func NewWithLevel(f io.Writer, matcher sauceignore.Matcher, level int) (Writer, error) {
+	if level == 0 {
+		level = flate.DefaultCompression
+	}
+	if level < flate.DefaultCompression || level > flate.BestCompression {
+		return Writer{}, fmt.Errorf("zip: invalid compression level: %d", level)
+	}
+
+	w := Writer{W: newZipWriter(f, level), M: matcher}
 	return w, nil
 }
 
+// newZipWriter returns a zip.Writer that compresses with a fixed compression level, so that the compressed output
+// doesn't depend on library defaults.
+func newZipWriter(w io.Writer, level int) *zip.Writer {
+	zw := zip.NewWriter(w)
+	zw.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
+		return flate.NewWriter(out, level)
+	})
+	return zw
+}
+
 // Add adds the file at src to the destination dst in the archive and returns a count of
 // the files added to the archive, as well the length of the longest path.
 // The added file names should not contain any backslashes according to the specification outlined in
@@ -45,81 +77,67 @@ func New(f io.Writer, matcher sauceignore.Matcher) (Writer, error) {
 // It's essential to adhere to this specification to ensure compatibility and
 // proper functioning of the files across different systems and platforms.
 func (w *Writer) Add(src, dst string) (count int, length int, err error) {
-	finfo, err := os.Stat(src)
-	if err != nil {
-		return 0, 0, err
-	}
-
-	// Only will be applied if we have .sauceignore file and have patterns to exclude files and folders
-	if w.M.Match(strings.Split(src, string(os.PathSeparator)), finfo.IsDir()) {
-		return 0, 0, nil
-	}
-
-	log.Debug().Str("name", src).Msg("Adding to archive")
-	target := path.Join(dst, finfo.Name())
-	if finfo.IsDir() {
-		// The trailing slash denotes a directory entry.
-		target = fmt.Sprintf("%s/", target)
-	}
-
-	finfoHeader, err := zip.FileInfoHeader(finfo)
-	if err != nil {
-		return 0, 0, err
-	}
+	err = walk.Walk(src, dst, w.M, w.Policy, func(e walk.Entry) error {
+		if err := w.add(e); err != nil {
+			return err
+		}
 
-	finfoHeader.Name = filepath.ToSlash(target)
-	finfoHeader.Method = zip.Deflate
-	fileWriter, err := w.W.CreateHeader(finfoHeader)
+		count++
+		if !e.Mode.IsDir() && len(e.Name) > length {
+			length = len(e.Name)
+		}
+		return nil
+	})
 	if err != nil {
 		return 0, 0, err
 	}
-	count++
-
-	if !finfo.IsDir() {
-		f, err := os.Open(src)
-		if err != nil {
-			return 0, 0, err
-		}
-
-		if _, err := io.Copy(fileWriter, f); err != nil {
-			return 0, 0, err
-		}
 
-		if err := f.Close(); err != nil {
-			return 0, 0, err
-		}
+	return count, length, nil
+}
 
-		return count, len(target), err
+// add writes the entry to the archive. The attributes that depend on when the file was created, i.e. the
+// modification time, are stripped.
+func (w *Writer) add(e walk.Entry) error {
+	h := &zip.FileHeader{
+		Name:     e.Name,
+		Method:   zip.Deflate,
+		Modified: modTime,
 	}
+	h.SetMode(e.Mode)
 
-	files, err := os.ReadDir(src)
+	fileWriter, err := w.W.CreateHeader(h)
 	if err != nil {
-		return 0, 0, err
+		return err
 	}
 
-	for _, f := range files {
-		base := filepath.Base(src)
-		rebase := path.Join(dst, base)
-		fpath := filepath.Join(src, f.Name())
-		fileCount, pathLength, err := w.Add(fpath, rebase)
+	switch {
+	case e.Mode&os.ModeSymlink != 0:
+		// Symbolic links store their target as content.
+		_, err = io.WriteString(fileWriter, e.Linkname)
+		return err
+	case e.IsRegular():
+		f, err := os.Open(e.Path)
 		if err != nil {
-			return 0, 0, err
+			return err
 		}
+		defer f.Close()
 
-		count += fileCount
-		if pathLength > length {
-			length = pathLength
-		}
+		_, err = io.Copy(fileWriter, f)
+		return err
+	default:
+		return nil
 	}
-
-	return count, length, nil
 }
 
 // Close closes the archive. Adding more files to the archive is not possible after this.
+// The underlying writer is only closed if it's a file that has been created by NewFileWriter.
 func (w *Writer) Close() error {
 	if err := w.W.Close(); err != nil {
 		return err
 	}
+	if w.ZipFile == nil {
+		return nil
+	}
 	return w.ZipFile.Close()
 }
 
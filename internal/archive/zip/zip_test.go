@@ -2,13 +2,17 @@ package zip
 
 import (
 	"archive/zip"
+	"bytes"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"gotest.tools/v3/fs"
 
+	"github.com/saucelabs/saucectl/internal/archive/walk"
 	"github.com/saucelabs/saucectl/internal/sauceignore"
 )
 
@@ -121,3 +125,226 @@ func TestZipper_Add(t *testing.T) {
 		})
 	}
 }
+
+func TestWriter_Add_Deterministic(t *testing.T) {
+	// Two copies of the same project, created at different times and with different permissions, as is typical when
+	// checking out the same commit on different CI machines.
+	newProject := func(prefix string, mtime time.Time, perm, execPerm os.FileMode) string {
+		dir := fs.NewDir(t, prefix,
+			fs.WithDir("project",
+				fs.WithDir("specs",
+					fs.WithFile("b.spec.js", "b", fs.WithMode(perm)),
+					fs.WithFile("a.spec.js", "a", fs.WithMode(perm)),
+				),
+				fs.WithFile("package.json", "{}", fs.WithMode(perm)),
+				fs.WithFile("run.sh", "#!/bin/sh", fs.WithMode(execPerm)),
+			),
+		)
+		t.Cleanup(dir.Remove)
+
+		err := filepath.Walk(dir.Join("project"), func(p string, _ os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			return os.Chtimes(p, mtime, mtime)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return dir.Join("project")
+	}
+
+	archive := func(src string) []byte {
+		var buf bytes.Buffer
+		z, err := New(&buf, sauceignore.NewMatcher([]sauceignore.Pattern{}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := z.Add(src, ""); err != nil {
+			t.Fatal(err)
+		}
+		if err := z.W.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return buf.Bytes()
+	}
+
+	first := archive(newProject("first", time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC), 0600, 0700))
+	second := archive(newProject("second", time.Now(), 0644, 0755))
+
+	if !bytes.Equal(first, second) {
+		t.Fatal("archives of identical content are not byte-identical")
+	}
+
+	r, err := zip.NewReader(bytes.NewReader(first), int64(len(first)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantModes := map[string]os.FileMode{
+		"project/":                os.ModeDir | 0755,
+		"project/package.json":    0644,
+		"project/run.sh":          0755,
+		"project/specs/":          os.ModeDir | 0755,
+		"project/specs/a.spec.js": 0644,
+		"project/specs/b.spec.js": 0644,
+	}
+	var names []string
+	for _, f := range r.File {
+		names = append(names, f.Name)
+		if !f.Modified.Equal(modTime) {
+			t.Errorf("%s: got modification time %v, want %v", f.Name, f.Modified, modTime)
+		}
+		if f.Mode() != wantModes[f.Name] {
+			t.Errorf("%s: got mode %v, want %v", f.Name, f.Mode(), wantModes[f.Name])
+		}
+	}
+	wantNames := []string{"project/", "project/package.json", "project/run.sh", "project/specs/", "project/specs/a.spec.js", "project/specs/b.spec.js"}
+	if strings.Join(names, ",") != strings.Join(wantNames, ",") {
+		t.Errorf("got entries %v, want %v", names, wantNames)
+	}
+}
+
+func TestWriter_Add_Negation(t *testing.T) {
+	dir := fs.NewDir(t, "negation",
+		fs.WithDir("project",
+			fs.WithDir("cypress",
+				fs.WithDir("e2e", fs.WithFile("login.cy.js", "login")),
+				fs.WithDir("fixtures", fs.WithFile("users.json", "{}")),
+				fs.WithDir("videos", fs.WithFile("login.mp4", "video")),
+			),
+			fs.WithFile("package.json", "{}"),
+		),
+	)
+	defer dir.Remove()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir.Path()); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	var buf bytes.Buffer
+	z, err := New(&buf, sauceignore.NewMatcher([]sauceignore.Pattern{
+		sauceignore.NewPattern("project/cypress/"),
+		sauceignore.NewPattern("!project/cypress/e2e/"),
+		sauceignore.NewPattern("!project/cypress/**/*.json"),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	count, _, err := z.Add("project", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := z.W.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range r.File {
+		names = append(names, f.Name)
+	}
+
+	wantNames := []string{
+		"project/",
+		"project/cypress/e2e/",
+		"project/cypress/e2e/login.cy.js",
+		"project/cypress/fixtures/users.json",
+		"project/package.json",
+	}
+	if strings.Join(names, ",") != strings.Join(wantNames, ",") {
+		t.Errorf("got files %v, want %v", names, wantNames)
+	}
+	if count != len(wantNames) {
+		t.Errorf("got count %d, want %d", count, len(wantNames))
+	}
+}
+
+func TestWriter_Add_Policy(t *testing.T) {
+	dir := fs.NewDir(t, "policy",
+		fs.WithDir("project",
+			fs.WithFile("run.sh", "#!/bin/sh", fs.WithMode(0750)),
+		),
+	)
+	defer dir.Remove()
+	if err := os.Symlink("run.sh", dir.Join("project", "start.sh")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		policy    walk.Options
+		wantModes map[string]os.FileMode
+		wantLink  string
+	}{
+		{
+			name:   "follow and normalize",
+			policy: walk.Options{},
+			wantModes: map[string]os.FileMode{
+				"project/":         os.ModeDir | 0755,
+				"project/run.sh":   0755,
+				"project/start.sh": 0755,
+			},
+			wantLink: "#!/bin/sh",
+		},
+		{
+			name:   "preserve",
+			policy: walk.Options{Symlinks: walk.SymlinkPreserve, Permissions: walk.PermissionPreserve},
+			wantModes: map[string]os.FileMode{
+				"project/":         os.ModeDir | 0700,
+				"project/run.sh":   0750,
+				"project/start.sh": os.ModeSymlink | 0777,
+			},
+			wantLink: "run.sh",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.Chmod(dir.Join("project"), 0700); err != nil {
+				t.Fatal(err)
+			}
+
+			var buf bytes.Buffer
+			z, err := New(&buf, sauceignore.NewMatcher([]sauceignore.Pattern{}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			z.Policy = tt.policy
+			if _, _, err := z.Add(dir.Join("project"), ""); err != nil {
+				t.Fatal(err)
+			}
+			if err := z.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, f := range r.File {
+				if f.Mode() != tt.wantModes[f.Name] {
+					t.Errorf("%s: got mode %v, want %v", f.Name, f.Mode(), tt.wantModes[f.Name])
+				}
+				if f.Name != "project/start.sh" {
+					continue
+				}
+				rc, err := f.Open()
+				if err != nil {
+					t.Fatal(err)
+				}
+				b, _ := io.ReadAll(rc)
+				_ = rc.Close()
+				if string(b) != tt.wantLink {
+					t.Errorf("%s: got content %q, want %q", f.Name, b, tt.wantLink)
+				}
+			}
+		})
+	}
+}
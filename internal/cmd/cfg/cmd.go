@@ -0,0 +1,26 @@
+package cfg
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// Command creates the `config` command.
+func Command(preRun func(cmd *cobra.Command, args []string)) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:              "config",
+		Short:            "Manage saucectl configuration files",
+		SilenceUsage:     true,
+		TraverseChildren: true,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			if preRun != nil {
+				preRun(cmd, args)
+			}
+		},
+	}
+
+	cmd.AddCommand(
+		MigrateCommand(),
+	)
+
+	return cmd
+}
@@ -0,0 +1,126 @@
+package cfg
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/pmezard/go-difflib/difflib"
+	cmds "github.com/saucelabs/saucectl/internal/cmd"
+	"github.com/saucelabs/saucectl/internal/config"
+	"github.com/saucelabs/saucectl/internal/cypress"
+	"github.com/saucelabs/saucectl/internal/segment"
+	"github.com/saucelabs/saucectl/internal/usage"
+	"github.com/saucelabs/saucectl/internal/yaml"
+	"github.com/spf13/cobra"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// migrators contains the config migrations, keyed by framework kind.
+var migrators = map[string]func(doc *yaml.Node) ([]string, error){
+	cypress.Kind: cypress.Migrate,
+}
+
+func MigrateCommand() *cobra.Command {
+	var cfgPath string
+	var out string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:          "migrate",
+		Short:        "Migrates a config file to the latest apiVersion.",
+		SilenceUsage: true,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			tracker := segment.DefaultTracker
+
+			go func() {
+				tracker.Collect(
+					cases.Title(language.English).String(cmds.FullName(cmd)),
+					usage.Properties{}.SetFlags(cmd.Flags()),
+				)
+				_ = tracker.Close()
+			}()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if out == "" {
+				out = cfgPath
+			}
+			return migrate(cfgPath, out, dryRun)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&cfgPath, "config", "c", filepath.Join(".sauce", "config.yml"), "The config file to migrate.")
+	flags.StringVarP(&out, "out", "o", "", "The file to write the migrated config to. Overwrites the config file if not set.")
+	flags.BoolVar(&dryRun, "dry-run", false, "Only print the changes without writing them.")
+
+	return cmd
+}
+
+func migrate(cfgPath, out string, dryRun bool) error {
+	td, err := config.Describe(cfgPath)
+	if err != nil {
+		return err
+	}
+
+	m, ok := migrators[td.Kind]
+	if !ok {
+		return fmt.Errorf("no migration available for kind '%s' (apiVersion %s)", td.Kind, td.APIVersion)
+	}
+
+	fi, err := os.Stat(cfgPath)
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(cfgPath)
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+
+	doc, err := yaml.ParseDocument(content)
+	if err != nil {
+		return fmt.Errorf("failed to parse config: %w", err)
+	}
+	warnings, err := m(doc)
+	if err != nil {
+		return err
+	}
+	migrated, err := yaml.EncodeDocument(doc)
+	if err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(content)),
+		B:        difflib.SplitLines(string(migrated)),
+		FromFile: cfgPath,
+		ToFile:   out,
+		Context:  3,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println(diff)
+
+	if len(warnings) > 0 {
+		color.Yellow("The following settings could not be migrated automatically:")
+		for _, w := range warnings {
+			color.Yellow("  - %s", w)
+		}
+		println()
+	}
+
+	if dryRun {
+		println("Dry run. No changes were written.")
+		return nil
+	}
+
+	if err := os.WriteFile(out, migrated, fi.Mode()); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	fmt.Printf("Migrated config written to %s\n", out)
+
+	return nil
+}
@@ -75,8 +75,8 @@ func runApitest(cmd *cobra.Command, isCLIDriven bool) (int, error) {
 }
 
 func applyApitestFlags(p *apitest.Project) error {
-	if gFlags.selectedSuite != "" {
-		if err := apitest.FilterSuites(p, gFlags.selectedSuite); err != nil {
+	if !gFlags.suiteSelector.IsZero() {
+		if err := apitest.FilterSuites(p, gFlags.suiteSelector); err != nil {
 			return err
 		}
 	}
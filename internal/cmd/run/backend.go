@@ -0,0 +1,66 @@
+package run
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/saucelabs/saucectl/internal/archive"
+	"github.com/saucelabs/saucectl/internal/config"
+	"github.com/saucelabs/saucectl/internal/cypress"
+	"github.com/saucelabs/saucectl/internal/docker"
+	"github.com/saucelabs/saucectl/internal/playwright"
+	"github.com/saucelabs/saucectl/internal/saucecloud"
+	"github.com/saucelabs/saucectl/internal/saucecloud/retry"
+	"github.com/saucelabs/saucectl/internal/testcafe"
+)
+
+// Backends that jobs can run on.
+const (
+	// backendSauce runs jobs in the Sauce Labs cloud.
+	backendSauce = "sauce"
+	// backendDocker runs jobs in local Docker containers.
+	backendDocker = "docker"
+)
+
+// checkBackend returns an error if the selected backend can't run the given kind of project.
+func checkBackend(kind string) error {
+	if gFlags.backend != backendDocker {
+		return nil
+	}
+	switch kind {
+	case cypress.Kind, playwright.Kind, testcafe.Kind:
+		return nil
+	}
+	return fmt.Errorf("the %s backend does not support %s", backendDocker, kind)
+}
+
+// useBackend points the runner to the selected backend. It returns a function that cleans up after the backend.
+func useBackend(r *saucecloud.CloudRunner, artifactCfg config.ArtifactDownload) (func(), error) {
+	if gFlags.backend != backendDocker {
+		return func() {}, nil
+	}
+
+	if r.Archive.Format != "" && r.Archive.Format != archive.FormatZip {
+		return nil, fmt.Errorf("the %s backend only supports zip archives", backendDocker)
+	}
+
+	dir, err := os.MkdirTemp("", "saucectl-docker-")
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Msg("Running suites in local Docker containers")
+	svc := docker.New(dir, r.MetadataService, artifactCfg)
+	r.JobService = svc
+	r.ProjectUploader = svc.Storage
+	r.Local = true
+	if rt, ok := r.Retrier.(*retry.SauceReportRetrier); ok {
+		rt.VDCReader = svc
+		rt.ProjectUploader = svc.Storage
+	}
+
+	return func() {
+		_ = os.RemoveAll(dir)
+	}, nil
+}
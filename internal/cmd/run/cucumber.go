@@ -1,8 +1,6 @@
 package run
 
 import (
-	"os"
-
 	"github.com/rs/zerolog/log"
 	"github.com/saucelabs/saucectl/internal/ci"
 	cmds "github.com/saucelabs/saucectl/internal/cmd"
@@ -11,6 +9,7 @@ import (
 	"github.com/saucelabs/saucectl/internal/flags"
 	"github.com/saucelabs/saucectl/internal/framework"
 	"github.com/saucelabs/saucectl/internal/http"
+	"github.com/saucelabs/saucectl/internal/redact"
 	"github.com/saucelabs/saucectl/internal/region"
 	"github.com/saucelabs/saucectl/internal/report/captor"
 	"github.com/saucelabs/saucectl/internal/saucecloud"
@@ -36,17 +35,18 @@ func NewCucumberCmd() *cobra.Command {
 		TraverseChildren: true,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			sc.BindAll()
-			return preRun()
+			if err := preRun(); err != nil {
+				return err
+			}
+			return checkBackend(cucumber.Kind)
 		},
 		Run: func(cmd *cobra.Command, args []string) {
 			// Test patterns are passed in via positional args.
 			viper.Set("suite::options::paths", args)
 
-			exitCode, err := runCucumber(cmd, true)
-			if err != nil {
-				log.Err(err).Msg("failed to execute run command")
-			}
-			os.Exit(exitCode)
+			execute(func() (int, error) {
+				return runCucumber(cmd, true)
+			})
 		},
 	}
 
@@ -125,6 +125,11 @@ func runCucumber(cmd *cobra.Command, isCLIDriven bool) (int, error) {
 	insightsClient := http.NewInsightsService(regio.APIBaseURL(), creds, insightsTimeout)
 	iamClient := http.NewUserService(regio.APIBaseURL(), creds, iamTimeout)
 
+	redactor, err := redact.New(p.Sauce.Redact.Keys, p.Sauce.Redact.Patterns)
+	if err != nil {
+		return 1, err
+	}
+
 	log.Info().Msg("Running Playwright-Cucumberjs in Sauce Labs")
 	r := saucecloud.CucumberRunner{
 		Project: p,
@@ -150,8 +155,19 @@ func runCucumber(cmd *cobra.Command, isCLIDriven bool) (int, error) {
 				"cucumber", "sauce", gFlags.async),
 			Async:                  gFlags.async,
 			FailFast:               gFlags.failFast,
+			MaxFailures:            gFlags.maxFailures,
+			TimeBudget:             gFlags.timeBudget,
+			GracePeriod:            gFlags.gracePeriod,
+			ManifestPath:           gFlags.manifest,
+			TimingsPath:            gFlags.timings,
+			Resume:                 gFlags.resumeManifest,
+			Redactor:               redactor,
 			MetadataSearchStrategy: framework.NewSearchStrategy(p.Playwright.Version, p.RootDir),
 			NPMDependencies:        p.Npm.Dependencies,
+			PayloadListing:         payloadOptions(),
+			Archive:                p.Sauce.Archive,
+			RetryPolicy:            p.Sauce.RetryPolicy,
+			AutoConcurrency:        p.Sauce.AutoConcurrency,
 			Retrier: &retry.SauceReportRetrier{
 				VDCReader:       &restoClient,
 				ProjectUploader: &appsClient,
@@ -165,8 +181,8 @@ func runCucumber(cmd *cobra.Command, isCLIDriven bool) (int, error) {
 }
 
 func applyCucumberFlags(p *cucumber.Project) error {
-	if gFlags.selectedSuite != "" {
-		if err := cucumber.FilterSuites(p, gFlags.selectedSuite); err != nil {
+	if !gFlags.suiteSelector.IsZero() {
+		if err := cucumber.FilterSuites(p, gFlags.suiteSelector); err != nil {
 			return err
 		}
 	}
@@ -2,7 +2,6 @@ package run
 
 import (
 	"errors"
-	"os"
 
 	cmds "github.com/saucelabs/saucectl/internal/cmd"
 	"github.com/saucelabs/saucectl/internal/http"
@@ -19,6 +18,7 @@ import (
 	"github.com/saucelabs/saucectl/internal/flags"
 	"github.com/saucelabs/saucectl/internal/framework"
 	"github.com/saucelabs/saucectl/internal/msg"
+	"github.com/saucelabs/saucectl/internal/redact"
 	"github.com/saucelabs/saucectl/internal/region"
 	"github.com/saucelabs/saucectl/internal/report/captor"
 	"github.com/saucelabs/saucectl/internal/saucecloud"
@@ -46,11 +46,9 @@ func NewCypressCmd() *cobra.Command {
 			// Test patterns are passed in via positional args.
 			viper.Set("suite::config::specPattern", args)
 
-			exitCode, err := runCypress(cmd, true)
-			if err != nil {
-				log.Err(err).Msg("failed to execute run command")
-			}
-			os.Exit(exitCode)
+			execute(func() (int, error) {
+				return runCypress(cmd, true)
+			})
 		},
 	}
 
@@ -101,7 +99,7 @@ func runCypress(cmd *cobra.Command, isCLIDriven bool) (int, error) {
 	}
 
 	p.SetCLIFlags(flags.CaptureCommandLineFlags(cmd.Flags()))
-	if err := p.ApplyFlags(gFlags.selectedSuite); err != nil {
+	if err := p.ApplyFlags(gFlags.suiteSelector); err != nil {
 		return 1, err
 	}
 	p.SetDefaults()
@@ -147,6 +145,11 @@ func runCypress(cmd *cobra.Command, isCLIDriven bool) (int, error) {
 	insightsClient := http.NewInsightsService(regio.APIBaseURL(), creds, insightsTimeout)
 	iamClient := http.NewUserService(regio.APIBaseURL(), creds, iamTimeout)
 
+	redactor, err := redact.New(p.GetSauceCfg().Redact.Keys, p.GetSauceCfg().Redact.Patterns)
+	if err != nil {
+		return 1, err
+	}
+
 	log.Info().Msg("Running Cypress in Sauce Labs")
 	r := saucecloud.CypressRunner{
 		Project: p,
@@ -172,8 +175,19 @@ func runCypress(cmd *cobra.Command, isCLIDriven bool) (int, error) {
 				"cypress", "sauce", gFlags.async),
 			Async:                  gFlags.async,
 			FailFast:               gFlags.failFast,
+			MaxFailures:            gFlags.maxFailures,
+			TimeBudget:             gFlags.timeBudget,
+			GracePeriod:            gFlags.gracePeriod,
+			ManifestPath:           gFlags.manifest,
+			TimingsPath:            gFlags.timings,
+			Resume:                 gFlags.resumeManifest,
+			Redactor:               redactor,
 			MetadataSearchStrategy: framework.NewSearchStrategy(p.GetVersion(), p.GetRootDir()),
 			NPMDependencies:        p.GetNpm().Dependencies,
+			PayloadListing:         payloadOptions(),
+			Archive:                p.GetSauceCfg().Archive,
+			RetryPolicy:            p.GetSauceCfg().RetryPolicy,
+			AutoConcurrency:        p.GetSauceCfg().AutoConcurrency,
 			Retrier: &retry.SauceReportRetrier{
 				VDCReader:       &restoClient,
 				ProjectUploader: &appsClient,
@@ -182,6 +196,12 @@ func runCypress(cmd *cobra.Command, isCLIDriven bool) (int, error) {
 		},
 	}
 
+	cleanup, err := useBackend(&r.CloudRunner, p.GetArtifactsCfg().Download)
+	if err != nil {
+		return 1, err
+	}
+	defer cleanup()
+
 	p.CleanPackages()
 	return r.RunProject()
 }
@@ -1,8 +1,6 @@
 package run
 
 import (
-	"os"
-
 	cmds "github.com/saucelabs/saucectl/internal/cmd"
 	"github.com/saucelabs/saucectl/internal/http"
 
@@ -17,6 +15,7 @@ import (
 	"github.com/saucelabs/saucectl/internal/espresso"
 	"github.com/saucelabs/saucectl/internal/flags"
 	"github.com/saucelabs/saucectl/internal/framework"
+	"github.com/saucelabs/saucectl/internal/redact"
 	"github.com/saucelabs/saucectl/internal/region"
 	"github.com/saucelabs/saucectl/internal/report/captor"
 	"github.com/saucelabs/saucectl/internal/saucecloud"
@@ -45,14 +44,15 @@ func NewEspressoCmd() *cobra.Command {
 		TraverseChildren: true,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			sc.BindAll()
-			return preRun()
+			if err := preRun(); err != nil {
+				return err
+			}
+			return checkBackend(espresso.Kind)
 		},
 		Run: func(cmd *cobra.Command, args []string) {
-			exitCode, err := runEspresso(cmd, lflags, true)
-			if err != nil {
-				log.Err(err).Msg("failed to execute run command")
-			}
-			os.Exit(exitCode)
+			execute(func() (int, error) {
+				return runEspresso(cmd, lflags, true)
+			})
 		},
 	}
 
@@ -146,6 +146,11 @@ func runEspressoInCloud(p espresso.Project, regio region.Region) (int, error) {
 	insightsClient := http.NewInsightsService(regio.APIBaseURL(), creds, insightsTimeout)
 	iamClient := http.NewUserService(regio.APIBaseURL(), creds, iamTimeout)
 
+	redactor, err := redact.New(p.Sauce.Redact.Keys, p.Sauce.Redact.Patterns)
+	if err != nil {
+		return 1, err
+	}
+
 	r := saucecloud.EspressoRunner{
 		Project: p,
 		CloudRunner: saucecloud.CloudRunner{
@@ -169,9 +174,18 @@ func runEspressoInCloud(p espresso.Project, regio region.Region) (int, error) {
 			ShowConsoleLog:  p.ShowConsoleLog,
 			Reporters: createReporters(p.Reporters, p.Notifications, p.Sauce.Metadata, &testcompClient, &restoClient,
 				"espresso", "sauce", gFlags.async),
-			Framework: framework.Framework{Name: espresso.Kind},
-			Async:     gFlags.async,
-			FailFast:  gFlags.failFast,
+			Framework:       framework.Framework{Name: espresso.Kind},
+			Async:           gFlags.async,
+			FailFast:        gFlags.failFast,
+			MaxFailures:     gFlags.maxFailures,
+			TimeBudget:      gFlags.timeBudget,
+			GracePeriod:     gFlags.gracePeriod,
+			ManifestPath:    gFlags.manifest,
+			TimingsPath:     gFlags.timings,
+			Resume:          gFlags.resumeManifest,
+			Redactor:        redactor,
+			RetryPolicy:     p.Sauce.RetryPolicy,
+			AutoConcurrency: p.Sauce.AutoConcurrency,
 			Retrier: &retry.JunitRetrier{
 				RDCReader: &rdcClient,
 				VDCReader: &restoClient,
@@ -188,8 +202,8 @@ func hasKey(testOptions map[string]interface{}, key string) bool {
 }
 
 func applyEspressoFlags(p *espresso.Project, flags espressoFlags) error {
-	if gFlags.selectedSuite != "" {
-		if err := espresso.FilterSuites(p, gFlags.selectedSuite); err != nil {
+	if !gFlags.suiteSelector.IsZero() {
+		if err := espresso.FilterSuites(p, gFlags.suiteSelector); err != nil {
 			return err
 		}
 	}
@@ -80,8 +80,8 @@ func runImageRunner(cmd *cobra.Command) (int, error) {
 }
 
 func applyImageRunnerFlags(p *imagerunner.Project) error {
-	if gFlags.selectedSuite != "" {
-		if err := imagerunner.FilterSuites(p, gFlags.selectedSuite); err != nil {
+	if !gFlags.suiteSelector.IsZero() {
+		if err := imagerunner.FilterSuites(p, gFlags.suiteSelector); err != nil {
 			return err
 		}
 	}
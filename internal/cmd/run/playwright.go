@@ -3,7 +3,6 @@ package run
 import (
 	"errors"
 	"fmt"
-	"os"
 
 	cmds "github.com/saucelabs/saucectl/internal/cmd"
 	"github.com/saucelabs/saucectl/internal/http"
@@ -20,6 +19,7 @@ import (
 	"github.com/saucelabs/saucectl/internal/framework"
 	"github.com/saucelabs/saucectl/internal/msg"
 	"github.com/saucelabs/saucectl/internal/playwright"
+	"github.com/saucelabs/saucectl/internal/redact"
 	"github.com/saucelabs/saucectl/internal/region"
 	"github.com/saucelabs/saucectl/internal/report/captor"
 	"github.com/saucelabs/saucectl/internal/saucecloud"
@@ -47,11 +47,9 @@ func NewPlaywrightCmd() *cobra.Command {
 			// Test patterns are passed in via positional args.
 			viper.Set("suite::testMatch", args)
 
-			exitCode, err := runPlaywright(cmd, true)
-			if err != nil {
-				log.Err(err).Msg("failed to execute run command")
-			}
-			os.Exit(exitCode)
+			execute(func() (int, error) {
+				return runPlaywright(cmd, true)
+			})
 		},
 	}
 
@@ -160,6 +158,11 @@ func runPlaywright(cmd *cobra.Command, isCLIDriven bool) (int, error) {
 	insightsClient := http.NewInsightsService(regio.APIBaseURL(), creds, insightsTimeout)
 	iamClient := http.NewUserService(regio.APIBaseURL(), creds, iamTimeout)
 
+	redactor, err := redact.New(p.Sauce.Redact.Keys, p.Sauce.Redact.Patterns)
+	if err != nil {
+		return 1, err
+	}
+
 	log.Info().Msg("Running Playwright in Sauce Labs")
 	r := saucecloud.PlaywrightRunner{
 		Project: p,
@@ -185,8 +188,19 @@ func runPlaywright(cmd *cobra.Command, isCLIDriven bool) (int, error) {
 				"playwright", "sauce", gFlags.async),
 			Async:                  gFlags.async,
 			FailFast:               gFlags.failFast,
+			MaxFailures:            gFlags.maxFailures,
+			TimeBudget:             gFlags.timeBudget,
+			GracePeriod:            gFlags.gracePeriod,
+			ManifestPath:           gFlags.manifest,
+			TimingsPath:            gFlags.timings,
+			Resume:                 gFlags.resumeManifest,
+			Redactor:               redactor,
 			MetadataSearchStrategy: framework.NewSearchStrategy(p.Playwright.Version, p.RootDir),
 			NPMDependencies:        p.Npm.Dependencies,
+			PayloadListing:         payloadOptions(),
+			Archive:                p.Sauce.Archive,
+			RetryPolicy:            p.Sauce.RetryPolicy,
+			AutoConcurrency:        p.Sauce.AutoConcurrency,
 			Retrier: &retry.SauceReportRetrier{
 				VDCReader:       &restoClient,
 				ProjectUploader: &appsClient,
@@ -195,13 +209,19 @@ func runPlaywright(cmd *cobra.Command, isCLIDriven bool) (int, error) {
 		},
 	}
 
+	cleanup, err := useBackend(&r.CloudRunner, p.Artifacts.Download)
+	if err != nil {
+		return 1, err
+	}
+	defer cleanup()
+
 	p.Npm.Packages = cleanPlaywrightPackages(p.Npm, p.Playwright.Version)
 	return r.RunProject()
 }
 
 func applyPlaywrightFlags(p *playwright.Project) error {
-	if gFlags.selectedSuite != "" {
-		if err := playwright.FilterSuites(p, gFlags.selectedSuite); err != nil {
+	if !gFlags.suiteSelector.IsZero() {
+		if err := playwright.FilterSuites(p, gFlags.suiteSelector); err != nil {
 			return err
 		}
 	}
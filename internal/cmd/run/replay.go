@@ -2,7 +2,6 @@ package run
 
 import (
 	"errors"
-	"os"
 
 	cmds "github.com/saucelabs/saucectl/internal/cmd"
 	"github.com/saucelabs/saucectl/internal/http"
@@ -19,6 +18,7 @@ import (
 	"github.com/saucelabs/saucectl/internal/framework"
 	"github.com/saucelabs/saucectl/internal/msg"
 	"github.com/saucelabs/saucectl/internal/puppeteer/replay"
+	"github.com/saucelabs/saucectl/internal/redact"
 	"github.com/saucelabs/saucectl/internal/region"
 	"github.com/saucelabs/saucectl/internal/report/captor"
 	"github.com/saucelabs/saucectl/internal/saucecloud"
@@ -41,17 +41,18 @@ func NewReplayCmd() *cobra.Command {
 		TraverseChildren: true,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			sc.BindAll()
-			return preRun()
+			if err := preRun(); err != nil {
+				return err
+			}
+			return checkBackend(replay.Kind)
 		},
 		Run: func(cmd *cobra.Command, args []string) {
 			// Test patterns are passed in via positional args.
 			viper.Set("suite::recordings", args)
 
-			exitCode, err := runReplay(cmd, true)
-			if err != nil {
-				log.Err(err).Msg("failed to execute run command")
-			}
-			os.Exit(exitCode)
+			execute(func() (int, error) {
+				return runReplay(cmd, true)
+			})
 		},
 	}
 
@@ -134,6 +135,11 @@ func runPuppeteerReplayInSauce(p replay.Project, regio region.Region) (int, erro
 	insightsClient := http.NewInsightsService(regio.APIBaseURL(), creds, insightsTimeout)
 	iamClient := http.NewUserService(regio.APIBaseURL(), creds, iamTimeout)
 
+	redactor, err := redact.New(p.Sauce.Redact.Keys, p.Sauce.Redact.Patterns)
+	if err != nil {
+		return 1, err
+	}
+
 	r := saucecloud.ReplayRunner{
 		Project: p,
 		CloudRunner: saucecloud.CloudRunner{
@@ -158,8 +164,19 @@ func runPuppeteerReplayInSauce(p replay.Project, regio region.Region) (int, erro
 				"puppeteer-replay", "sauce", gFlags.async),
 			Async:                  gFlags.async,
 			FailFast:               gFlags.failFast,
+			MaxFailures:            gFlags.maxFailures,
+			TimeBudget:             gFlags.timeBudget,
+			GracePeriod:            gFlags.gracePeriod,
+			ManifestPath:           gFlags.manifest,
+			TimingsPath:            gFlags.timings,
+			Resume:                 gFlags.resumeManifest,
+			Redactor:               redactor,
 			MetadataSearchStrategy: framework.ExactStrategy{},
 			Retrier:                &retry.BasicRetrier{},
+			PayloadListing:         payloadOptions(),
+			Archive:                p.Sauce.Archive,
+			RetryPolicy:            p.Sauce.RetryPolicy,
+			AutoConcurrency:        p.Sauce.AutoConcurrency,
 		},
 	}
 
@@ -167,8 +184,8 @@ func runPuppeteerReplayInSauce(p replay.Project, regio region.Region) (int, erro
 }
 
 func applyPuppeteerReplayFlags(p *replay.Project) error {
-	if gFlags.selectedSuite != "" {
-		if err := replay.FilterSuites(p, gFlags.selectedSuite); err != nil {
+	if !gFlags.suiteSelector.IsZero() {
+		if err := replay.FilterSuites(p, gFlags.suiteSelector); err != nil {
 			return err
 		}
 	}
@@ -17,6 +17,7 @@ import (
 	"github.com/saucelabs/saucectl/internal/report/spotlight"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v2"
 
 	"github.com/saucelabs/saucectl/internal/apitest"
 	"github.com/saucelabs/saucectl/internal/build"
@@ -30,13 +31,18 @@ import (
 	"github.com/saucelabs/saucectl/internal/imagerunner"
 	"github.com/saucelabs/saucectl/internal/msg"
 	"github.com/saucelabs/saucectl/internal/notification/slack"
+	"github.com/saucelabs/saucectl/internal/payload"
 	"github.com/saucelabs/saucectl/internal/playwright"
 	"github.com/saucelabs/saucectl/internal/puppeteer/replay"
+	"github.com/saucelabs/saucectl/internal/redact"
 	"github.com/saucelabs/saucectl/internal/report"
 	"github.com/saucelabs/saucectl/internal/report/captor"
 	"github.com/saucelabs/saucectl/internal/report/github"
+	"github.com/saucelabs/saucectl/internal/saucecloud"
+	"github.com/saucelabs/saucectl/internal/secret"
 	"github.com/saucelabs/saucectl/internal/testcafe"
 	"github.com/saucelabs/saucectl/internal/version"
+	"github.com/saucelabs/saucectl/internal/viper"
 	"github.com/saucelabs/saucectl/internal/xcuitest"
 )
 
@@ -64,13 +70,29 @@ var gFlags = globalFlags{}
 
 type globalFlags struct {
 	cfgFilePath     string
+	envFile         string
 	globalTimeout   time.Duration
-	selectedSuite   string
+	selectedSuites  []string
+	excludedSuites  []string
+	suiteTags       []string
+	suiteSelector   config.SuiteSelector
 	testEnvSilent   bool
 	async           bool
 	failFast        bool
+	maxFailures     int
+	timeBudget      time.Duration
+	gracePeriod     time.Duration
+	manifest        string
+	timings         string
+	resume          string
+	resumeManifest  saucecloud.Manifest
+	backend         string
 	appStoreTimeout time.Duration
 	noAutoTagging   bool
+	printConfig     bool
+	listPayload     bool
+	listPayloadTop  int
+	listPayloadJSON string
 }
 
 // Command creates the `run` command
@@ -86,11 +108,9 @@ func Command() *cobra.Command {
 			return preRun()
 		},
 		Run: func(cmd *cobra.Command, args []string) {
-			exitCode, err := Run(cmd)
-			if err != nil {
-				log.Err(err).Msg("failed to execute run command")
-			}
-			os.Exit(exitCode)
+			execute(func() (int, error) {
+				return Run(cmd)
+			})
 		},
 	}
 
@@ -98,15 +118,23 @@ func Command() *cobra.Command {
 
 	defaultCfgPath := filepath.Join(".sauce", "config.yml")
 	cmd.PersistentFlags().StringVarP(&gFlags.cfgFilePath, "config", "c", defaultCfgPath, "Specifies which config file to use")
+	cmd.PersistentFlags().StringVar(&gFlags.envFile, "env-file", "", "Loads environment variables from the specified file. Variables that are already set take precedence. Values that are referenced in the config are masked in any output.")
 	cmd.PersistentFlags().DurationVarP(&gFlags.globalTimeout, "timeout", "t", 0, "Global timeout that limits how long saucectl can run in total. Supports duration values like '10s', '30m' etc. (default: no timeout)")
 	cmd.PersistentFlags().BoolVar(&gFlags.async, "async", false, "Launches tests without waiting for test results")
 	cmd.PersistentFlags().BoolVar(&gFlags.failFast, "fail-fast", false, "Stops suites after the first failure")
+	cmd.PersistentFlags().IntVar(&gFlags.maxFailures, "max-failures", 0, "Stops launching new suites once the given number of suites have failed. Suites that are already running are allowed to finish. Skipped suites fail the run with exit code 1. (default: no limit)")
+	cmd.PersistentFlags().DurationVar(&gFlags.gracePeriod, "grace-period", time.Minute, "How long an interrupted run waits for suites in progress to stop, before reporting the partial results. Supports duration values like '10s', '30m' etc. 0 waits indefinitely.")
+	cmd.PersistentFlags().StringVar(&gFlags.manifest, "manifest", "saucectl-manifest.json", "Specifies the file that the run manifest is written to when the run is interrupted. The manifest allows the run to be resumed.")
+	cmd.PersistentFlags().StringVar(&gFlags.timings, "timings", "", "Specifies the file that the durations of suites are recorded in. The durations are used by launchOrder 'duration' and --time-budget if Insights is not available. (default: a file in ~/.sauce/timings that belongs to the current directory)")
+	cmd.PersistentFlags().StringVar(&gFlags.resume, "resume", "", "Resumes the run recorded in the given run manifest. Suites that already passed aren't run again.")
+	cmd.PersistentFlags().StringVar(&gFlags.backend, "backend", backendSauce, "Specifies where suites run: 'sauce' runs them in the Sauce Labs cloud, 'docker' runs them in local Docker containers with the Sauce Labs runner images. The docker backend supports cypress, playwright and testcafe.")
+	cmd.PersistentFlags().DurationVar(&gFlags.timeBudget, "time-budget", 0, "Stops launching new suites that aren't expected to finish within the given duration, based on their historical durations. Skipped suites fail the run with exit code 1. Supports duration values like '10s', '30m' etc. (default: no limit)")
 	cmd.PersistentFlags().DurationVar(&gFlags.appStoreTimeout, "uploadTimeout", 5*time.Minute, "Upload timeout that limits how long saucectl will wait for an upload to finish. Supports duration values like '10s' '30m' etc. (default: 5m)")
 	cmd.PersistentFlags().DurationVar(&gFlags.appStoreTimeout, "upload-timeout", 5*time.Minute, "Upload timeout that limits how long saucectl will wait for an upload to finish. Supports duration values like '10s' '30m' etc. (default: 5m)")
 	sc.StringP("region", "r", "sauce::region", "", "The sauce labs region. Options: us-west-1, eu-central-1.")
-	sc.StringToStringP("env", "e", "envFlag", map[string]string{}, "Set environment variables, e.g. -e foo=bar. Not supported for RDC or Espresso on virtual devices!")
+	sc.StringToStringP("env", "e", "envFlag", map[string]string{}, "Set environment variables, e.g. -e foo=bar. Takes precedence over env vars in the config. Not supported for Espresso, nor for XCUITest on real devices, where env vars are ignored!")
 	sc.Bool("show-console-log", "showConsoleLog", false, "Shows suites console.log locally. By default console.log is only shown on failures.")
-	sc.Int("ccy", "sauce::concurrency", 2, "Concurrency specifies how many suites are run at the same time.")
+	sc.String("ccy", "sauce::concurrency", "2", "Concurrency specifies how many suites are run at the same time. 'auto' sizes it according to the concurrency that is available to the account.")
 	sc.String("tunnel-name", "sauce::tunnel::name", "", "Sets the sauce-connect tunnel name to be used for the run.")
 	sc.String("tunnel-owner", "sauce::tunnel::owner", "", "Sets the sauce-connect tunnel owner to be used for the run.")
 	sc.String("runner-version", "runnerVersion", "", "Overrides the automatically determined runner version.")
@@ -115,7 +143,7 @@ func Command() *cobra.Command {
 	sc.StringToString("experiment", "sauce::experiment", map[string]string{}, "Specifies a list of experimental flags and values")
 	sc.Bool("dry-run", "dryRun", false, "Simulate a test run without actually running any tests.")
 	sc.Int("retries", "sauce::retries", 0, "Retries specifies the number of times to retry a failed suite")
-	sc.String("launch-order", "sauce::launchOrder", "", `Launch jobs based on the failure rate. Jobs with the highest failure rate launch first. Supports values: ["fail rate"]`)
+	sc.String("launch-order", "sauce::launchOrder", "", `Launch jobs based on their history. 'fail rate' launches the jobs with the highest failure rate first, 'duration' the longest running ones. Supports values: ["fail rate", "duration"]`)
 
 	// Metadata
 	sc.StringSlice("tags", "sauce::metadata::tags", []string{}, "Adds tags to tests")
@@ -130,12 +158,19 @@ func Command() *cobra.Command {
 	// Reporters
 	sc.Bool("reporters.junit.enabled", "reporters::junit::enabled", false, "Toggle saucectl's own junit reporting on/off. This only affects the reports that saucectl itself generates as a summary of your tests. Each Job in Sauce Labs has an independent report regardless.")
 	sc.String("reporters.junit.filename", "reporters::junit::filename", "saucectl-report.xml", "Specifies the report filename.")
+	sc.String("reporters.junit.mode", "reporters::junit::mode", junit.ModeMerge, "Specifies how retries are reported. Options: 'merge' reports the last result of each test, 'collapse' reports every test once, with the failures of its other attempts as flaky or rerun failures.")
 	sc.Bool("reporters.json.enabled", "reporters::json::enabled", false, "Toggle saucectl's JSON test result reporting on/off. This only affects the reports that saucectl itself generates as a summary of your tests.")
 	sc.String("reporters.json.filename", "reporters::json::filename", "saucectl-report.json", "Specifies the report filename.")
 	sc.String("reporters.json.webhookURL", "reporters::json::webhookURL", "", "Specifies the webhook URL. When saucectl test is finished, it'll send a HTTP POST payload to the configured webhook URL.")
 
-	cmd.PersistentFlags().StringVar(&gFlags.selectedSuite, "select-suite", "", "Run specified test suite.")
+	cmd.PersistentFlags().StringArrayVar(&gFlags.selectedSuites, "select-suite", []string{}, "Run the specified test suites. Repeat the flag to select several. Accepts suite names, globs (e.g. 'login*') and regular expressions enclosed in slashes (e.g. '/^login-.+$/').")
+	cmd.PersistentFlags().StringArrayVar(&gFlags.excludedSuites, "exclude-suite", []string{}, "Skip the specified test suites. Repeat the flag to exclude several. Accepts the same values as --select-suite.")
+	cmd.PersistentFlags().StringSliceVar(&gFlags.suiteTags, "suite-tags", []string{}, "Run test suites that have any of the specified tags. Tags prefixed with '!' skip suites that have them, e.g. 'smoke,!slow'.")
 	cmd.PersistentFlags().BoolVar(&gFlags.testEnvSilent, "test-env-silent", false, "Skips the test environment announcement.")
+	cmd.PersistentFlags().BoolVar(&gFlags.printConfig, "print-config", false, "Prints the config with sensitive values redacted and exits without running any tests.")
+	cmd.PersistentFlags().BoolVar(&gFlags.listPayload, "list-payload", false, "Lists the contents of the project archives and exits without uploading them or running any tests.")
+	cmd.PersistentFlags().IntVar(&gFlags.listPayloadTop, "list-payload-top", 10, "Limits --list-payload to the specified number of largest files and directories per archive. Lists all files if 0.")
+	cmd.PersistentFlags().StringVar(&gFlags.listPayloadJSON, "list-payload-json", "", "Writes the --list-payload results to the specified file in JSON format. Implies --list-payload.")
 	cmd.PersistentFlags().BoolVar(&gFlags.noAutoTagging, "no-auto-tagging", false, "Disable the automatic tagging of jobs with metadata, such as CI or GIT information.")
 
 	// Hide undocumented flags that the user does not need to care about.
@@ -167,6 +202,11 @@ func preRun() error {
 		return fmt.Errorf("invalid HTTP_PROXY value")
 	}
 
+	// Printing the config neither runs anything nor requires credentials. See execute.
+	if gFlags.printConfig {
+		return nil
+	}
+
 	println("Running version", version.Version)
 	checkForUpdates()
 	go awaitGlobalTimeout()
@@ -180,6 +220,47 @@ func preRun() error {
 		return fmt.Errorf("no credentials set")
 	}
 
+	if gFlags.envFile != "" {
+		if err := secret.LoadEnvFile(gFlags.envFile); err != nil {
+			return err
+		}
+	}
+
+	// Listing the payload only requires the archives, so make sure nothing is uploaded or run.
+	if gFlags.listPayload || gFlags.listPayloadJSON != "" {
+		viper.Set("dryRun", true)
+	}
+
+	if gFlags.maxFailures < 0 {
+		return fmt.Errorf("invalid --max-failures value: must not be negative")
+	}
+	if gFlags.timeBudget < 0 {
+		return fmt.Errorf("invalid --time-budget value: must not be negative")
+	}
+
+	if gFlags.gracePeriod < 0 {
+		return fmt.Errorf("invalid --grace-period value: must not be negative")
+	}
+	if gFlags.backend != backendSauce && gFlags.backend != backendDocker {
+		return fmt.Errorf("invalid --backend value: must be one of '%s' or '%s'", backendSauce, backendDocker)
+	}
+
+	if gFlags.timings == "" {
+		gFlags.timings = saucecloud.DefaultTimingsPath(".")
+	}
+
+	if gFlags.resume != "" {
+		gFlags.resumeManifest, err = saucecloud.ReadManifest(gFlags.resume)
+		if err != nil {
+			return err
+		}
+	}
+
+	gFlags.suiteSelector, err = config.NewSuiteSelector(gFlags.selectedSuites, gFlags.excludedSuites, gFlags.suiteTags)
+	if err != nil {
+		return err
+	}
+
 	d, err := config.Describe(gFlags.cfgFilePath)
 	if err != nil {
 		return err
@@ -189,8 +270,69 @@ func preRun() error {
 	return nil
 }
 
+// payloadOptions returns the payload listing options as set by the global flags.
+func payloadOptions() payload.Options {
+	return payload.Options{
+		Enabled:  gFlags.listPayload || gFlags.listPayloadJSON != "",
+		Top:      gFlags.listPayloadTop,
+		JSONFile: gFlags.listPayloadJSON,
+	}
+}
+
+// printConfig prints the given config file with all sensitive values redacted.
+func printConfig(cfgPath string) error {
+	content, err := os.ReadFile(cfgPath)
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+
+	// Only the redaction settings are decoded, which don't depend on the framework.
+	var c struct {
+		Sauce struct {
+			Redact config.Redact `yaml:"redact"`
+		} `yaml:"sauce"`
+	}
+	if err := yaml.Unmarshal(content, &c); err != nil {
+		return fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	r, err := redact.New(c.Sauce.Redact.Keys, c.Sauce.Redact.Patterns)
+	if err != nil {
+		return err
+	}
+	redacted, err := r.YAML(content)
+	if err != nil {
+		return fmt.Errorf("failed to redact config: %w", err)
+	}
+
+	_, err = os.Stdout.Write(redacted)
+	return err
+}
+
+// execute runs the tests with run and exits with its exit code. If --print-config is set, it prints the config
+// instead of running any tests.
+func execute(run func() (int, error)) {
+	if gFlags.printConfig {
+		if err := printConfig(gFlags.cfgFilePath); err != nil {
+			log.Err(err).Msg("failed to print config")
+			os.Exit(1)
+		}
+		return
+	}
+
+	exitCode, err := run()
+	if err != nil {
+		log.Err(err).Msg("failed to execute run command")
+	}
+	os.Exit(exitCode)
+}
+
 // Run runs the command
 func Run(cmd *cobra.Command) (int, error) {
+	if err := checkBackend(typeDef.Kind); err != nil {
+		return 1, err
+	}
+
 	if typeDef.Kind == cypress.Kind {
 		return runCypress(cmd, false)
 	}
@@ -276,8 +418,13 @@ func createReporters(c config.Reporters, ntfs config.Notifications, metadata con
 	// results.
 	if !async {
 		if c.JUnit.Enabled {
+			if c.JUnit.Mode != "" && c.JUnit.Mode != junit.ModeMerge && c.JUnit.Mode != junit.ModeCollapse {
+				log.Warn().Msgf("Unknown junit reporter mode %q; options: %s, %s. Falling back to %s.",
+					c.JUnit.Mode, junit.ModeMerge, junit.ModeCollapse, junit.ModeMerge)
+			}
 			reps = append(reps, &junit.Reporter{
 				Filename: c.JUnit.Filename,
+				Mode:     c.JUnit.Mode,
 			})
 		}
 		if c.JSON.Enabled {
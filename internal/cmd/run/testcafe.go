@@ -3,7 +3,6 @@ package run
 import (
 	"errors"
 	"fmt"
-	"os"
 
 	cmds "github.com/saucelabs/saucectl/internal/cmd"
 	"github.com/saucelabs/saucectl/internal/http"
@@ -19,6 +18,7 @@ import (
 	"github.com/saucelabs/saucectl/internal/flags"
 	"github.com/saucelabs/saucectl/internal/framework"
 	"github.com/saucelabs/saucectl/internal/msg"
+	"github.com/saucelabs/saucectl/internal/redact"
 	"github.com/saucelabs/saucectl/internal/region"
 	"github.com/saucelabs/saucectl/internal/report/captor"
 	"github.com/saucelabs/saucectl/internal/saucecloud"
@@ -53,11 +53,9 @@ func NewTestcafeCmd() *cobra.Command {
 			// Test patterns are passed in via positional args.
 			viper.Set("suite::src", args)
 
-			exitCode, err := runTestcafe(cmd, lflags, true)
-			if err != nil {
-				log.Err(err).Msg("failed to execute run command")
-			}
-			os.Exit(exitCode)
+			execute(func() (int, error) {
+				return runTestcafe(cmd, lflags, true)
+			})
 		},
 	}
 
@@ -182,6 +180,11 @@ func runTestcafe(cmd *cobra.Command, tcFlags testcafeFlags, isCLIDriven bool) (i
 	insightsClient := http.NewInsightsService(regio.APIBaseURL(), creds, insightsTimeout)
 	iamClient := http.NewUserService(regio.APIBaseURL(), creds, iamTimeout)
 
+	redactor, err := redact.New(p.Sauce.Redact.Keys, p.Sauce.Redact.Patterns)
+	if err != nil {
+		return 1, err
+	}
+
 	log.Info().Msg("Running Testcafe in Sauce Labs")
 	r := saucecloud.TestcafeRunner{
 		Project: p,
@@ -207,8 +210,19 @@ func runTestcafe(cmd *cobra.Command, tcFlags testcafeFlags, isCLIDriven bool) (i
 				"testcafe", "sauce", gFlags.async),
 			Async:                  gFlags.async,
 			FailFast:               gFlags.failFast,
+			MaxFailures:            gFlags.maxFailures,
+			TimeBudget:             gFlags.timeBudget,
+			GracePeriod:            gFlags.gracePeriod,
+			ManifestPath:           gFlags.manifest,
+			TimingsPath:            gFlags.timings,
+			Resume:                 gFlags.resumeManifest,
+			Redactor:               redactor,
 			MetadataSearchStrategy: framework.NewSearchStrategy(p.Testcafe.Version, p.RootDir),
 			NPMDependencies:        p.Npm.Dependencies,
+			PayloadListing:         payloadOptions(),
+			Archive:                p.Sauce.Archive,
+			RetryPolicy:            p.Sauce.RetryPolicy,
+			AutoConcurrency:        p.Sauce.AutoConcurrency,
 			Retrier: &retry.SauceReportRetrier{
 				VDCReader:       &restoClient,
 				ProjectUploader: &appsClient,
@@ -217,13 +231,19 @@ func runTestcafe(cmd *cobra.Command, tcFlags testcafeFlags, isCLIDriven bool) (i
 		},
 	}
 
+	cleanup, err := useBackend(&r.CloudRunner, p.Artifacts.Download)
+	if err != nil {
+		return 1, err
+	}
+	defer cleanup()
+
 	cleanTestCafePackages(&p)
 	return r.RunProject()
 }
 
 func applyTestcafeFlags(p *testcafe.Project, flags testcafeFlags) error {
-	if gFlags.selectedSuite != "" {
-		if err := testcafe.FilterSuites(p, gFlags.selectedSuite); err != nil {
+	if !gFlags.suiteSelector.IsZero() {
+		if err := testcafe.FilterSuites(p, gFlags.suiteSelector); err != nil {
 			return err
 		}
 	}
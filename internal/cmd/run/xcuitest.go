@@ -1,8 +1,6 @@
 package run
 
 import (
-	"os"
-
 	cmds "github.com/saucelabs/saucectl/internal/cmd"
 	"github.com/saucelabs/saucectl/internal/http"
 
@@ -16,6 +14,7 @@ import (
 	"github.com/saucelabs/saucectl/internal/config"
 	"github.com/saucelabs/saucectl/internal/flags"
 	"github.com/saucelabs/saucectl/internal/framework"
+	"github.com/saucelabs/saucectl/internal/redact"
 	"github.com/saucelabs/saucectl/internal/region"
 	"github.com/saucelabs/saucectl/internal/report/captor"
 	"github.com/saucelabs/saucectl/internal/saucecloud"
@@ -45,14 +44,15 @@ func NewXCUITestCmd() *cobra.Command {
 		TraverseChildren: true,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			sc.BindAll()
-			return preRun()
+			if err := preRun(); err != nil {
+				return err
+			}
+			return checkBackend(xcuitest.Kind)
 		},
 		Run: func(cmd *cobra.Command, args []string) {
-			exitCode, err := runXcuitest(cmd, lflags, true)
-			if err != nil {
-				log.Err(err).Msg("failed to execute run command")
-			}
-			os.Exit(exitCode)
+			execute(func() (int, error) {
+				return runXcuitest(cmd, lflags, true)
+			})
 		},
 	}
 
@@ -147,6 +147,11 @@ func runXcuitestInCloud(p xcuitest.Project, regio region.Region) (int, error) {
 	insightsClient := http.NewInsightsService(regio.APIBaseURL(), creds, insightsTimeout)
 	iamClient := http.NewUserService(regio.APIBaseURL(), creds, iamTimeout)
 
+	redactor, err := redact.New(p.Sauce.Redact.Keys, p.Sauce.Redact.Patterns)
+	if err != nil {
+		return 1, err
+	}
+
 	r := saucecloud.XcuitestRunner{
 		Project: p,
 		CloudRunner: saucecloud.CloudRunner{
@@ -170,11 +175,21 @@ func runXcuitestInCloud(p xcuitest.Project, regio region.Region) (int, error) {
 			ShowConsoleLog:  p.ShowConsoleLog,
 			Reporters: createReporters(p.Reporters, p.Notifications, p.Sauce.Metadata, &testcompClient, &restoClient,
 				"xcuitest", "sauce", gFlags.async),
-			Framework: framework.Framework{Name: xcuitest.Kind},
-			Async:     gFlags.async,
-			FailFast:  gFlags.failFast,
+			Framework:       framework.Framework{Name: xcuitest.Kind},
+			Async:           gFlags.async,
+			FailFast:        gFlags.failFast,
+			MaxFailures:     gFlags.maxFailures,
+			TimeBudget:      gFlags.timeBudget,
+			GracePeriod:     gFlags.gracePeriod,
+			ManifestPath:    gFlags.manifest,
+			TimingsPath:     gFlags.timings,
+			Resume:          gFlags.resumeManifest,
+			Redactor:        redactor,
+			RetryPolicy:     p.Sauce.RetryPolicy,
+			AutoConcurrency: p.Sauce.AutoConcurrency,
 			Retrier: &retry.JunitRetrier{
 				RDCReader: &rdcClient,
+				VDCReader: &restoClient,
 			},
 		},
 	}
@@ -182,8 +197,8 @@ func runXcuitestInCloud(p xcuitest.Project, regio region.Region) (int, error) {
 }
 
 func applyXCUITestFlags(p *xcuitest.Project, flags xcuitestFlags) error {
-	if gFlags.selectedSuite != "" {
-		if err := xcuitest.FilterSuites(p, gFlags.selectedSuite); err != nil {
+	if !gFlags.suiteSelector.IsZero() {
+		if err := xcuitest.FilterSuites(p, gFlags.suiteSelector); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,88 @@
+package sauceignore
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	cmds "github.com/saucelabs/saucectl/internal/cmd"
+	"github.com/saucelabs/saucectl/internal/sauceignore"
+	"github.com/saucelabs/saucectl/internal/segment"
+	"github.com/saucelabs/saucectl/internal/usage"
+	"github.com/spf13/cobra"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+func CheckCommand() *cobra.Command {
+	var sauceignoreFile string
+
+	cmd := &cobra.Command{
+		Use:   "check <path>...",
+		Short: "Explains which pattern includes or excludes the given paths from the project archive.",
+		Long: "Explains which pattern includes or excludes the given paths from the project archive. " +
+			"Paths are relative to the current working directory, the same way saucectl archives them.",
+		SilenceUsage: true,
+		Args:         cobra.MinimumNArgs(1),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			tracker := segment.DefaultTracker
+
+			go func() {
+				tracker.Collect(
+					cases.Title(language.English).String(cmds.FullName(cmd)),
+					usage.Properties{}.SetFlags(cmd.Flags()),
+				)
+				_ = tracker.Close()
+			}()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			matcher, err := sauceignore.NewMatcherFromFile(sauceignoreFile)
+			if err != nil {
+				return err
+			}
+			for _, p := range args {
+				check(os.Stdout, matcher, p)
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&sauceignoreFile, "sauceignore", ".sauceignore", "Specifies the path to the .sauceignore file.")
+
+	return cmd
+}
+
+// check writes to w whether the path p is excluded from the archive, and which pattern is responsible for it.
+func check(w io.Writer, matcher sauceignore.Matcher, p string) {
+	isDir := strings.HasSuffix(p, "/")
+	if finfo, err := os.Stat(p); err == nil {
+		isDir = finfo.IsDir()
+	}
+
+	segments := strings.Split(filepath.Clean(p), string(os.PathSeparator))
+
+	// Archiving stops at ignored directories, unless their contents may be re-included.
+	for i := 1; i < len(segments); i++ {
+		parent := segments[:i]
+		if matcher.Match(parent, true) && !matcher.MayReinclude(parent) {
+			pattern, _ := matcher.Explain(parent, true)
+			_, _ = fmt.Fprintf(w, "%s: %s by %s (parent directory %s)\n", p, color.RedString("excluded"),
+				pattern, strings.Join(parent, "/"))
+			return
+		}
+	}
+
+	pattern, ok := matcher.Explain(segments, isDir)
+	switch {
+	case !ok:
+		_, _ = fmt.Fprintf(w, "%s: %s (no matching pattern)\n", p, color.GreenString("included"))
+	case pattern.IsNegated():
+		_, _ = fmt.Fprintf(w, "%s: %s by %s\n", p, color.GreenString("included"), pattern)
+	default:
+		_, _ = fmt.Fprintf(w, "%s: %s by %s\n", p, color.RedString("excluded"), pattern)
+	}
+}
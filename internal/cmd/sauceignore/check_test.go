@@ -0,0 +1,50 @@
+package sauceignore
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/saucelabs/saucectl/internal/sauceignore"
+)
+
+func Test_check(t *testing.T) {
+	color.NoColor = true
+
+	matcher := sauceignore.NewMatcher([]sauceignore.Pattern{
+		{P: "cypress/", Source: ".sauceignore", Line: 1},
+		{P: "!cypress/e2e/", Source: ".sauceignore", Line: 2},
+		{P: "node_modules/", Source: ".sauceignore", Line: 3},
+	})
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{
+			path: "cypress/videos/login.mp4",
+			want: "cypress/videos/login.mp4: excluded by .sauceignore:1: cypress/ (parent directory cypress/videos)\n",
+		},
+		{
+			path: "cypress/e2e/login.cy.js",
+			want: "cypress/e2e/login.cy.js: included by .sauceignore:2: !cypress/e2e/\n",
+		},
+		{
+			path: "node_modules/cypress/index.js",
+			want: "node_modules/cypress/index.js: excluded by .sauceignore:3: node_modules/ (parent directory node_modules)\n",
+		},
+		{
+			path: "package.json",
+			want: "package.json: included (no matching pattern)\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			var buf bytes.Buffer
+			check(&buf, matcher, tt.path)
+			if got := buf.String(); got != tt.want {
+				t.Errorf("check() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
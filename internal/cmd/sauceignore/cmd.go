@@ -0,0 +1,26 @@
+package sauceignore
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// Command creates the `sauceignore` command.
+func Command(preRun func(cmd *cobra.Command, args []string)) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:              "sauceignore",
+		Short:            "Inspect .sauceignore files",
+		SilenceUsage:     true,
+		TraverseChildren: true,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			if preRun != nil {
+				preRun(cmd, args)
+			}
+		},
+	}
+
+	cmd.AddCommand(
+		CheckCommand(),
+	)
+
+	return cmd
+}
@@ -52,6 +52,10 @@ func Command(preRun func(cmd *cobra.Command, args []string)) *cobra.Command {
 		UploadCommand(),
 		DownloadCommand(),
 		DeleteCommand(),
+		PruneCommand(),
+		TagCommand(),
+		DescribeCommand(),
+		CopyCommand(),
 	)
 
 	return cmd
@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/saucelabs/saucectl/internal/apps"
+	cmds "github.com/saucelabs/saucectl/internal/cmd"
+	"github.com/saucelabs/saucectl/internal/credentials"
+	"github.com/saucelabs/saucectl/internal/http"
+	"github.com/saucelabs/saucectl/internal/region"
+	"github.com/saucelabs/saucectl/internal/segment"
+	"github.com/saucelabs/saucectl/internal/storage"
+	"github.com/saucelabs/saucectl/internal/usage"
+	"github.com/spf13/cobra"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+func CopyCommand() *cobra.Command {
+	var out string
+	var toRegion string
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "copy <fileID|storage:filename=...>",
+		Short: "Copies a file from Sauce Storage in one region to another region.",
+		Long: "Copies a file from Sauce Storage in the region specified by --region to the region specified by " +
+			"--to-region. The file is streamed between regions without being stored locally. If the target region " +
+			"already has a file with a matching checksum, the copy is skipped.",
+		SilenceUsage: true,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 || args[0] == "" {
+				return errors.New("no ID specified")
+			}
+
+			return nil
+		},
+		PreRun: func(cmd *cobra.Command, args []string) {
+			tracker := segment.DefaultTracker
+
+			go func() {
+				tracker.Collect(
+					cases.Title(language.English).String(cmds.FullName(cmd)),
+					usage.Properties{}.SetFlags(cmd.Flags()),
+				)
+				_ = tracker.Close()
+			}()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reg := region.FromString(toRegion)
+			if reg == region.None {
+				return errors.New("invalid target region")
+			}
+			if reg.APIBaseURL() == appsClient.URL {
+				return errors.New("source and target region must be different")
+			}
+
+			item, err := resolveItem(args[0])
+			if err != nil {
+				return err
+			}
+
+			target := http.NewAppStore(reg.APIBaseURL(),
+				credentials.Get().Username, credentials.Get().AccessKey,
+				15*time.Minute)
+
+			var copied storage.Item
+			skipCopy := false
+			if !force {
+				if copied, skipCopy, err = findBySHA256(target, item.SHA256); err != nil {
+					return err
+				}
+			}
+
+			if !skipCopy {
+				copied, err = streamUpload(target, item.Name, item.Description, item.SHA256, out,
+					func() (io.ReadCloser, int64, error) {
+						return appsClient.Download(item.ID)
+					})
+				if err != nil {
+					return fmt.Errorf("failed to copy file: %w", err)
+				}
+			}
+
+			switch out {
+			case "text":
+				if skipCopy {
+					fmt.Printf("File already stored in %s! The ID of your file is %s\n", reg, copied.ID)
+					return nil
+				}
+				fmt.Printf("Success! The ID of your file in %s is %s\n", reg, copied.ID)
+			case "json":
+				if err := renderJSON(copied); err != nil {
+					return fmt.Errorf("failed to render output: %w", err)
+				}
+			default:
+				return errors.New("unknown output format")
+			}
+
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&out, "out", "o", "text",
+		"Output format to the console. Options: text, json.",
+	)
+	flags.StringVar(&toRegion, "to-region", "",
+		"The Sauce Labs region to copy the file to. Options: us-west-1, eu-central-1.",
+	)
+	flags.BoolVar(&force, "force", false,
+		"Forces the copy to happen, even if there's already a file in the target region with a matching checksum.",
+	)
+
+	_ = cmd.MarkFlagRequired("to-region")
+
+	return cmd
+}
+
+// resolveItem looks up the file that ref refers to. If ref refers to a filename, the most recently uploaded file
+// with that name is returned.
+func resolveItem(ref string) (storage.Item, error) {
+	fileID, filename, ok := apps.ParseStorageReference(ref)
+	if !ok {
+		return storage.Item{}, fmt.Errorf("invalid storage reference: %s", ref)
+	}
+
+	if fileID != "" {
+		item, err := appsClient.Get(fileID)
+		if err != nil {
+			return storage.Item{}, fmt.Errorf("failed to retrieve file: %w", err)
+		}
+		return item, nil
+	}
+
+	list, err := appsClient.List(storage.ListOptions{Name: filename})
+	if err != nil {
+		return storage.Item{}, fmt.Errorf("storage lookup failed: %w", err)
+	}
+
+	var latest storage.Item
+	for _, item := range list.Items {
+		// Only accept exact matches.
+		if item.Name == filename && item.Uploaded.After(latest.Uploaded) {
+			latest = item
+		}
+	}
+	if latest.ID == "" {
+		return storage.Item{}, fmt.Errorf("%w: %s", storage.ErrFileNotFound, filename)
+	}
+
+	return latest, nil
+}
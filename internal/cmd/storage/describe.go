@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	cmds "github.com/saucelabs/saucectl/internal/cmd"
+	"github.com/saucelabs/saucectl/internal/segment"
+	"github.com/saucelabs/saucectl/internal/storage"
+	"github.com/saucelabs/saucectl/internal/usage"
+	"github.com/spf13/cobra"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+func DescribeCommand() *cobra.Command {
+	var out string
+
+	cmd := &cobra.Command{
+		Use:   "describe <fileID> [description]",
+		Short: "Shows the details of a file in Sauce Storage, or replaces its description.",
+		Long: "Shows the details of a file in Sauce Storage. If a description is given, it replaces the description " +
+			"of the file. Any tags within the description are retained, unless the new description contains tags of its own.",
+		SilenceUsage: true,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 || args[0] == "" {
+				return errors.New("no ID specified")
+			}
+			if len(args) > 2 {
+				return errors.New("too many arguments; quote the description if it contains spaces")
+			}
+
+			return nil
+		},
+		PreRun: func(cmd *cobra.Command, args []string) {
+			tracker := segment.DefaultTracker
+
+			go func() {
+				tracker.Collect(
+					cases.Title(language.English).String(cmds.FullName(cmd)),
+					usage.Properties{}.SetFlags(cmd.Flags()),
+				)
+				_ = tracker.Close()
+			}()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			item, err := appsClient.Get(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to retrieve file: %w", err)
+			}
+
+			if len(args) == 2 {
+				description := args[1]
+				if len(storage.ParseTags(description)) == 0 {
+					description = storage.SetTags(description, item.Tags, nil)
+				}
+				if item, err = appsClient.Describe(item.ID, description); err != nil {
+					return fmt.Errorf("failed to update file: %w", err)
+				}
+			}
+
+			switch out {
+			case "text":
+				renderItem(item)
+			case "json":
+				if err := renderJSON(item); err != nil {
+					return fmt.Errorf("failed to render output: %w", err)
+				}
+			default:
+				return errors.New("unknown output format")
+			}
+
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&out, "out", "o", "text",
+		"Output format to the console. Options: text, json.",
+	)
+
+	return cmd
+}
+
+func renderItem(item storage.Item) {
+	fmt.Printf("ID:          %s\n", item.ID)
+	fmt.Printf("Name:        %s\n", item.Name)
+	fmt.Printf("Size:        %s\n", humanize.Bytes(uint64(item.Size)))
+	fmt.Printf("Uploaded:    %s\n", item.Uploaded.Format("2006-01-02 15:04:05 MST"))
+	fmt.Printf("Description: %s\n", item.Description)
+	fmt.Printf("Tags:        %s\n", strings.Join(item.Tags, ", "))
+}
@@ -1,10 +1,15 @@
 package storage
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/dustin/go-humanize"
@@ -63,6 +68,12 @@ func ListCommand() *cobra.Command {
 	var name string
 	var out string
 	var sha256 string
+	var kind string
+	var page int
+	var all bool
+	var uploadedAfter string
+	var sortBy string
+	var desc bool
 
 	cmd := &cobra.Command{
 		Use: "list",
@@ -83,14 +94,51 @@ func ListCommand() *cobra.Command {
 			}()
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			list, err := appsClient.List(storage.ListOptions{
+			if out != "text" && out != "json" && out != "csv" {
+				return errors.New("unknown output format")
+			}
+			if kind != "" && kind != storage.KindAndroid && kind != storage.KindIOS && kind != storage.KindOther {
+				return fmt.Errorf("unknown kind '%s'", kind)
+			}
+			if sortBy != "" && sortBy != "name" && sortBy != "size" && sortBy != "uploaded" {
+				return fmt.Errorf("unknown sort field '%s'", sortBy)
+			}
+			if all && page > 0 {
+				return errors.New("--all and --page are mutually exclusive")
+			}
+
+			var after time.Time
+			if uploadedAfter != "" {
+				var err error
+				if after, err = parseTime(uploadedAfter, time.Now()); err != nil {
+					return err
+				}
+			}
+
+			opts := storage.ListOptions{
 				Q:      query,
 				Name:   name,
 				SHA256: sha256,
-			})
+				Kind:   kind,
+				Page:   page,
+			}
+
+			var list storage.List
+			var err error
+			if all {
+				list.Items, err = listAll(opts)
+			} else {
+				list, err = appsClient.List(opts)
+			}
 			if err != nil {
 				return fmt.Errorf("failed to retrieve list: %w", err)
 			}
+
+			if !after.IsZero() {
+				list.Items = uploadedSince(list.Items, after)
+			}
+			sortItems(list.Items, sortBy, desc)
+
 			switch out {
 			case "text":
 				renderTable(list)
@@ -98,8 +146,10 @@ func ListCommand() *cobra.Command {
 				if err := renderJSON(list); err != nil {
 					return fmt.Errorf("failed to render output: %w", err)
 				}
-			default:
-				return errors.New("unknown output format")
+			case "csv":
+				if err := renderCSV(os.Stdout, list.Items); err != nil {
+					return fmt.Errorf("failed to render output: %w", err)
+				}
 			}
 
 			return nil
@@ -116,13 +166,95 @@ func ListCommand() *cobra.Command {
 	flags.StringVar(&sha256, "sha256", "",
 		"The checksum of the file by which you want to filter.",
 	)
+	flags.StringVar(&kind, "kind", "",
+		"The kind of file by which you want to filter. Options: android, ios, other.",
+	)
+	flags.StringVar(&uploadedAfter, "uploaded-after", "",
+		"Only lists files uploaded after this point in time. Supports dates like '2024-01-31', RFC 3339 timestamps and "+
+			"relative durations like '7d' or '12h'. Filters the fetched page only, unless used with --all.",
+	)
+	flags.IntVar(&page, "page", 0,
+		"The page of results to return, starting at 1.",
+	)
+	flags.BoolVar(&all, "all", false,
+		"Returns all files by following pagination.",
+	)
+	flags.StringVar(&sortBy, "sort", "",
+		"Sorts the files by the given field. Options: name, size, uploaded.",
+	)
+	flags.BoolVar(&desc, "desc", false,
+		"Sorts the files in descending order.",
+	)
 	flags.StringVarP(&out, "out", "o", "text",
-		"Output format to the console. Options: text, json.",
+		"Output format to the console. Options: text, json, csv.",
 	)
 
 	return cmd
 }
 
+// listAll returns the files across all pages that match opts.
+func listAll(opts storage.ListOptions) ([]storage.Item, error) {
+	var items []storage.Item
+	for page := 1; ; page++ {
+		opts.Page = page
+		list, err := appsClient.List(opts)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, list.Items...)
+		if !list.Truncated || len(list.Items) == 0 {
+			return items, nil
+		}
+	}
+}
+
+// parseTime parses s either as a date, an RFC 3339 timestamp, or a duration relative to now.
+func parseTime(s string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, s, time.Local); err == nil {
+		return t, nil
+	}
+	if d, err := parseAge(s); err == nil {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid point in time '%s'", s)
+}
+
+// uploadedSince returns the items that have been uploaded after t.
+func uploadedSince(items []storage.Item, t time.Time) []storage.Item {
+	var res []storage.Item
+	for _, it := range items {
+		if it.Uploaded.After(t) {
+			res = append(res, it)
+		}
+	}
+	return res
+}
+
+// sortItems sorts the items in place by the given field. Retains the order if field is empty.
+func sortItems(items []storage.Item, field string, desc bool) {
+	var less func(a, b storage.Item) bool
+	switch field {
+	case "name":
+		less = func(a, b storage.Item) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) }
+	case "size":
+		less = func(a, b storage.Item) bool { return a.Size < b.Size }
+	case "uploaded":
+		less = func(a, b storage.Item) bool { return a.Uploaded.Before(b.Uploaded) }
+	default:
+		return
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if desc {
+			return less(items[j], items[i])
+		}
+		return less(items[i], items[j])
+	})
+}
+
 func renderTable(list storage.List) {
 	if len(list.Items) == 0 {
 		println("No files match the search criteria.")
@@ -132,7 +264,7 @@ func renderTable(list storage.List) {
 	t.SetStyle(defaultTableStyle)
 	t.SuppressEmptyColumns()
 
-	t.AppendHeader(table.Row{"Size", "Uploaded", "ID", "Name"})
+	t.AppendHeader(table.Row{"Size", "Uploaded", "ID", "Name", "Version", "Bundle ID"})
 	t.SetColumnConfigs([]table.ColumnConfig{
 		{
 			Name:        "Size",
@@ -159,18 +291,43 @@ func renderTable(list storage.List) {
 		{
 			Name: "Name",
 		},
+		{
+			Name: "Version",
+		},
+		{
+			Name: "Bundle ID",
+		},
 	})
 
 	for _, item := range list.Items {
 		// the order of values must match the order of the header
-		t.AppendRow(table.Row{item.Size, item.Uploaded, item.ID, item.Name})
+		t.AppendRow(table.Row{item.Size, item.Uploaded, item.ID, item.Name, item.Version, item.BundleID})
 	}
 
 	println(t.Render())
 
 	if list.Truncated {
-		println("\nYour query returned more files than we can display. Please refine your query.")
+		println("\nYour query returned more files than we can display. Please refine your query, or use --all or --page to see more.")
+	}
+}
+
+// renderCSV writes the items in CSV format to w.
+func renderCSV(w io.Writer, items []storage.Item) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"id", "name", "size", "uploaded", "kind", "version", "bundleId", "sha256", "description"}); err != nil {
+		return err
+	}
+	for _, it := range items {
+		err := cw.Write([]string{
+			it.ID, it.Name, strconv.Itoa(it.Size), it.Uploaded.UTC().Format(time.RFC3339), it.Kind, it.Version,
+			it.BundleID, it.SHA256, it.Description,
+		})
+		if err != nil {
+			return err
+		}
 	}
+	cw.Flush()
+	return cw.Error()
 }
 
 func renderJSON(val any) error {
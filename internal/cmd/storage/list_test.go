@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/saucelabs/saucectl/internal/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseTime(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{in: "2024-06-01T08:00:00Z", want: time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)},
+		{in: "2024-06-01", want: time.Date(2024, 6, 1, 0, 0, 0, 0, time.Local)},
+		{in: "7d", want: now.Add(-7 * 24 * time.Hour)},
+		{in: "12h", want: now.Add(-12 * time.Hour)},
+		{in: "yesterday", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseTime(tt.in, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_sortItems(t *testing.T) {
+	now := time.Now()
+	items := []storage.Item{
+		{ID: "1", Name: "b.apk", Size: 30, Uploaded: now.Add(-2 * time.Hour)},
+		{ID: "2", Name: "A.ipa", Size: 10, Uploaded: now.Add(-1 * time.Hour)},
+		{ID: "3", Name: "c.zip", Size: 20, Uploaded: now.Add(-3 * time.Hour)},
+	}
+
+	ids := func(items []storage.Item) []string {
+		var res []string
+		for _, it := range items {
+			res = append(res, it.ID)
+		}
+		return res
+	}
+
+	tests := []struct {
+		field string
+		desc  bool
+		want  []string
+	}{
+		{field: "", want: []string{"1", "2", "3"}},
+		{field: "name", want: []string{"2", "1", "3"}},
+		{field: "size", desc: true, want: []string{"1", "3", "2"}},
+		{field: "uploaded", want: []string{"3", "1", "2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			sorted := append([]storage.Item{}, items...)
+			sortItems(sorted, tt.field, tt.desc)
+			assert.Equal(t, tt.want, ids(sorted))
+		})
+	}
+}
+
+func Test_renderCSV(t *testing.T) {
+	var buf bytes.Buffer
+	err := renderCSV(&buf, []storage.Item{
+		{
+			ID:          "f7c0a1e2",
+			Name:        "app.apk",
+			Size:        1024,
+			Uploaded:    time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC),
+			Kind:        storage.KindAndroid,
+			Version:     "1.2.3",
+			BundleID:    "com.example.app",
+			SHA256:      "abc123",
+			Description: "Nightly build, #release",
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "id,name,size,uploaded,kind,version,bundleId,sha256,description\n"+
+		"f7c0a1e2,app.apk,1024,2024-06-01T08:00:00Z,android,1.2.3,com.example.app,abc123,\"Nightly build, #release\"\n",
+		buf.String())
+}
@@ -0,0 +1,185 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	cmds "github.com/saucelabs/saucectl/internal/cmd"
+	"github.com/saucelabs/saucectl/internal/segment"
+	"github.com/saucelabs/saucectl/internal/storage"
+	"github.com/saucelabs/saucectl/internal/usage"
+	"github.com/spf13/cobra"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+func PruneCommand() *cobra.Command {
+	var filter storage.PruneFilter
+	var olderThan string
+	var dryRun bool
+	var concurrency int
+	var out string
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Deletes files from Sauce Storage that match the given criteria.",
+		Long: "Deletes files from Sauce Storage that match all of the given criteria. At least one criterion is required. " +
+			"Use --dry-run to list the files that would be deleted.",
+		Example: `  # Delete project archives that saucectl uploaded more than a week ago.
+  saucectl storage prune --saucectl --older-than 7d
+
+  # Delete all but the 3 latest uploads of each app.
+  saucectl storage prune --name "*.apk" --keep-latest 3`,
+		SilenceUsage: true,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			tracker := segment.DefaultTracker
+
+			go func() {
+				tracker.Collect(
+					cases.Title(language.English).String(cmds.FullName(cmd)),
+					usage.Properties{}.SetFlags(cmd.Flags()),
+				)
+				_ = tracker.Close()
+			}()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if out != "text" && out != "json" {
+				return errors.New("unknown output format")
+			}
+			if concurrency < 1 {
+				return errors.New("concurrency must be at least 1")
+			}
+
+			if olderThan != "" {
+				d, err := parseAge(olderThan)
+				if err != nil {
+					return err
+				}
+				filter.OlderThan = d
+			}
+			if filter.IsZero() {
+				return errors.New("no criteria specified; refusing to delete all files")
+			}
+
+			items, err := listAll(storage.ListOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to retrieve list: %w", err)
+			}
+
+			selected, err := filter.Select(items, time.Now())
+			if err != nil {
+				return err
+			}
+
+			if dryRun {
+				if out == "json" {
+					return renderJSON(selected)
+				}
+				if len(selected) == 0 {
+					println("No files match the criteria.")
+					return nil
+				}
+				renderTable(storage.List{Items: selected})
+				fmt.Printf("\n%d files would be deleted.\n", len(selected))
+				return nil
+			}
+
+			deleted, err := deleteItems(selected, concurrency)
+			if out == "json" {
+				if err := renderJSON(deleted); err != nil {
+					return fmt.Errorf("failed to render output: %w", err)
+				}
+			} else {
+				fmt.Printf("Deleted %d of %d files.\n", len(deleted), len(selected))
+			}
+
+			return err
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&olderThan, "older-than", "",
+		"Deletes files that have been uploaded longer ago than this. Supports durations like '36h' and days like '7d'.",
+	)
+	flags.StringVarP(&filter.Name, "name", "n", "",
+		"Deletes files whose name (case-insensitive) matches this glob pattern, e.g. 'app-*.zip'.",
+	)
+	flags.IntVar(&filter.KeepLatest, "keep-latest", 0,
+		"Retains the latest N files of each name, regardless of any other criteria.",
+	)
+	flags.BoolVar(&filter.Generated, "saucectl", false,
+		"Deletes files that saucectl generated and uploaded on its own, such as project archives.",
+	)
+	flags.StringSliceVar(&filter.Tags, "tag", []string{},
+		"Deletes files that have any of the specified tags.",
+	)
+	flags.BoolVar(&dryRun, "dry-run", false, "Lists the files that would be deleted without deleting them.")
+	flags.IntVar(&concurrency, "concurrency", 5, "The number of files to delete at the same time.")
+	flags.StringVarP(&out, "out", "o", "text",
+		"Output format to the console. Options: text, json.",
+	)
+
+	return cmd
+}
+
+// parseAge parses a duration that, in addition to the units supported by time.ParseDuration, supports days, e.g. '7d'.
+func parseAge(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration '%s'", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration '%s'", s)
+	}
+	return d, nil
+}
+
+// deleteItems deletes the items with the given concurrency and returns the ones that have been deleted.
+func deleteItems(items []storage.Item, concurrency int) ([]storage.Item, error) {
+	jobs := make(chan storage.Item)
+	var mu sync.Mutex
+	var deleted []storage.Item
+	var errs []error
+
+	var wg sync.WaitGroup
+	for i := 0; i < min(concurrency, len(items)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for it := range jobs {
+				err := appsClient.Delete(it.ID)
+
+				mu.Lock()
+				if err != nil {
+					log.Error().Err(err).Str("id", it.ID).Str("name", it.Name).Msg("Failed to delete file.")
+					errs = append(errs, fmt.Errorf("%s: %w", it.ID, err))
+				} else {
+					log.Info().Str("id", it.ID).Str("name", it.Name).Msg("File deleted.")
+					deleted = append(deleted, it)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, it := range items {
+		jobs <- it
+	}
+	close(jobs)
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return deleted, fmt.Errorf("failed to delete %d files: %w", len(errs), errors.Join(errs...))
+	}
+	return deleted, nil
+}
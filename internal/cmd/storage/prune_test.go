@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_parseAge(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "7d", want: 7 * 24 * time.Hour},
+		{in: "36h", want: 36 * time.Hour},
+		{in: "1h30m", want: 90 * time.Minute},
+		{in: "-1d", wantErr: true},
+		{in: "d", wantErr: true},
+		{in: "soon", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseAge(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAge() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseAge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+
+	"github.com/saucelabs/saucectl/internal/http"
+	"github.com/saucelabs/saucectl/internal/storage"
+	"github.com/schollz/progressbar/v3"
+)
+
+// source opens the data to be transferred and returns its size, or -1 if unknown.
+type source func() (io.ReadCloser, int64, error)
+
+// findBySHA256 returns the item in storage that matches the checksum, if any.
+func findBySHA256(client *http.AppStore, checksum string) (storage.Item, bool, error) {
+	if checksum == "" {
+		return storage.Item{}, false, nil
+	}
+
+	list, err := client.List(storage.ListOptions{
+		SHA256:     checksum,
+		MaxResults: 1,
+	})
+	if err != nil {
+		return storage.Item{}, false, fmt.Errorf("storage lookup failed: %w", err)
+	}
+	if len(list.Items) == 0 {
+		return storage.Item{}, false, nil
+	}
+
+	return list.Items[0], true, nil
+}
+
+// streamUpload uploads the data from src to client without storing it locally. src is reopened for every upload
+// attempt. The checksum of the transferred data is verified against the given checksum (if not empty), as well as
+// the one reported by the storage. Uploads that fail verification are deleted.
+func streamUpload(client *http.AppStore, filename, description, checksum, out string, src source) (storage.Item, error) {
+	first, size, err := src()
+	if err != nil {
+		return storage.Item{}, err
+	}
+
+	bar := newProgressBar(out, size, "Transferring")
+	defer bar.Close()
+
+	h := sha256.New()
+	open := func() (io.ReadCloser, error) {
+		r := first
+		first = nil
+		if r == nil {
+			var err error
+			if r, _, err = src(); err != nil {
+				return nil, err
+			}
+		}
+
+		// Every attempt starts from the beginning.
+		h.Reset()
+		bar.Reset()
+
+		return &hashingReader{r: r, h: h, bar: bar}, nil
+	}
+
+	item, err := client.UploadStreamFunc(filename, description, size, open)
+	if first != nil {
+		_ = first.Close()
+	}
+	if err != nil {
+		return storage.Item{}, err
+	}
+
+	sum := fmt.Sprintf("%x", h.Sum(nil))
+	if (checksum != "" && !strings.EqualFold(sum, checksum)) || (item.SHA256 != "" && !strings.EqualFold(sum, item.SHA256)) {
+		_ = client.Delete(item.ID)
+		want := checksum
+		if want == "" {
+			want = item.SHA256
+		}
+		return storage.Item{}, fmt.Errorf("checksum mismatch: transferred %s, expected %s", sum, want)
+	}
+
+	return item, nil
+}
+
+// hashingReader hashes and reports the progress of everything that's read from r.
+type hashingReader struct {
+	r   io.ReadCloser
+	h   hash.Hash
+	bar *progressbar.ProgressBar
+}
+
+func (r *hashingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.h.Write(p[:n])
+	_ = r.bar.Add(n)
+	return n, err
+}
+
+func (r *hashingReader) Close() error {
+	return r.r.Close()
+}
@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	sauceHTTP "github.com/saucelabs/saucectl/internal/http"
+)
+
+func Test_streamUpload(t *testing.T) {
+	content := "app content"
+	checksum := fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
+
+	tests := []struct {
+		name        string
+		checksum    string
+		serverSum   string
+		wantErr     bool
+		wantDeleted bool
+	}{
+		{
+			name:      "matching checksums",
+			checksum:  checksum,
+			serverSum: checksum,
+		},
+		{
+			name:      "no expected checksum",
+			serverSum: checksum,
+		},
+		{
+			name:        "mismatch with expected checksum",
+			checksum:    strings.Repeat("0", 64),
+			serverSum:   checksum,
+			wantErr:     true,
+			wantDeleted: true,
+		},
+		{
+			name:        "mismatch with stored checksum",
+			checksum:    checksum,
+			serverSum:   strings.Repeat("0", 64),
+			wantErr:     true,
+			wantDeleted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleted := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/v1/storage/upload":
+					_, _ = io.Copy(io.Discard, r.Body)
+					w.WriteHeader(http.StatusCreated)
+					_ = json.NewEncoder(w).Encode(sauceHTTP.UploadResponse{
+						Item: sauceHTTP.Item{ID: "app-id", Name: "app.apk", SHA256: tt.serverSum},
+					})
+				case r.Method == http.MethodDelete && r.URL.Path == "/v1/storage/files/app-id":
+					deleted = true
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			client := sauceHTTP.NewAppStore(server.URL, "", "", 10*time.Second)
+			item, err := streamUpload(client, "app.apk", "", tt.checksum, "json",
+				func() (io.ReadCloser, int64, error) {
+					return io.NopCloser(strings.NewReader(content)), int64(len(content)), nil
+				})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("streamUpload() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && item.ID != "app-id" {
+				t.Errorf("streamUpload() item.ID = %s, want app-id", item.ID)
+			}
+			if deleted != tt.wantDeleted {
+				t.Errorf("streamUpload() deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	cmds "github.com/saucelabs/saucectl/internal/cmd"
+	"github.com/saucelabs/saucectl/internal/segment"
+	"github.com/saucelabs/saucectl/internal/storage"
+	"github.com/saucelabs/saucectl/internal/usage"
+	"github.com/spf13/cobra"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+func TagCommand() *cobra.Command {
+	var remove bool
+
+	cmd := &cobra.Command{
+		Use:   "tag <fileID> <tag>...",
+		Short: "Adds tags to, or removes tags from, a file in Sauce Storage.",
+		Long: "Adds tags to, or removes tags from, a file in Sauce Storage. " +
+			"Tags are stored as hashtags in the file description, e.g. 'Nightly build #release'.",
+		SilenceUsage: true,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 || args[0] == "" {
+				return errors.New("no ID specified")
+			}
+			if len(args) < 2 {
+				return errors.New("no tags specified")
+			}
+
+			return nil
+		},
+		PreRun: func(cmd *cobra.Command, args []string) {
+			tracker := segment.DefaultTracker
+
+			go func() {
+				tracker.Collect(
+					cases.Title(language.English).String(cmds.FullName(cmd)),
+					usage.Properties{}.SetFlags(cmd.Flags()),
+				)
+				_ = tracker.Close()
+			}()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			item, err := appsClient.Get(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to retrieve file: %w", err)
+			}
+
+			var description string
+			if remove {
+				description = storage.SetTags(item.Description, nil, args[1:])
+			} else {
+				description = storage.SetTags(item.Description, args[1:], nil)
+			}
+
+			item, err = appsClient.Describe(item.ID, description)
+			if err != nil {
+				return fmt.Errorf("failed to update file: %w", err)
+			}
+
+			fmt.Printf("Tags of %s: %s\n", item.ID, strings.Join(item.Tags, ", "))
+
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&remove, "remove", false, "Removes the specified tags instead of adding them.")
+
+	return cmd
+}
@@ -3,8 +3,12 @@ package storage
 import (
 	"errors"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
+	"path"
 
+	"github.com/saucelabs/saucectl/internal/apps"
 	cmds "github.com/saucelabs/saucectl/internal/cmd"
 	"github.com/saucelabs/saucectl/internal/hashio"
 	"github.com/saucelabs/saucectl/internal/progress"
@@ -21,13 +25,25 @@ func UploadCommand() *cobra.Command {
 	var out string
 	var force bool
 	var description string
+	var fromURL string
+	var checksum string
 
 	cmd := &cobra.Command{
 		Use: "upload filename",
 		Short: "Uploads an app file to Sauce Storage and returns a unique file ID assigned to the app. " +
 			"Sauce Storage supports app files in *.apk, *.aab, *.ipa, or *.zip format.",
+		Long: "Uploads an app file to Sauce Storage and returns a unique file ID assigned to the app. " +
+			"Sauce Storage supports app files in *.apk, *.aab, *.ipa, or *.zip format.\n\n" +
+			"With --from-url, the file is streamed from the given URL to Sauce Storage without being stored locally. " +
+			"The filename defaults to the last element of the URL path.",
 		SilenceUsage: true,
 		Args: func(cmd *cobra.Command, args []string) error {
+			if fromURL != "" {
+				if len(args) > 1 {
+					return errors.New("too many arguments")
+				}
+				return nil
+			}
 			if len(args) == 0 || args[0] == "" {
 				return errors.New("no filename specified")
 			}
@@ -46,6 +62,14 @@ func UploadCommand() *cobra.Command {
 			}()
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if fromURL != "" {
+				var filename string
+				if len(args) > 0 {
+					filename = args[0]
+				}
+				return uploadFromURL(fromURL, filename, description, checksum, out, force)
+			}
+
 			file, err := os.Open(args[0])
 			if err != nil {
 				return fmt.Errorf("failed to open file: %w", err)
@@ -116,10 +140,69 @@ func UploadCommand() *cobra.Command {
 		"Forces the upload to happen, even if there's already a file in storage with a matching checksum.",
 	)
 	flags.StringVarP(&description, "description", "d", "", "A description to distinguish your app.")
+	flags.StringVar(&fromURL, "from-url", "",
+		"Upload the file from this URL instead of a local file. The optional filename argument names the file in storage.",
+	)
+	flags.StringVar(&checksum, "sha256", "",
+		"The expected SHA-256 checksum of the file at --from-url. "+
+			"Used to verify the transfer and to skip the upload if the file is already stored.",
+	)
 
 	return cmd
 }
 
+// uploadFromURL streams the file at fileURL to storage under the given filename. If filename is empty, it's derived
+// from fileURL.
+func uploadFromURL(fileURL, filename, description, checksum, out string, force bool) error {
+	if !apps.IsRemote(fileURL) {
+		return fmt.Errorf("invalid URL: %s", fileURL)
+	}
+	if filename == "" {
+		u, _ := url.Parse(fileURL)
+		filename = path.Base(u.Path)
+		if filename == "/" || filename == "." {
+			return errors.New("unable to derive a filename from the URL; please specify one")
+		}
+	}
+
+	var item storage.Item
+	skipUpload := false
+	if !force {
+		var err error
+		if item, skipUpload, err = findBySHA256(&appsClient, checksum); err != nil {
+			return err
+		}
+	}
+
+	if !skipUpload {
+		var err error
+		item, err = streamUpload(&appsClient, filename, description, checksum, out,
+			func() (io.ReadCloser, int64, error) {
+				return appsClient.DownloadURL(fileURL)
+			})
+		if err != nil {
+			return fmt.Errorf("failed to upload file: %w", err)
+		}
+	}
+
+	switch out {
+	case "text":
+		if skipUpload {
+			println("File already stored! The ID of your file is " + item.ID)
+			return nil
+		}
+		println("Success! The ID of your file is " + item.ID)
+	case "json":
+		if err := renderJSON(item); err != nil {
+			return fmt.Errorf("failed to render output: %w", err)
+		}
+	default:
+		return errors.New("unknown output format")
+	}
+
+	return nil
+}
+
 func newProgressBar(outputFormat string, size int64, description ...string) *progressbar.ProgressBar {
 	switch outputFormat {
 	case "text":
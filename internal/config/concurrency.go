@@ -0,0 +1,82 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// ConcurrencyAuto is the concurrency value that sizes the concurrency according to what's available to the account.
+const ConcurrencyAuto = "auto"
+
+// AutoConcurrency represents the settings that size the concurrency according to the account's concurrency allowance
+// and current usage. It's enabled by setting the concurrency to ConcurrencyAuto.
+type AutoConcurrency struct {
+	// Enabled is set by `concurrency: auto`.
+	Enabled bool `yaml:"-" json:"-"`
+	// Max caps the concurrency. No cap, if 0.
+	Max int `yaml:"max,omitempty" json:"-"`
+	// Reserve is the concurrency that is left available to others using the same account, e.g. other pipelines.
+	Reserve int `yaml:"reserve,omitempty" json:"-"`
+}
+
+// Validate validates the auto concurrency settings.
+func (a AutoConcurrency) Validate() error {
+	if a.Max < 0 {
+		return errors.New("max should not be less than 0")
+	}
+	if a.Reserve < 0 {
+		return errors.New("reserve should not be less than 0")
+	}
+	return nil
+}
+
+// Size returns the concurrency to use, given the concurrency that's available to the account.
+func (a AutoConcurrency) Size(available int) int {
+	n := available - a.Reserve
+	if a.Max > 0 {
+		n = min(n, a.Max)
+	}
+	return max(n, 1)
+}
+
+// concurrencyHook is a decode hook that turns `concurrency: auto` into an enabled AutoConcurrency, since the
+// concurrency itself is a number.
+func concurrencyHook(_ reflect.Type, to reflect.Type, data interface{}) (interface{}, error) {
+	if to != reflect.TypeOf(SauceConfig{}) {
+		return data, nil
+	}
+	m, ok := data.(map[string]interface{})
+	if !ok {
+		return data, nil
+	}
+
+	var key string
+	for k, v := range m {
+		if s, ok := v.(string); ok && strings.EqualFold(k, "concurrency") && strings.EqualFold(s, ConcurrencyAuto) {
+			key = k
+		}
+	}
+	if key == "" {
+		return data, nil
+	}
+
+	cfg := make(map[string]interface{}, len(m)+1)
+	auto := map[string]interface{}{}
+	for k, v := range m {
+		if strings.EqualFold(k, "autoConcurrency") {
+			if a, ok := v.(map[string]interface{}); ok {
+				for ak, av := range a {
+					auto[ak] = av
+				}
+			}
+			continue
+		}
+		cfg[k] = v
+	}
+	auto["enabled"] = true
+	cfg[key] = 0
+	cfg["autoConcurrency"] = auto
+
+	return cfg, nil
+}
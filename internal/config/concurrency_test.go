@@ -0,0 +1,73 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutoConcurrency_Size(t *testing.T) {
+	tests := []struct {
+		name      string
+		auto      AutoConcurrency
+		available int
+		want      int
+	}{
+		{name: "all available", auto: AutoConcurrency{}, available: 10, want: 10},
+		{name: "capped", auto: AutoConcurrency{Max: 4}, available: 10, want: 4},
+		{name: "reserved", auto: AutoConcurrency{Reserve: 3}, available: 10, want: 7},
+		{name: "reserved and capped", auto: AutoConcurrency{Max: 8, Reserve: 3}, available: 10, want: 7},
+		{name: "nothing available", auto: AutoConcurrency{Reserve: 3}, available: 2, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.auto.Size(tt.available))
+		})
+	}
+}
+
+func TestConcurrencyHook(t *testing.T) {
+	tests := []struct {
+		name  string
+		input map[string]interface{}
+		want  SauceConfig
+	}{
+		{
+			name:  "number",
+			input: map[string]interface{}{"concurrency": 5},
+			want:  SauceConfig{Concurrency: 5},
+		},
+		{
+			name:  "number from flag",
+			input: map[string]interface{}{"concurrency": "5"},
+			want:  SauceConfig{Concurrency: 5},
+		},
+		{
+			name:  "auto",
+			input: map[string]interface{}{"concurrency": "auto"},
+			want:  SauceConfig{AutoConcurrency: AutoConcurrency{Enabled: true}},
+		},
+		{
+			name: "auto with settings",
+			input: map[string]interface{}{
+				"concurrency":     "auto",
+				"autoconcurrency": map[string]interface{}{"max": 10, "reserve": 2},
+			},
+			want: SauceConfig{AutoConcurrency: AutoConcurrency{Enabled: true, Max: 10, Reserve: 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got SauceConfig
+			dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+				DecodeHook:       concurrencyHook,
+				WeaklyTypedInput: true,
+				Result:           &got,
+			})
+			assert.NoError(t, err)
+			assert.NoError(t, dec.Decode(tt.input))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
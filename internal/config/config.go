@@ -20,7 +20,9 @@ import (
 	// httploader needs to be loaded to be able to fetch http-based schemas.
 	_ "github.com/santhosh-tekuri/jsonschema/v5/httploader"
 
+	"github.com/saucelabs/saucectl/internal/archive"
 	"github.com/saucelabs/saucectl/internal/msg"
+	"github.com/saucelabs/saucectl/internal/secret"
 	"github.com/saucelabs/saucectl/internal/viper"
 )
 
@@ -33,20 +35,46 @@ type Metadata struct {
 type LaunchOrder string
 
 const (
+	// LaunchOrderFailRate launches the suites with the highest historical fail rate first.
 	LaunchOrderFailRate LaunchOrder = "fail rate"
+	// LaunchOrderDuration launches the suites with the longest historical duration first, which minimizes the total
+	// duration of runs with limited concurrency.
+	LaunchOrderDuration LaunchOrder = "duration"
 )
 
+// ValidateLaunchOrder checks whether the launch order is supported. An empty launch order retains the order of the
+// suites in the config.
+func ValidateLaunchOrder(o LaunchOrder) error {
+	if o != "" && o != LaunchOrderFailRate && o != LaunchOrderDuration {
+		return fmt.Errorf(msg.InvalidLaunchingOption, o, fmt.Sprintf("'%s' or '%s'", LaunchOrderFailRate, LaunchOrderDuration))
+	}
+	return nil
+}
+
 // SauceConfig represents sauce labs related settings.
 type SauceConfig struct {
-	Region      string            `yaml:"region,omitempty" json:"region"`
-	Metadata    Metadata          `yaml:"metadata,omitempty" json:"metadata"`
-	Tunnel      Tunnel            `yaml:"tunnel,omitempty" json:"tunnel,omitempty"`
-	Concurrency int               `yaml:"concurrency,omitempty" json:"concurrency,omitempty"`
-	Sauceignore string            `yaml:"sauceignore,omitempty" json:"sauceignore,omitempty"`
-	Experiments map[string]string `yaml:"experiments,omitempty" json:"experiments,omitempty"`
-	Retries     int               `yaml:"retries,omitempty" json:"-"`
-	Visibility  string            `yaml:"visibility,omitempty" json:"-"`
-	LaunchOrder LaunchOrder       `yaml:"launchOrder,omitempty" json:"launchOrder,omitempty"`
+	Region          string            `yaml:"region,omitempty" json:"region"`
+	Metadata        Metadata          `yaml:"metadata,omitempty" json:"metadata"`
+	Tunnel          Tunnel            `yaml:"tunnel,omitempty" json:"tunnel,omitempty"`
+	Concurrency     int               `yaml:"concurrency,omitempty" json:"concurrency,omitempty"`
+	AutoConcurrency AutoConcurrency   `yaml:"autoConcurrency,omitempty" json:"-"`
+	Sauceignore     string            `yaml:"sauceignore,omitempty" json:"sauceignore,omitempty"`
+	Experiments     map[string]string `yaml:"experiments,omitempty" json:"experiments,omitempty"`
+	Retries         int               `yaml:"retries,omitempty" json:"-"`
+	RetryPolicy     RetryPolicy       `yaml:"retryPolicy,omitempty" json:"-"`
+	Visibility      string            `yaml:"visibility,omitempty" json:"-"`
+	LaunchOrder     LaunchOrder       `yaml:"launchOrder,omitempty" json:"launchOrder,omitempty"`
+	Redact          Redact            `yaml:"redact,omitempty" json:"-"`
+	Archive         archive.Options   `yaml:"archive,omitempty" json:"-"`
+}
+
+// Redact represents the settings for redacting sensitive values from the config and CLI flags that are attached to
+// jobs.
+type Redact struct {
+	// Keys are config keys (e.g. 'npm.registries.authToken') whose values are redacted.
+	Keys []string `yaml:"keys,omitempty" json:"-"`
+	// Patterns are regular expressions. Matching parts of any value are redacted.
+	Patterns []string `yaml:"patterns,omitempty" json:"-"`
 }
 
 // DeviceOptions represents the devices capabilities required from a real device.
@@ -154,6 +182,7 @@ type Reporters struct {
 	JUnit struct {
 		Enabled  bool   `yaml:"enabled"`
 		Filename string `yaml:"filename"`
+		Mode     string `yaml:"mode"`
 	} `yaml:"junit"`
 
 	JSON struct {
@@ -333,56 +362,78 @@ func Unmarshal(cfgPath string, project interface{}) error {
 		decodeCfg.DecodeHook = mapstructure.ComposeDecodeHookFunc(
 			mapstructure.StringToTimeDurationHookFunc(),
 			mapstructure.StringToSliceHookFunc(","),
+			concurrencyHook,
 			func(in reflect.Kind, out reflect.Kind, v interface{}) (interface{}, error) {
-				return expandEnv(v), nil
+				return expandEnv(v)
 			},
 		)
 	})
 }
 
-func expandEnv(v interface{}) interface{} {
+// expandEnv expands environment variables and secret references in v.
+func expandEnv(v interface{}) (interface{}, error) {
 	if v == nil {
-		return nil
+		return nil, nil
 	}
 	switch reflect.TypeOf(v).Kind() {
 	case reflect.String:
-		return os.ExpandEnv(v.(string))
+		return secret.Expand(v.(string))
 	case reflect.Slice:
 		if val, ok := v.([]string); ok {
 			var strs []string
 			for _, item := range val {
-				strs = append(strs, os.ExpandEnv(item))
+				s, err := secret.Expand(item)
+				if err != nil {
+					return nil, err
+				}
+				strs = append(strs, s)
 			}
-			return strs
+			return strs, nil
 		}
 		if val, ok := v.([]interface{}); ok {
 			var items []interface{}
 			for _, item := range val {
-				items = append(items, expandEnv(item))
+				i, err := expandEnv(item)
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, i)
 			}
-			return items
+			return items, nil
 		}
 	case reflect.Map:
 		if mp, ok := v.(map[string]string); ok {
 			for key, val := range mp {
-				mp[key] = os.ExpandEnv(val)
+				s, err := secret.Expand(val)
+				if err != nil {
+					return nil, err
+				}
+				mp[key] = s
 			}
-			return mp
+			return mp, nil
 		}
 		if mp, ok := v.(map[string]interface{}); ok {
 			for key, val := range mp {
-				mp[key] = expandEnv(val)
+				i, err := expandEnv(val)
+				if err != nil {
+					return nil, err
+				}
+				mp[key] = i
 			}
-			return mp
+			return mp, nil
 		}
 		if mp, ok := v.(map[interface{}]interface{}); ok {
 			for key, val := range mp {
-				mp[key] = expandEnv(val)
+				i, err := expandEnv(val)
+				if err != nil {
+					return nil, err
+				}
+				mp[key] = i
 			}
-			return mp
+			return mp, nil
 		}
 	}
-	return v
+	return v, nil
 }
 
 // SetDefaults updates tunnel default values
@@ -606,3 +657,12 @@ func ValidateRegistries(registries []Registry) error {
 	}
 	return nil
 }
+
+// SuiteSauceignore returns the sauceignore file that applies to a suite, which is the suite's own, if set, or the
+// project's otherwise.
+func SuiteSauceignore(projectFile, suiteFile string) string {
+	if suiteFile != "" {
+		return suiteFile
+	}
+	return projectFile
+}
@@ -172,7 +172,8 @@ func TestConfig_ExpandEnv(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result := expandEnv(tc.input)
+			result, err := expandEnv(tc.input)
+			assert.NoError(t, err)
 			assert.False(t, strings.Contains(fmt.Sprint(result), "$"))
 			assert.Equal(t, tc.expected, result)
 		})
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SuiteDependencies describes the suites that a suite depends on.
+type SuiteDependencies struct {
+	Suite     string
+	DependsOn []string
+}
+
+// ValidateDependencies checks that suites only depend on other, existing suites and that their dependencies don't
+// form a cycle.
+func ValidateDependencies(suites []SuiteDependencies) error {
+	deps := make(map[string][]string, len(suites))
+	for _, s := range suites {
+		deps[s.Suite] = s.DependsOn
+	}
+
+	for _, s := range suites {
+		for _, d := range s.DependsOn {
+			if d == s.Suite {
+				return fmt.Errorf("suite %q depends on itself", s.Suite)
+			}
+			if _, ok := deps[d]; !ok {
+				return fmt.Errorf("suite %q depends on unknown suite %q", s.Suite, d)
+			}
+		}
+	}
+
+	// Depth-first search for back edges, which close a cycle.
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(suites))
+	var path []string
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("circular suite dependency: %s -> %s", strings.Join(path, " -> "), name)
+		case visited:
+			return nil
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		for _, d := range deps[name] {
+			if err := visit(d); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+
+	for _, s := range suites {
+		if err := visit(s.Suite); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ShardedDependencies returns the dependencies of a suite, with dependencies on sharded suites replaced by
+// dependencies on each of their shards. shards maps the names of sharded suites to the names of their shards.
+func ShardedDependencies(dependsOn []string, shards map[string][]string) []string {
+	if len(dependsOn) == 0 || len(shards) == 0 {
+		return dependsOn
+	}
+
+	var deps []string
+	for _, d := range dependsOn {
+		if names, ok := shards[d]; ok {
+			deps = append(deps, names...)
+			continue
+		}
+		deps = append(deps, d)
+	}
+	return deps
+}
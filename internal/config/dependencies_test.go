@@ -0,0 +1,74 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDependencies(t *testing.T) {
+	tests := []struct {
+		name    string
+		suites  []SuiteDependencies
+		wantErr string
+	}{
+		{
+			name: "no dependencies",
+			suites: []SuiteDependencies{
+				{Suite: "smoke"},
+				{Suite: "regression"},
+			},
+		},
+		{
+			name: "chain",
+			suites: []SuiteDependencies{
+				{Suite: "regression", DependsOn: []string{"smoke", "api"}},
+				{Suite: "smoke"},
+				{Suite: "api", DependsOn: []string{"smoke"}},
+			},
+		},
+		{
+			name: "unknown suite",
+			suites: []SuiteDependencies{
+				{Suite: "regression", DependsOn: []string{"smoke"}},
+			},
+			wantErr: `suite "regression" depends on unknown suite "smoke"`,
+		},
+		{
+			name: "self",
+			suites: []SuiteDependencies{
+				{Suite: "smoke", DependsOn: []string{"smoke"}},
+			},
+			wantErr: `suite "smoke" depends on itself`,
+		},
+		{
+			name: "cycle",
+			suites: []SuiteDependencies{
+				{Suite: "a", DependsOn: []string{"b"}},
+				{Suite: "b", DependsOn: []string{"c"}},
+				{Suite: "c", DependsOn: []string{"a"}},
+			},
+			wantErr: "circular suite dependency: a -> b -> c -> a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDependencies(tt.suites)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestShardedDependencies(t *testing.T) {
+	shards := map[string][]string{
+		"smoke": {"smoke - 1/2", "smoke - 2/2"},
+	}
+
+	assert.Equal(t, []string{"smoke - 1/2", "smoke - 2/2", "api"}, ShardedDependencies([]string{"smoke", "api"}, shards))
+	assert.Equal(t, []string{"api"}, ShardedDependencies([]string{"api"}, shards))
+	assert.Nil(t, ShardedDependencies(nil, shards))
+}
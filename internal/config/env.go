@@ -0,0 +1,60 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/saucelabs/saucectl/internal/msg"
+	"github.com/saucelabs/saucectl/internal/secret"
+)
+
+// MergeEnv merges the given env maps into dst, which is allocated if needed. Maps that come later take precedence
+// over earlier ones. Returns dst unchanged if there is nothing to merge.
+//
+// The precedence for env vars across saucectl is:
+// --env flag > root-level env > root-level envFile > suite-level env > suite-level envFile.
+func MergeEnv(dst map[string]string, envs ...map[string]string) map[string]string {
+	for _, env := range envs {
+		for k, v := range env {
+			if dst == nil {
+				dst = map[string]string{}
+			}
+			dst[k] = v
+		}
+	}
+	return dst
+}
+
+// ResolveEnvFiles reads the given env files and merges their variables with env. Variables in env take precedence
+// over those from files, and later files take precedence over earlier ones. Values read from files are subject to the
+// same expansion as values in the config file.
+func ResolveEnvFiles(env map[string]string, files []string) (map[string]string, error) {
+	if len(files) == 0 {
+		return env, nil
+	}
+
+	var fromFiles map[string]string
+	for _, f := range files {
+		vars, err := secret.ReadEnvFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read envFile: %w", err)
+		}
+		for k, v := range vars {
+			if vars[k], err = secret.Expand(v); err != nil {
+				return nil, fmt.Errorf("failed to expand %s in %s: %w", k, f, err)
+			}
+		}
+		fromFiles = MergeEnv(fromFiles, vars)
+	}
+
+	return MergeEnv(fromFiles, env), nil
+}
+
+// WarnUnsupportedEnv logs a warning that the given suite defines env vars that can't be honored by target.
+func WarnUnsupportedEnv(suiteName, target string, env map[string]string) {
+	if len(env) == 0 {
+		return
+	}
+	log.Warn().Msgf(msg.EnvNotSupported, suiteName, target)
+}
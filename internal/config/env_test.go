@@ -0,0 +1,59 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gotest.tools/v3/fs"
+)
+
+func TestMergeEnv(t *testing.T) {
+	testCases := []struct {
+		name string
+		dst  map[string]string
+		envs []map[string]string
+		want map[string]string
+	}{
+		{
+			name: "nothing to merge",
+			want: nil,
+		},
+		{
+			name: "merge into nil",
+			envs: []map[string]string{{"A": "1"}},
+			want: map[string]string{"A": "1"},
+		},
+		{
+			name: "later maps take precedence",
+			dst:  map[string]string{"A": "suite", "B": "suite"},
+			envs: []map[string]string{{"A": "root", "C": "root"}, {"A": "flag"}},
+			want: map[string]string{"A": "flag", "B": "suite", "C": "root"},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MergeEnv(tt.dst, tt.envs...))
+		})
+	}
+}
+
+func TestResolveEnvFiles(t *testing.T) {
+	dir := fs.NewDir(t, "envfiles",
+		fs.WithFile("base.env", "A=base\nB=base\nC=base\n"),
+		fs.WithFile("override.env", "B=override\nD=${SAUCECTL_TEST_ENVFILE}\n"),
+	)
+	defer dir.Remove()
+	t.Setenv("SAUCECTL_TEST_ENVFILE", "expanded")
+
+	got, err := ResolveEnvFiles(map[string]string{"C": "inline"}, []string{dir.Join("base.env"), dir.Join("override.env")})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"A": "base", "B": "override", "C": "inline", "D": "expanded"}, got)
+
+	_, err = ResolveEnvFiles(nil, []string{dir.Join("missing.env")})
+	assert.Error(t, err)
+
+	env := map[string]string{"A": "1"}
+	got, err = ResolveEnvFiles(env, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, env, got)
+}
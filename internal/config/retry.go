@@ -0,0 +1,132 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// RetryReason is the reason for which a suite is retried.
+type RetryReason string
+
+// Supported retry reasons.
+const (
+	// RetryOnError retries suites that failed to start or ended in an error state, which indicates an issue with the
+	// infrastructure rather than with the tests.
+	RetryOnError RetryReason = "error"
+	// RetryOnTimeout retries suites that exceeded their timeout.
+	RetryOnTimeout RetryReason = "timeout"
+	// RetryOnFailure retries suites that have test failures, as well as suites that have yet to reach their
+	// passThreshold.
+	RetryOnFailure RetryReason = "failure"
+)
+
+// RetryReasons are all supported retry reasons.
+var RetryReasons = []RetryReason{RetryOnError, RetryOnTimeout, RetryOnFailure}
+
+// RetryPolicy represents the settings that control when and how often suites are retried.
+type RetryPolicy struct {
+	// RetryOn are the reasons for which suites are retried. Defaults to all reasons.
+	RetryOn []RetryReason `yaml:"retryOn,omitempty" json:"-"`
+	// Budgets limit the number of retries per reason. They apply within the retries of the suite (or project), which
+	// remain the cap on retries across all reasons.
+	Budgets map[RetryReason]int `yaml:"budgets,omitempty" json:"-"`
+	// Delay is the delay before the first retry of a suite.
+	Delay time.Duration `yaml:"delay,omitempty" json:"-"`
+	// Multiplier grows the delay exponentially with each subsequent retry of a suite. Defaults to 1, i.e. a constant
+	// delay.
+	Multiplier float64 `yaml:"multiplier,omitempty" json:"-"`
+	// MaxDelay caps the delay between retries. Unlimited if 0.
+	MaxDelay time.Duration `yaml:"maxDelay,omitempty" json:"-"`
+	// MaxAttempts caps the number of attempts across all suites of a run. Once reached, suites are no longer
+	// retried. Unlimited if 0.
+	MaxAttempts int `yaml:"maxAttempts,omitempty" json:"-"`
+}
+
+// Validate checks whether the policy is valid.
+func (p RetryPolicy) Validate() error {
+	for _, r := range p.RetryOn {
+		if !isRetryReason(r) {
+			return fmt.Errorf("unknown retry reason %q; options: %s, %s, %s", r, RetryOnError, RetryOnTimeout, RetryOnFailure)
+		}
+	}
+	for r, n := range p.Budgets {
+		if !isRetryReason(r) {
+			return fmt.Errorf("unknown retry budget %q; options: %s, %s, %s", r, RetryOnError, RetryOnTimeout, RetryOnFailure)
+		}
+		if n < 0 {
+			return fmt.Errorf("retry budget for %s should not be less than 0", r)
+		}
+	}
+	if p.Delay < 0 || p.MaxDelay < 0 {
+		return fmt.Errorf("retry delays should not be negative")
+	}
+	if p.Multiplier != 0 && p.Multiplier < 1 {
+		return fmt.Errorf("retry delay multiplier should not be less than 1")
+	}
+	if p.MaxAttempts < 0 {
+		return fmt.Errorf("maxAttempts should not be less than 0")
+	}
+
+	return nil
+}
+
+// RetriesOn reports whether suites are retried for the given reason.
+func (p RetryPolicy) RetriesOn(reason RetryReason) bool {
+	if len(p.RetryOn) == 0 {
+		return true
+	}
+	for _, r := range p.RetryOn {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// Budget returns the number of retries for the given reason. retries is the number of retries of the suite, which
+// caps the budget the policy defines for the reason, if any.
+func (p RetryPolicy) Budget(reason RetryReason, retries int) int {
+	if n, ok := p.Budgets[reason]; ok {
+		return min(n, retries)
+	}
+	return retries
+}
+
+// Backoff returns the delay before the given retry (starting at 1) of a suite.
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	if p.Delay <= 0 || retry < 1 {
+		return 0
+	}
+
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	d := float64(p.Delay) * math.Pow(multiplier, float64(retry-1))
+	if p.MaxDelay > 0 && d > float64(p.MaxDelay) {
+		return p.MaxDelay
+	}
+	if d > math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(d)
+}
+
+// SuiteRetries returns the number of retries of a suite. The suite's own setting, if any, overrides the project's.
+func SuiteRetries(project int, suite *int) int {
+	if suite != nil {
+		return *suite
+	}
+	return project
+}
+
+func isRetryReason(reason RetryReason) bool {
+	for _, r := range RetryReasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		wantErr bool
+	}{
+		{name: "empty", policy: RetryPolicy{}},
+		{
+			name: "valid",
+			policy: RetryPolicy{
+				RetryOn:     []RetryReason{RetryOnError, RetryOnTimeout},
+				Budgets:     map[RetryReason]int{RetryOnError: 3, RetryOnFailure: 0},
+				Delay:       10 * time.Second,
+				Multiplier:  2,
+				MaxDelay:    time.Minute,
+				MaxAttempts: 20,
+			},
+		},
+		{name: "unknown reason", policy: RetryPolicy{RetryOn: []RetryReason{"flake"}}, wantErr: true},
+		{name: "unknown budget", policy: RetryPolicy{Budgets: map[RetryReason]int{"flake": 1}}, wantErr: true},
+		{name: "negative budget", policy: RetryPolicy{Budgets: map[RetryReason]int{RetryOnError: -1}}, wantErr: true},
+		{name: "negative delay", policy: RetryPolicy{Delay: -time.Second}, wantErr: true},
+		{name: "shrinking delay", policy: RetryPolicy{Multiplier: 0.5}, wantErr: true},
+		{name: "negative max attempts", policy: RetryPolicy{MaxAttempts: -1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRetryPolicy_RetriesOn(t *testing.T) {
+	all := RetryPolicy{}
+	for _, r := range RetryReasons {
+		assert.True(t, all.RetriesOn(r), r)
+	}
+
+	infra := RetryPolicy{RetryOn: []RetryReason{RetryOnError, RetryOnTimeout}}
+	assert.True(t, infra.RetriesOn(RetryOnError))
+	assert.True(t, infra.RetriesOn(RetryOnTimeout))
+	assert.False(t, infra.RetriesOn(RetryOnFailure))
+}
+
+func TestRetryPolicy_Budget(t *testing.T) {
+	p := RetryPolicy{Budgets: map[RetryReason]int{RetryOnError: 5, RetryOnTimeout: 0}}
+
+	assert.Equal(t, 2, p.Budget(RetryOnError, 2))
+	assert.Equal(t, 5, p.Budget(RetryOnError, 7))
+	assert.Equal(t, 0, p.Budget(RetryOnTimeout, 2))
+	assert.Equal(t, 2, p.Budget(RetryOnFailure, 2))
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy RetryPolicy
+		retry  int
+		want   time.Duration
+	}{
+		{name: "no delay", policy: RetryPolicy{}, retry: 3, want: 0},
+		{name: "constant", policy: RetryPolicy{Delay: time.Second}, retry: 3, want: time.Second},
+		{name: "first retry", policy: RetryPolicy{Delay: time.Second, Multiplier: 2}, retry: 1, want: time.Second},
+		{name: "exponential", policy: RetryPolicy{Delay: time.Second, Multiplier: 2}, retry: 4, want: 8 * time.Second},
+		{name: "capped", policy: RetryPolicy{Delay: time.Second, Multiplier: 2, MaxDelay: 5 * time.Second}, retry: 4, want: 5 * time.Second},
+		{name: "huge", policy: RetryPolicy{Delay: time.Hour, Multiplier: 10}, retry: 100, want: time.Duration(1<<63 - 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.policy.Backoff(tt.retry))
+		})
+	}
+}
+
+func TestSuiteRetries(t *testing.T) {
+	zero := 0
+	assert.Equal(t, 3, SuiteRetries(3, nil))
+	assert.Equal(t, 0, SuiteRetries(3, &zero))
+}
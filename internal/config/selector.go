@@ -0,0 +1,154 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/saucelabs/saucectl/internal/msg"
+)
+
+// SuiteSelector selects suites by name and by tags.
+type SuiteSelector struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+	tags    []string
+	notTags []string
+
+	names []string
+	desc  string
+}
+
+// NewSuiteSelector creates a SuiteSelector.
+//
+// Suites are selected if their name matches any of the include patterns (or if there are none), and doesn't match any
+// of the exclude patterns. A pattern is either an exact suite name, a glob where '*' matches any sequence of
+// characters and '?' matches a single character, or a regular expression enclosed in slashes, e.g. '/^login-.+$/'.
+//
+// Additionally, a suite must have at least one of the given tags, unless only negated tags are given. Tags prefixed
+// with '!' deselect suites that have that tag.
+func NewSuiteSelector(include, exclude, tags []string) (SuiteSelector, error) {
+	sel := SuiteSelector{names: include}
+
+	var err error
+	if sel.include, err = compileSuitePatterns(include); err != nil {
+		return sel, err
+	}
+	if sel.exclude, err = compileSuitePatterns(exclude); err != nil {
+		return sel, err
+	}
+
+	for _, t := range tags {
+		t = strings.TrimSpace(t)
+		if negated, ok := strings.CutPrefix(t, "!"); ok {
+			if negated != "" {
+				sel.notTags = append(sel.notTags, negated)
+			}
+			continue
+		}
+		if t != "" {
+			sel.tags = append(sel.tags, t)
+		}
+	}
+
+	var desc []string
+	if len(include) > 0 {
+		desc = append(desc, fmt.Sprintf("select-suite=%s", strings.Join(include, ",")))
+	}
+	if len(exclude) > 0 {
+		desc = append(desc, fmt.Sprintf("exclude-suite=%s", strings.Join(exclude, ",")))
+	}
+	if len(tags) > 0 {
+		desc = append(desc, fmt.Sprintf("suite-tags=%s", strings.Join(tags, ",")))
+	}
+	sel.desc = strings.Join(desc, " ")
+
+	return sel, nil
+}
+
+// IsZero returns true if the selector doesn't filter out any suites.
+func (s SuiteSelector) IsZero() bool {
+	return len(s.include) == 0 && len(s.exclude) == 0 && len(s.tags) == 0 && len(s.notTags) == 0
+}
+
+// Match returns true if a suite with the given name and tags is selected.
+func (s SuiteSelector) Match(name string, tags []string) bool {
+	if len(s.include) > 0 && !matchAny(s.include, name) {
+		return false
+	}
+	if matchAny(s.exclude, name) {
+		return false
+	}
+	if len(s.tags) > 0 && !containsAny(tags, s.tags) {
+		return false
+	}
+	return !containsAny(tags, s.notTags)
+}
+
+// NoMatchError returns the error that describes that no suite matches the selector.
+func (s SuiteSelector) NoMatchError() error {
+	// Selecting a single suite by its exact name is the most common case, so keep its error concise.
+	if len(s.names) == 1 && len(s.exclude) == 0 && len(s.tags) == 0 && len(s.notTags) == 0 &&
+		!strings.HasPrefix(s.names[0], "/") && !strings.ContainsAny(s.names[0], "*?") {
+		return fmt.Errorf(msg.SuiteNameNotFound, s.names[0])
+	}
+	return fmt.Errorf(msg.NoSuiteSelected, s.desc)
+}
+
+// compileSuitePatterns compiles the given suite name patterns into regular expressions.
+func compileSuitePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	var rr []*regexp.Regexp
+	for _, p := range patterns {
+		if p == "" {
+			continue
+		}
+
+		expr := "^" + globToRegexp(p) + "$"
+		if len(p) > 1 && strings.HasPrefix(p, "/") && strings.HasSuffix(p, "/") {
+			expr = p[1 : len(p)-1]
+		}
+
+		r, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid suite pattern '%s': %w", p, err)
+		}
+		rr = append(rr, r)
+	}
+	return rr, nil
+}
+
+// globToRegexp converts the glob pattern p into a regular expression.
+func globToRegexp(p string) string {
+	var sb strings.Builder
+	for _, r := range p {
+		switch r {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	return sb.String()
+}
+
+func matchAny(rr []*regexp.Regexp, s string) bool {
+	for _, r := range rr {
+		if r.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(ss []string, candidates []string) bool {
+	for _, s := range ss {
+		for _, c := range candidates {
+			if s == c {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuiteSelector_Match(t *testing.T) {
+	type suite struct {
+		name string
+		tags []string
+	}
+	suites := []suite{
+		{name: "login chrome", tags: []string{"smoke"}},
+		{name: "login firefox", tags: []string{"smoke", "slow"}},
+		{name: "checkout chrome", tags: []string{"regression"}},
+		{name: "checkout/v2 safari"},
+	}
+
+	testCases := []struct {
+		name    string
+		include []string
+		exclude []string
+		tags    []string
+		want    []string
+	}{
+		{
+			name: "no selection",
+			want: []string{"login chrome", "login firefox", "checkout chrome", "checkout/v2 safari"},
+		},
+		{
+			name:    "exact names",
+			include: []string{"login chrome", "checkout chrome"},
+			want:    []string{"login chrome", "checkout chrome"},
+		},
+		{
+			name:    "glob",
+			include: []string{"checkout*"},
+			want:    []string{"checkout chrome", "checkout/v2 safari"},
+		},
+		{
+			name:    "regex",
+			include: []string{"/(chrome|safari)$/"},
+			want:    []string{"login chrome", "checkout chrome", "checkout/v2 safari"},
+		},
+		{
+			name:    "exclude",
+			exclude: []string{"*firefox", "/^checkout/"},
+			want:    []string{"login chrome"},
+		},
+		{
+			name: "tags",
+			tags: []string{"smoke", "regression"},
+			want: []string{"login chrome", "login firefox", "checkout chrome"},
+		},
+		{
+			name: "negated tags",
+			tags: []string{"!slow"},
+			want: []string{"login chrome", "checkout chrome", "checkout/v2 safari"},
+		},
+		{
+			name:    "all combined",
+			include: []string{"login*", "checkout*"},
+			exclude: []string{"checkout/*"},
+			tags:    []string{"smoke", "!slow"},
+			want:    []string{"login chrome"},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			sel, err := NewSuiteSelector(tt.include, tt.exclude, tt.tags)
+			assert.NoError(t, err)
+
+			var got []string
+			for _, s := range suites {
+				if sel.Match(s.name, s.tags) {
+					got = append(got, s.name)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSuiteSelector_NoMatchError(t *testing.T) {
+	sel, _ := NewSuiteSelector([]string{"suite3"}, nil, nil)
+	assert.EqualError(t, sel.NoMatchError(), "no suite named 'suite3' found")
+
+	sel, _ = NewSuiteSelector([]string{"suite*"}, nil, []string{"!slow"})
+	assert.EqualError(t, sel.NoMatchError(), "no suite matches the selection: select-suite=suite* suite-tags=!slow")
+}
+
+func TestNewSuiteSelector_InvalidRegex(t *testing.T) {
+	_, err := NewSuiteSelector([]string{"/(/"}, nil, nil)
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -47,6 +48,7 @@ type Project struct {
 	Reporters     config.Reporters     `yaml:"reporters,omitempty" json:"-"`
 	Defaults      config.Defaults      `yaml:"defaults,omitempty" json:"defaults"`
 	Env           map[string]string    `yaml:"env,omitempty" json:"env"`
+	EnvFile       []string             `yaml:"envFile,omitempty" json:"-"`
 	EnvFlag       map[string]string    `yaml:"-" json:"-"`
 	Notifications config.Notifications `yaml:"notifications,omitempty" json:"-"`
 }
@@ -60,16 +62,21 @@ type Playwright struct {
 // Suite represents the playwright-cucumberjs test suite configuration.
 type Suite struct {
 	Name             string            `yaml:"name,omitempty" json:"name"`
+	Tags             []string          `yaml:"tags,omitempty" json:"-"`
 	BrowserName      string            `yaml:"browserName,omitempty" json:"browserName"`
 	BrowserVersion   string            `yaml:"browserVersion,omitempty" json:"browserVersion"`
 	PlatformName     string            `yaml:"platformName,omitempty" json:"platformName"`
 	Env              map[string]string `yaml:"env,omitempty" json:"env"`
+	EnvFile          []string          `yaml:"envFile,omitempty" json:"-"`
 	Shard            string            `yaml:"shard,omitempty" json:"shard"`
 	Timeout          time.Duration     `yaml:"timeout,omitempty" json:"timeout"`
 	ScreenResolution string            `yaml:"screenResolution,omitempty" json:"screenResolution"`
 	PreExec          []string          `yaml:"preExec,omitempty" json:"preExec"`
 	Options          Options           `yaml:"options,omitempty" json:"options"`
 	PassThreshold    int               `yaml:"passThreshold,omitempty" json:"-"`
+	Retries          *int              `yaml:"retries,omitempty" json:"-"`
+	Priority         int               `yaml:"priority,omitempty" json:"-"`
+	DependsOn        []string          `yaml:"dependsOn,omitempty" json:"-"`
 	SmartRetry       config.SmartRetry `yaml:"smartRetry,omitempty" json:"-"`
 }
 
@@ -97,6 +104,17 @@ func FromFile(cfgPath string) (Project, error) {
 		return p, err
 	}
 
+	var err error
+	if p.Env, err = config.ResolveEnvFiles(p.Env, p.EnvFile); err != nil {
+		return p, err
+	}
+	for i := range p.Suites {
+		s := &p.Suites[i]
+		if s.Env, err = config.ResolveEnvFiles(s.Env, s.EnvFile); err != nil {
+			return p, fmt.Errorf("suite '%s': %w", s.Name, err)
+		}
+	}
+
 	p.ConfigFilePath = cfgPath
 
 	return p, nil
@@ -151,16 +169,9 @@ func SetDefaults(p *Project) {
 
 	// Apply global env vars onto every suite.
 	// Precedence: --env flag > root-level env vars > suite-level env vars.
-	for _, env := range []map[string]string{p.Env, p.EnvFlag} {
-		for k, v := range env {
-			for ks := range p.Suites {
-				s := &p.Suites[ks]
-				if s.Env == nil {
-					s.Env = map[string]string{}
-				}
-				s.Env[k] = v
-			}
-		}
+	// Env vars from an envFile have lower precedence than env vars on the same level.
+	for i := range p.Suites {
+		p.Suites[i].Env = config.MergeEnv(p.Suites[i].Env, p.Env, p.EnvFlag)
 	}
 }
 
@@ -177,6 +188,18 @@ func Validate(p *Project) error {
 		return fmt.Errorf(msg.InvalidVisibility, p.Sauce.Visibility, strings.Join(config.ValidVisibilityValues, ","))
 	}
 
+	if err := p.Sauce.Archive.Validate(); err != nil {
+		return fmt.Errorf("invalid archive settings: %w", err)
+	}
+
+	if err := p.Sauce.RetryPolicy.Validate(); err != nil {
+		return fmt.Errorf("invalid retry policy: %w", err)
+	}
+
+	if err := p.Sauce.AutoConcurrency.Validate(); err != nil {
+		return fmt.Errorf("invalid auto concurrency: %w", err)
+	}
+
 	err := config.ValidateRegistries(p.Npm.Registries)
 	if err != nil {
 		return err
@@ -187,8 +210,8 @@ func Validate(p *Project) error {
 		return errors.New(msg.MissingFrameworkVersionConfig)
 	}
 
-	if p.Sauce.LaunchOrder != "" && p.Sauce.LaunchOrder != config.LaunchOrderFailRate {
-		return fmt.Errorf(msg.InvalidLaunchingOption, p.Sauce.LaunchOrder, string(config.LaunchOrderFailRate))
+	if err := config.ValidateLaunchOrder(p.Sauce.LaunchOrder); err != nil {
+		return err
 	}
 
 	for i, v := range p.Suites {
@@ -207,10 +230,17 @@ func Validate(p *Project) error {
 
 		p.Suites[i].Options.Paths = fpath.ExcludeFiles(files, excludedFiles)
 
-		if p.Sauce.Retries < v.PassThreshold-1 {
+		if p.Sauce.RetryPolicy.Budget(config.RetryOnFailure, config.SuiteRetries(p.Sauce.Retries, v.Retries)) < v.PassThreshold-1 {
 			return fmt.Errorf(msg.InvalidPassThreshold)
 		}
 	}
+	var deps []config.SuiteDependencies
+	for _, s := range p.Suites {
+		deps = append(deps, config.SuiteDependencies{Suite: s.Name, DependsOn: s.DependsOn})
+	}
+	if err := config.ValidateDependencies(deps); err != nil {
+		return err
+	}
 	if p.Sauce.Retries < 0 {
 		log.Warn().Int("retries", p.Sauce.Retries).Msg(msg.InvalidReries)
 	}
@@ -223,6 +253,7 @@ func Validate(p *Project) error {
 // shardSuites divides suites into shards based on the pattern.
 func shardSuites(rootDir string, suites []Suite, ccy int) ([]Suite, error) {
 	var shardedSuites []Suite
+	shards := make(map[string][]string)
 
 	for _, s := range suites {
 		if s.Shard != "spec" && s.Shard != "concurrency" {
@@ -248,6 +279,7 @@ func shardSuites(rootDir string, suites []Suite, ccy int) ([]Suite, error) {
 			for _, f := range testFiles {
 				replica := s
 				replica.Name = fmt.Sprintf("%s - %s", s.Name, f)
+				shards[s.Name] = append(shards[s.Name], replica.Name)
 				replica.Options.Paths = []string{f}
 				shardedSuites = append(shardedSuites, replica)
 			}
@@ -257,24 +289,33 @@ func shardSuites(rootDir string, suites []Suite, ccy int) ([]Suite, error) {
 			for i, group := range groups {
 				replica := s
 				replica.Name = fmt.Sprintf("%s - %d/%d", s.Name, i+1, len(groups))
+				shards[s.Name] = append(shards[s.Name], replica.Name)
 				replica.Options.Paths = group
 				shardedSuites = append(shardedSuites, replica)
 			}
 		}
 	}
 
+	for i := range shardedSuites {
+		shardedSuites[i].DependsOn = config.ShardedDependencies(shardedSuites[i].DependsOn, shards)
+	}
+
 	return shardedSuites, nil
 }
 
-// FilterSuites filters out suites in the project that don't match the given suite name.
-func FilterSuites(p *Project, suiteName string) error {
+// FilterSuites filters out suites in the project that aren't matched by the given selector.
+func FilterSuites(p *Project, sel config.SuiteSelector) error {
+	var suites []Suite
 	for _, s := range p.Suites {
-		if s.Name == suiteName {
-			p.Suites = []Suite{s}
-			return nil
+		if sel.Match(s.Name, s.Tags) {
+			suites = append(suites, s)
 		}
 	}
-	return fmt.Errorf("no suite named '%s' found", suiteName)
+	if len(suites) == 0 {
+		return sel.NoMatchError()
+	}
+	p.Suites = suites
+	return nil
 }
 
 // IsSharded checks if the suite is sharded
@@ -289,33 +330,16 @@ func IsSharded(suites []Suite) bool {
 
 // SortByHistory sorts the suites in the order of job history
 func SortByHistory(suites []Suite, history insights.JobHistory) []Suite {
-	hash := map[string]Suite{}
-	for _, s := range suites {
-		hash[s.Name] = s
-	}
-	var res []Suite
-	for _, s := range history.TestCases {
-		if v, ok := hash[s.Name]; ok {
-			res = append(res, v)
-			delete(hash, s.Name)
-		}
-	}
-	for _, v := range suites {
-		if _, ok := hash[v.Name]; ok {
-			res = append(res, v)
-		}
-	}
-	return res
+	return insights.SortByHistory(suites, history, func(s Suite) string { return s.Name })
 }
 
-// FilterFailedTests takes the failed specs in the report and sets them as a test filter in the suite.
+// FilterFailedTests takes the failed scenarios in the report and sets them as a test filter in the suite.
+// Scenarios are selected by 'file:line' if the report contains their line. Otherwise, their feature files are
+// selected and narrowed down to the failed scenarios by name.
 // The test filter remains unchanged if the report does not contain any failed tests.
 func (p *Project) FilterFailedTests(suiteName string, report saucereport.SauceReport) error {
-	specs, err := getFailedSpecFiles(report)
-	if err != nil {
-		return err
-	}
-	if len(specs) == 0 {
+	filter := getFailedScenarios(report)
+	if len(filter.paths) == 0 {
 		return nil
 	}
 
@@ -325,7 +349,10 @@ func (p *Project) FilterFailedTests(suiteName string, report saucereport.SauceRe
 			continue
 		}
 		found = true
-		p.Suites[i].Options.Paths = specs
+		p.Suites[i].Options.Paths = filter.paths
+		if filter.name != "" {
+			p.Suites[i].Options.Name = filter.name
+		}
 	}
 
 	if !found {
@@ -334,22 +361,89 @@ func (p *Project) FilterFailedTests(suiteName string, report saucereport.SauceRe
 	return nil
 }
 
-func getFailedSpecFiles(report saucereport.SauceReport) ([]string, error) {
-	var failedSpecs []string
+// scenarioFilter selects scenarios by their location and, optionally, by a regular expression for their names.
+type scenarioFilter struct {
+	paths []string
+	name  string
+}
+
+// getFailedScenarios returns a filter that selects the failed scenarios in the report. Scenarios are selected by
+// 'file:line' if possible. Scenarios without a line are selected by their feature file and name instead. Since the
+// name filter applies to all paths, it then needs to match every failed scenario. It's omitted entirely if a feature
+// failed without any failed scenarios (e.g. due to a failing hook), which requires the whole feature to be rerun.
+func getFailedScenarios(report saucereport.SauceReport) scenarioFilter {
+	var filter scenarioFilter
 	if report.Status != saucereport.StatusFailed {
-		return failedSpecs, nil
+		return filter
+	}
+
+	seen := map[string]bool{}
+	addPath := func(p string) {
+		if !seen[p] {
+			seen[p] = true
+			filter.paths = append(filter.paths, p)
+		}
 	}
+
+	var names []string
+	var byName, wholeFeature bool
 	for _, s := range report.Suites {
-		re, err := regexp.Compile(".*.feature$")
-		if err != nil {
-			return failedSpecs, err
+		if s.Status != saucereport.StatusFailed || !strings.HasSuffix(s.Name, ".feature") {
+			continue
 		}
-		if s.Status == saucereport.StatusFailed && re.MatchString(s.Name) {
-			failedSpecs = append(failedSpecs, filepath.Clean(s.Name))
+		file := filepath.Clean(s.Name)
+
+		scenarios := s.FailedTests()
+		if len(scenarios) == 0 {
+			wholeFeature = true
+			addPath(file)
+			continue
+		}
+
+		for _, sc := range scenarios {
+			names = append(names, sc.Name)
+			if line, ok := scenarioLine(sc); ok {
+				addPath(fmt.Sprintf("%s:%d", file, line))
+				continue
+			}
+			byName = true
+			addPath(file)
 		}
 	}
 
-	return failedSpecs, nil
+	if byName && !wholeFeature {
+		filter.name = nameExpression(names)
+	}
+
+	return filter
+}
+
+// scenarioLine returns the line of the scenario in its feature file, if the report contains it.
+func scenarioLine(t saucereport.Test) (int, bool) {
+	switch v := t.Metadata["line"].(type) {
+	case float64:
+		return int(v), v > 0
+	case int:
+		return v, v > 0
+	case string:
+		line, err := strconv.Atoi(v)
+		return line, err == nil && line > 0
+	}
+	return 0, false
+}
+
+// nameExpression returns a regular expression that matches any of the given scenario names exactly.
+func nameExpression(names []string) string {
+	seen := map[string]bool{}
+	var quoted []string
+	for _, n := range names {
+		if seen[n] {
+			continue
+		}
+		seen[n] = true
+		quoted = append(quoted, regexp.QuoteMeta(n))
+	}
+	return fmt.Sprintf("^(?:%s)$", strings.Join(quoted, "|"))
 }
 
 // IsSmartRetried checks if the suites contain a smartRetried suite
@@ -165,3 +165,77 @@ func TestCucumber_FilterFailedTests(t *testing.T) {
 		})
 	}
 }
+
+func TestGetFailedScenarios(t *testing.T) {
+	failed := func(name string, line interface{}) saucereport.Test {
+		test := saucereport.Test{Name: name, Status: saucereport.StatusFailed}
+		if line != nil {
+			test.Metadata = saucereport.Metadata{"line": line}
+		}
+		return test
+	}
+	feature := func(name string, tests ...saucereport.Test) saucereport.Suite {
+		return saucereport.Suite{Name: name, Status: saucereport.StatusFailed, Tests: tests}
+	}
+
+	testcases := []struct {
+		name   string
+		report saucereport.SauceReport
+		want   scenarioFilter
+	}{
+		{
+			name: "passed",
+			report: saucereport.SauceReport{
+				Status: saucereport.StatusPassed,
+				Suites: []saucereport.Suite{feature("login.feature", failed("logs in", 3.0))},
+			},
+			want: scenarioFilter{},
+		},
+		{
+			name: "lines",
+			report: saucereport.SauceReport{
+				Status: saucereport.StatusFailed,
+				Suites: []saucereport.Suite{
+					feature("features/login.feature", failed("logs in", 3.0), failed("logs out", 12)),
+					feature("features/cart.feature", failed("adds (one) item", "7")),
+					{Name: "features/search.feature", Status: saucereport.StatusPassed},
+				},
+			},
+			want: scenarioFilter{
+				paths: []string{"features/login.feature:3", "features/login.feature:12", "features/cart.feature:7"},
+			},
+		},
+		{
+			name: "names",
+			report: saucereport.SauceReport{
+				Status: saucereport.StatusFailed,
+				Suites: []saucereport.Suite{
+					feature("features/login.feature", failed("logs in", nil), failed("logs out", nil)),
+					feature("features/cart.feature", failed("adds (one) item", 7.0)),
+				},
+			},
+			want: scenarioFilter{
+				paths: []string{"features/login.feature", "features/cart.feature:7"},
+				name:  `^(?:logs in|logs out|adds \(one\) item)$`,
+			},
+		},
+		{
+			name: "feature without failed scenarios",
+			report: saucereport.SauceReport{
+				Status: saucereport.StatusFailed,
+				Suites: []saucereport.Suite{
+					feature("features/login.feature", failed("logs in", nil)),
+					feature("features/hooks.feature"),
+				},
+			},
+			want: scenarioFilter{
+				paths: []string{"features/login.feature", "features/hooks.feature"},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, getFailedScenarios(tc.report))
+		})
+	}
+}
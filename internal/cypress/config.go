@@ -1,11 +1,14 @@
 package cypress
 
 import (
+	"fmt"
+
 	"github.com/saucelabs/saucectl/internal/config"
 	"github.com/saucelabs/saucectl/internal/cypress/suite"
 	v1 "github.com/saucelabs/saucectl/internal/cypress/v1"
 	"github.com/saucelabs/saucectl/internal/cypress/v1alpha"
 	"github.com/saucelabs/saucectl/internal/saucereport"
+	"github.com/saucelabs/saucectl/internal/yaml"
 )
 
 // Config descriptors.
@@ -15,9 +18,9 @@ var (
 )
 
 type Project interface {
-	FilterSuites(suiteName string) error
+	FilterSuites(sel config.SuiteSelector) error
 	CleanPackages()
-	ApplyFlags(selectedSuite string) error
+	ApplyFlags(sel config.SuiteSelector) error
 	AppendTags([]string)
 	Validate() error
 	IsSharded() bool
@@ -72,3 +75,16 @@ func FromFile(cfgPath string) (Project, error) {
 	}
 	return v1.FromFile(cfgPath)
 }
+
+// Migrate converts the given config document in place to the latest apiVersion.
+// Returns warnings for settings that couldn't be converted automatically.
+func Migrate(doc *yaml.Node) ([]string, error) {
+	version := yaml.LookupString(yaml.Root(doc), "apiVersion")
+	switch version {
+	case v1alpha.APIVersion:
+		return v1alpha.Migrate(doc)
+	case v1.APIVersion:
+		return nil, fmt.Errorf("config is already at the latest apiVersion (%s)", v1.APIVersion)
+	}
+	return nil, fmt.Errorf("unknown apiVersion %q", version)
+}
@@ -0,0 +1,42 @@
+package grep
+
+import (
+	"strings"
+)
+
+// TitleExpression returns a cypress-grep title expression that selects the tests with the given titles.
+//
+// cypress-grep matches titles by substring, separates them by ';' and inverts those that start with '-', none of which
+// can be escaped. A title that contains any of these characters is therefore represented by its longest part that can
+// be expressed. That part still selects the test, though possibly some other tests as well.
+//
+// Returns an empty string, which selects all tests, if there are no titles or any of them can't be expressed at all.
+func TitleExpression(titles []string) string {
+	seen := map[string]bool{}
+	var parts []string
+	for _, t := range titles {
+		p := expressible(t)
+		if p == "" {
+			return ""
+		}
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		parts = append(parts, p)
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// expressible returns the longest part of title that cypress-grep matches literally.
+func expressible(title string) string {
+	var longest string
+	for _, p := range strings.Split(title, ";") {
+		p = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(p), "-"))
+		if len(p) > len(longest) {
+			longest = p
+		}
+	}
+	return longest
+}
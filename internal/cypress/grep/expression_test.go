@@ -0,0 +1,73 @@
+package grep
+
+import "testing"
+
+func TestTitleExpression(t *testing.T) {
+	tests := []struct {
+		name   string
+		titles []string
+		want   string
+	}{
+		{
+			name:   "no titles",
+			titles: nil,
+			want:   "",
+		},
+		{
+			name:   "plain titles",
+			titles: []string{"logs in", "logs out"},
+			want:   "logs in;logs out",
+		},
+		{
+			name:   "duplicates",
+			titles: []string{"logs in", "logs in", "logs out"},
+			want:   "logs in;logs out",
+		},
+		{
+			name:   "separator",
+			titles: []string{"adds a; removes b"},
+			want:   "removes b",
+		},
+		{
+			name:   "leading dash",
+			titles: []string{"- negative amounts are rejected"},
+			want:   "negative amounts are rejected",
+		},
+		{
+			name:   "surrounding whitespace",
+			titles: []string{"  padded title "},
+			want:   "padded title",
+		},
+		{
+			name:   "inexpressible title selects all",
+			titles: []string{"logs in", "-;-"},
+			want:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TitleExpression(tt.titles); got != tt.want {
+				t.Errorf("TitleExpression() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTitleExpression_Selects(t *testing.T) {
+	titles := []string{
+		"logs in",
+		"adds a; removes b",
+		"- negative amounts are rejected",
+		"renders -1",
+	}
+
+	exp := ParseGrepTitleExp(TitleExpression(titles))
+	for _, title := range titles {
+		if !exp.Eval(title) {
+			t.Errorf("expression doesn't select %q", title)
+		}
+	}
+	if exp.Eval("logs out") {
+		t.Errorf("expression selects %q", "logs out")
+	}
+}
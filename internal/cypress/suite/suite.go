@@ -20,25 +20,13 @@ type Suite struct {
 	TimeZone         string            `yaml:"timeZone,omitempty" json:"timeZone"`
 	Env              map[string]string `yaml:"env,omitempty" json:"env"`
 	PassThreshold    int               `yaml:"passThreshold,omitempty" json:"-"`
+	Retries          *int              `yaml:"retries,omitempty" json:"-"`
+	Priority         int               `yaml:"priority,omitempty" json:"-"`
+	DependsOn        []string          `yaml:"dependsOn,omitempty" json:"-"`
+	Sauceignore      string            `yaml:"sauceignore,omitempty" json:"-"`
 }
 
 // SortByHistory sorts the suites in the order of job history
 func SortByHistory(suites []Suite, history insights.JobHistory) []Suite {
-	hash := map[string]Suite{}
-	for _, s := range suites {
-		hash[s.Name] = s
-	}
-	var res []Suite
-	for _, s := range history.TestCases {
-		if v, ok := hash[s.Name]; ok {
-			res = append(res, v)
-			delete(hash, s.Name)
-		}
-	}
-	for _, v := range suites {
-		if _, ok := hash[v.Name]; ok {
-			res = append(res, v)
-		}
-	}
-	return res
+	return insights.SortByHistory(suites, history, func(s Suite) string { return s.Name })
 }
@@ -49,6 +49,7 @@ type Project struct {
 	Artifacts     config.Artifacts     `yaml:"artifacts,omitempty" json:"artifacts"`
 	Reporters     config.Reporters     `yaml:"reporters,omitempty" json:"-"`
 	Env           map[string]string    `yaml:"env,omitempty" json:"env"`
+	EnvFile       []string             `yaml:"envFile,omitempty" json:"-"`
 	EnvFlag       map[string]string    `yaml:"-" json:"-"`
 	Notifications config.Notifications `yaml:"notifications,omitempty" json:"-"`
 }
@@ -56,6 +57,8 @@ type Project struct {
 // Suite represents the cypress test suite configuration.
 type Suite struct {
 	Name             string            `yaml:"name,omitempty" json:"name"`
+	Tags             []string          `yaml:"tags,omitempty" json:"-"`
+	Sauceignore      string            `yaml:"sauceignore,omitempty" json:"-"`
 	Browser          string            `yaml:"browser,omitempty" json:"browser"`
 	BrowserVersion   string            `yaml:"browserVersion,omitempty" json:"browserVersion"`
 	PlatformName     string            `yaml:"platformName,omitempty" json:"platformName"`
@@ -68,6 +71,9 @@ type Suite struct {
 	PreExec          []string          `yaml:"preExec,omitempty" json:"preExec"`
 	TimeZone         string            `yaml:"timeZone,omitempty" json:"timeZone"`
 	PassThreshold    int               `yaml:"passThreshold,omitempty" json:"-"`
+	Retries          *int              `yaml:"retries,omitempty" json:"-"`
+	Priority         int               `yaml:"priority,omitempty" json:"-"`
+	DependsOn        []string          `yaml:"dependsOn,omitempty" json:"-"`
 	SmartRetry       config.SmartRetry `yaml:"smartRetry,omitempty" json:"-"`
 }
 
@@ -77,6 +83,7 @@ type SuiteConfig struct {
 	SpecPattern        []string          `yaml:"specPattern,omitempty" json:"specPattern"`
 	ExcludeSpecPattern []string          `yaml:"excludeSpecPattern,omitempty" json:"excludeSpecPattern,omitempty"`
 	Env                map[string]string `yaml:"env,omitempty" json:"env"`
+	EnvFile            []string          `yaml:"envFile,omitempty" json:"-"`
 }
 
 // Reporter represents a cypress report configuration.
@@ -111,6 +118,17 @@ func FromFile(cfgPath string) (*Project, error) {
 		return p, err
 	}
 
+	var err error
+	if p.Env, err = config.ResolveEnvFiles(p.Env, p.EnvFile); err != nil {
+		return p, err
+	}
+	for i := range p.Suites {
+		s := &p.Suites[i]
+		if s.Config.Env, err = config.ResolveEnvFiles(s.Config.Env, s.Config.EnvFile); err != nil {
+			return p, fmt.Errorf("suite '%s': %w", s.Name, err)
+		}
+	}
+
 	p.ConfigFilePath = cfgPath
 
 	return p, nil
@@ -155,16 +173,12 @@ func (p *Project) SetDefaults() {
 			s.Timeout = p.Defaults.Timeout
 		}
 
-		if s.Config.Env == nil {
-			s.Config.Env = map[string]string{}
-		}
-
 		// Apply global env vars onto suite.
 		// Precedence: --env flag > root-level env vars > suite-level env vars.
-		for _, env := range []map[string]string{p.Env, p.EnvFlag} {
-			for k, v := range env {
-				s.Config.Env[k] = v
-			}
+		// Env vars from an envFile have lower precedence than env vars on the same level.
+		s.Config.Env = config.MergeEnv(s.Config.Env, p.Env, p.EnvFlag)
+		if s.Config.Env == nil {
+			s.Config.Env = map[string]string{}
 		}
 
 		if s.Config.TestingType == "" {
@@ -211,13 +225,25 @@ func (p *Project) Validate() error {
 		return fmt.Errorf(msg.InvalidVisibility, p.Sauce.Visibility, strings.Join(config.ValidVisibilityValues, ","))
 	}
 
+	if err := p.Sauce.Archive.Validate(); err != nil {
+		return fmt.Errorf("invalid archive settings: %w", err)
+	}
+
+	if err := p.Sauce.RetryPolicy.Validate(); err != nil {
+		return fmt.Errorf("invalid retry policy: %w", err)
+	}
+
+	if err := p.Sauce.AutoConcurrency.Validate(); err != nil {
+		return fmt.Errorf("invalid auto concurrency: %w", err)
+	}
+
 	err := config.ValidateRegistries(p.Npm.Registries)
 	if err != nil {
 		return err
 	}
 
-	if p.Sauce.LaunchOrder != "" && p.Sauce.LaunchOrder != config.LaunchOrderFailRate {
-		return fmt.Errorf(msg.InvalidLaunchingOption, p.Sauce.LaunchOrder, string(config.LaunchOrderFailRate))
+	if err := config.ValidateLaunchOrder(p.Sauce.LaunchOrder); err != nil {
+		return err
 	}
 
 	// Validate suites.
@@ -256,10 +282,17 @@ func (p *Project) Validate() error {
 		if len(s.Config.SpecPattern) == 0 {
 			return fmt.Errorf(msg.MissingTestFiles, s.Name)
 		}
-		if p.Sauce.Retries < s.PassThreshold-1 {
+		if p.Sauce.RetryPolicy.Budget(config.RetryOnFailure, config.SuiteRetries(p.Sauce.Retries, s.Retries)) < s.PassThreshold-1 {
 			return fmt.Errorf(msg.InvalidPassThreshold)
 		}
 	}
+	var deps []config.SuiteDependencies
+	for _, s := range p.Suites {
+		deps = append(deps, config.SuiteDependencies{Suite: s.Name, DependsOn: s.DependsOn})
+	}
+	if err := config.ValidateDependencies(deps); err != nil {
+		return err
+	}
 	if p.Sauce.Retries < 0 {
 		log.Warn().Int("retries", p.Sauce.Retries).Msg(msg.InvalidReries)
 	}
@@ -275,6 +308,7 @@ func (p *Project) Validate() error {
 
 func shardSuites(rootDir string, suites []Suite, ccy int, sauceignoreFile string) ([]Suite, error) {
 	var shardedSuites []Suite
+	shards := make(map[string][]string)
 	for _, s := range suites {
 		// Use the original suite if there is nothing to shard.
 		if s.Shard != "spec" && s.Shard != "concurrency" {
@@ -286,7 +320,7 @@ func shardSuites(rootDir string, suites []Suite, ccy int, sauceignoreFile string
 			return shardedSuites, err
 		}
 
-		files = sauceignore.ExcludeSauceIgnorePatterns(files, sauceignoreFile)
+		files = sauceignore.ExcludeSauceIgnorePatterns(files, config.SuiteSauceignore(sauceignoreFile, s.Sauceignore))
 
 		if len(files) == 0 {
 			msg.SuiteSplitNoMatch(s.Name, rootDir, s.Config.SpecPattern)
@@ -321,6 +355,7 @@ func shardSuites(rootDir string, suites []Suite, ccy int, sauceignoreFile string
 			for _, f := range testFiles {
 				replica := s
 				replica.Name = fmt.Sprintf("%s - %s", s.Name, f)
+				shards[s.Name] = append(shards[s.Name], replica.Name)
 				replica.Config.SpecPattern = []string{f}
 				shardedSuites = append(shardedSuites, replica)
 			}
@@ -330,12 +365,17 @@ func shardSuites(rootDir string, suites []Suite, ccy int, sauceignoreFile string
 			for i, group := range fileGroups {
 				replica := s
 				replica.Name = fmt.Sprintf("%s - %d/%d", s.Name, i+1, len(fileGroups))
+				shards[s.Name] = append(shards[s.Name], replica.Name)
 				replica.Config.SpecPattern = group
 				shardedSuites = append(shardedSuites, replica)
 			}
 		}
 	}
 
+	for i := range shardedSuites {
+		shardedSuites[i].DependsOn = config.ShardedDependencies(shardedSuites[i].DependsOn, shards)
+	}
+
 	return shardedSuites, nil
 }
 
@@ -462,6 +502,10 @@ func (p *Project) GetSuites() []suite.Suite {
 			TimeZone:         s.TimeZone,
 			Env:              s.Config.Env,
 			PassThreshold:    s.PassThreshold,
+			Retries:          s.Retries,
+			Priority:         s.Priority,
+			DependsOn:        s.DependsOn,
+			Sauceignore:      s.Sauceignore,
 		})
 	}
 	return suites
@@ -472,21 +516,25 @@ func (p *Project) GetKind() string {
 	return p.Kind
 }
 
-// FilterSuites filters out suites in the project that don't match the given suite name.
-func (p *Project) FilterSuites(suiteName string) error {
+// FilterSuites filters out suites in the project that aren't matched by the given selector.
+func (p *Project) FilterSuites(sel config.SuiteSelector) error {
+	var suites []Suite
 	for _, s := range p.Suites {
-		if s.Name == suiteName {
-			p.Suites = []Suite{s}
-			return nil
+		if sel.Match(s.Name, s.Tags) {
+			suites = append(suites, s)
 		}
 	}
-	return fmt.Errorf(msg.SuiteNameNotFound, suiteName)
+	if len(suites) == 0 {
+		return sel.NoMatchError()
+	}
+	p.Suites = suites
+	return nil
 }
 
 // ApplyFlags applys cli flags on cypress project
-func (p *Project) ApplyFlags(selectedSuite string) error {
-	if selectedSuite != "" {
-		if err := p.FilterSuites(selectedSuite); err != nil {
+func (p *Project) ApplyFlags(sel config.SuiteSelector) error {
+	if !sel.IsZero() {
+		if err := p.FilterSuites(sel); err != nil {
 			return err
 		}
 	}
@@ -520,6 +568,9 @@ func (p *Project) GetSuite() suite.Suite {
 		TimeZone:         s.TimeZone,
 		Env:              s.Config.Env,
 		PassThreshold:    s.PassThreshold,
+		Retries:          s.Retries,
+		Priority:         s.Priority,
+		DependsOn:        s.DependsOn,
 	}
 }
 
@@ -550,10 +601,11 @@ func (p *Project) GetSmartRetry(suiteName string) config.SmartRetry {
 }
 
 // FilterFailedTests takes the failed tests in the report and sets them as a test filter in the suite.
-// The test filter remains unchanged if the report does not contain any failed tests.
+// The test filter remains unchanged if the report does not contain any failed tests, or if their titles can't be
+// expressed as a cypress-grep expression.
 func (p *Project) FilterFailedTests(suiteName string, report saucereport.SauceReport) error {
-	failedTests := saucereport.GetFailedTests(report)
-	if len(failedTests) == 0 {
+	expr := grep.TitleExpression(saucereport.GetFailedTests(report))
+	if expr == "" {
 		return nil
 	}
 
@@ -566,8 +618,7 @@ func (p *Project) FilterFailedTests(suiteName string, report saucereport.SauceRe
 		if p.Suites[i].Config.Env == nil {
 			p.Suites[i].Config.Env = map[string]string{}
 		}
-		p.Suites[i].Config.Env["grep"] = strings.Join(failedTests, ";")
-
+		p.Suites[i].Config.Env["grep"] = expr
 	}
 	if !found {
 		return fmt.Errorf("suite(%s) not found", suiteName)
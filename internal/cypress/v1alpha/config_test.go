@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/saucelabs/saucectl/internal/config"
 	"github.com/saucelabs/saucectl/internal/saucereport"
 	"github.com/stretchr/testify/assert"
 	"gotest.tools/v3/fs"
@@ -62,7 +63,8 @@ func TestFilterSuites(t *testing.T) {
 
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
-			err := tc.config.FilterSuites(tc.suiteName)
+			sel, _ := config.NewSuiteSelector([]string{tc.suiteName}, nil, nil)
+			err := tc.config.FilterSuites(sel)
 			if err != nil {
 				assert.Equal(t, tc.expErr, err.Error())
 			}
@@ -520,6 +522,38 @@ func TestCypressV1Alpha_FilterFailedTests(t *testing.T) {
 			expResult: "",
 			expErr:    nil,
 		},
+		{
+			name:      "it should express titles that cypress-grep doesn't match literally",
+			suiteName: "my suite",
+			report: saucereport.SauceReport{
+				Status: saucereport.StatusFailed,
+				Suites: []saucereport.Suite{
+					{
+						Name:   "my suite",
+						Status: saucereport.StatusFailed,
+						Tests: []saucereport.Test{
+							{
+								Status: saucereport.StatusFailed,
+								Name:   "adds a; removes b",
+							},
+							{
+								Status: saucereport.StatusFailed,
+								Name:   "-1 is rejected",
+							},
+						},
+					},
+				},
+			},
+			project: &Project{
+				Suites: []Suite{
+					{
+						Name: "my suite",
+					},
+				},
+			},
+			expResult: "removes b;1 is rejected",
+			expErr:    nil,
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
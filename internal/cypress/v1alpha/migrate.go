@@ -0,0 +1,129 @@
+package v1alpha
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/saucelabs/saucectl/internal/yaml"
+)
+
+// NextAPIVersion is the config version that Migrate converts to.
+const NextAPIVersion = "v1"
+
+// nativeConfigCandidates are the cypress config files that replace cypress.json, in order of preference.
+var nativeConfigCandidates = []string{"cypress.config.js", "cypress.config.ts", "cypress.config.cjs", "cypress.config.mjs"}
+
+// Migrate converts the given v1alpha config document in place into its v1 equivalent.
+// Comments and key order are retained. Settings that can't be converted automatically are returned as warnings,
+// so that the user can address them manually.
+func Migrate(doc *yaml.Node) (warnings []string, err error) {
+	root := yaml.Root(doc)
+	if v := yaml.LookupString(root, "apiVersion"); v != APIVersion {
+		return nil, fmt.Errorf("unable to migrate apiVersion %q, expected %q", v, APIVersion)
+	}
+	yaml.SetString(root, "apiVersion", NextAPIVersion)
+
+	rootDir := yaml.LookupString(root, "rootDir")
+	if rootDir == "" {
+		rootDir = "."
+	}
+
+	cy := yaml.Lookup(root, "cypress")
+	cfgFile := yaml.LookupString(cy, "configFile")
+	if cfgFile == "" {
+		cfgFile = "cypress.json"
+	}
+
+	if ver := yaml.LookupString(cy, "version"); ver != "" {
+		if v, err := semver.NewVersion(ver); err == nil && v.Major() < 10 {
+			warnings = append(warnings, fmt.Sprintf(
+				"cypress.version %s is not supported by apiVersion %s, which requires Cypress 10 or later. Please update cypress.version.",
+				ver, NextAPIVersion))
+		}
+	}
+
+	native, err := configFromFile(filepath.Join(rootDir, cfgFile))
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf(
+			"Unable to read %s (%v). Assuming the default integrationFolder for spec patterns.", cfgFile, err))
+	}
+	if native.IntegrationFolder == "" {
+		native.IntegrationFolder = "cypress/integration"
+	}
+
+	cfgDir := path.Dir(filepath.ToSlash(cfgFile))
+	specDir := path.Join(cfgDir, filepath.ToSlash(native.IntegrationFolder))
+
+	if strings.HasSuffix(cfgFile, ".json") && cy != nil {
+		newCfgFile := path.Join(cfgDir, nativeConfigCandidates[0])
+		found := false
+		for _, c := range nativeConfigCandidates {
+			if _, err := os.Stat(filepath.Join(rootDir, cfgDir, c)); err == nil {
+				newCfgFile = path.Join(cfgDir, c)
+				found = true
+				break
+			}
+		}
+		yaml.SetString(cy, "configFile", newCfgFile)
+		if !found {
+			warnings = append(warnings, fmt.Sprintf(
+				"Cypress 10+ no longer reads %s. Please create %s, e.g. by using the Cypress migration assistant (npx cypress open).",
+				cfgFile, newCfgFile))
+		}
+
+		if native.PluginsFile != "" {
+			warnings = append(warnings, fmt.Sprintf(
+				"pluginsFile (%s) is no longer supported. Please register your plugins via setupNodeEvents in %s.",
+				native.PluginsFile, newCfgFile))
+		}
+		if native.SupportFile != "" {
+			warnings = append(warnings, fmt.Sprintf(
+				"supportFile (%s) must be moved into the e2e section of %s.", native.SupportFile, newCfgFile))
+		}
+		if f, ok := native.FixturesFolder.(string); ok && f != "" {
+			warnings = append(warnings, fmt.Sprintf(
+				"fixturesFolder (%s) must be moved into %s.", f, newCfgFile))
+		}
+	}
+
+	suites := yaml.Lookup(root, "suites")
+	if !yaml.IsSequence(suites) {
+		return warnings, nil
+	}
+	for _, s := range suites.Content {
+		name := yaml.LookupString(s, "name")
+		c := yaml.Lookup(s, "config")
+		if !yaml.IsMapping(c) {
+			warnings = append(warnings, fmt.Sprintf("Suite '%s' has no config.testFiles. Please add config.specPattern.", name))
+			continue
+		}
+
+		if !migratePatterns(c, "testFiles", "specPattern", specDir) {
+			warnings = append(warnings, fmt.Sprintf("Suite '%s' has no config.testFiles. Please add config.specPattern.", name))
+		}
+		migratePatterns(c, "excludedTestFiles", "excludeSpecPattern", specDir)
+	}
+
+	return warnings, nil
+}
+
+// migratePatterns renames oldKey to newKey in the mapping node n and rebases its patterns, which are relative to the
+// integration folder in v1alpha, onto dir.
+func migratePatterns(n *yaml.Node, oldKey, newKey, dir string) bool {
+	patterns := yaml.Strings(yaml.Lookup(n, oldKey))
+	if !yaml.Rename(n, oldKey, newKey) {
+		return false
+	}
+
+	var rebased []string
+	for _, p := range patterns {
+		rebased = append(rebased, path.Join(dir, filepath.ToSlash(p)))
+	}
+	yaml.SetStrings(n, newKey, rebased)
+
+	return true
+}
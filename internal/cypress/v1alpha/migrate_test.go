@@ -0,0 +1,129 @@
+package v1alpha
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/saucelabs/saucectl/internal/yaml"
+	"gotest.tools/v3/fs"
+)
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name         string
+		files        []fs.PathOp
+		config       string
+		want         string
+		wantWarnings int
+		wantErr      bool
+	}{
+		{
+			name: "default integration folder",
+			files: []fs.PathOp{
+				fs.WithFile("cypress.json", `{}`),
+				fs.WithFile("cypress.config.js", ``),
+			},
+			config: `apiVersion: v1alpha
+kind: cypress
+rootDir: %s
+cypress:
+  # The cypress config.
+  configFile: cypress.json
+  version: 10.1.0
+suites:
+  - name: chrome
+    config:
+      testFiles: ["**/*.*"] # all of them
+      excludedTestFiles:
+        - "skip/*.js"
+`,
+			want: `apiVersion: v1
+kind: cypress
+rootDir: %s
+cypress:
+  # The cypress config.
+  configFile: cypress.config.js
+  version: 10.1.0
+suites:
+  - name: chrome
+    config:
+      specPattern: # all of them
+        - cypress/integration/**/*.*
+      excludeSpecPattern:
+        - cypress/integration/skip/*.js
+`,
+		},
+		{
+			name: "custom integration folder and unconvertible settings",
+			files: []fs.PathOp{
+				fs.WithDir("tests", fs.WithFile("cypress.json", `{"integrationFolder": "./e2e", "pluginsFile": "plugins.js"}`)),
+			},
+			config: `apiVersion: v1alpha
+kind: cypress
+rootDir: %s
+cypress:
+  configFile: tests/cypress.json
+  version: 9.7.0
+suites:
+  - name: chrome
+    config:
+      testFiles: ["login.spec.js"]
+  - name: firefox
+`,
+			want: `apiVersion: v1
+kind: cypress
+rootDir: %s
+cypress:
+  configFile: tests/cypress.config.js
+  version: 9.7.0
+suites:
+  - name: chrome
+    config:
+      specPattern:
+        - tests/e2e/login.spec.js
+  - name: firefox
+`,
+			// version, missing cypress.config.js, pluginsFile, missing testFiles
+			wantWarnings: 4,
+		},
+		{
+			name: "unexpected version",
+			config: `apiVersion: v1
+kind: cypress
+rootDir: %s
+`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := fs.NewDir(t, "cypress-migrate", tt.files...)
+			defer dir.Remove()
+
+			doc, err := yaml.ParseDocument([]byte(fmt.Sprintf(tt.config, dir.Path())))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			warnings, err := Migrate(doc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Migrate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(warnings) != tt.wantWarnings {
+				t.Errorf("Migrate() got %d warnings, want %d: %s", len(warnings), tt.wantWarnings, strings.Join(warnings, "\n"))
+			}
+
+			got, err := yaml.EncodeDocument(doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := fmt.Sprintf(tt.want, dir.Path()); string(got) != want {
+				t.Errorf("Migrate() got:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
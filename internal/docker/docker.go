@@ -0,0 +1,423 @@
+// Package docker provides a job backend that runs suites in local Docker containers instead of the Sauce Labs cloud.
+//
+// The backend implements job.Service and storage.AppService, which is all that saucecloud.CloudRunner depends on to
+// start, poll, stop and collect jobs. A run therefore schedules, retries and reports suites exactly as it would in
+// the Sauce Labs cloud, while the suites themselves run in the same runner images that Sauce Labs uses:
+//
+//   - Project archives are kept in a local Storage rather than uploaded.
+//   - StartJob extracts the archives of a job into a project directory, copies it into a container of the framework's
+//     runner image and returns the container ID as the job ID. The project is copied rather than mounted, since the
+//     runner doesn't run as the user that owns the directory on the host.
+//   - The job is done once the container exits. It passed, if the runner exited with 0.
+//   - The runner writes its assets (console.log, junit.xml, sauce-test-report.json, videos, ...) to the __assets__
+//     directory of the project, which is copied back to the host before the container is removed, and is where
+//     they are read and downloaded from.
+//
+// The docker CLI is used to manage containers, so it has to be installed and on the PATH. Only virtual devices,
+// i.e. browsers that are bundled with the runner image, are supported.
+package docker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/ryanuber/go-glob"
+
+	"github.com/saucelabs/saucectl/internal/config"
+	"github.com/saucelabs/saucectl/internal/framework"
+	"github.com/saucelabs/saucectl/internal/job"
+)
+
+// ProjectDir is where the project is copied to inside the container.
+const ProjectDir = "/home/seluser/__project__"
+
+// AssetsDir is the directory of the project that the runner writes its assets to.
+const AssetsDir = "__assets__"
+
+// RunnerConfigFile is the runner config of the project, which describes the suites to run.
+const RunnerConfigFile = "sauce-runner.json"
+
+// Label marks the containers that were started by saucectl.
+const Label = "com.saucelabs.saucectl"
+
+// Command runs the docker CLI with args, writing its output to stdout and stderr.
+type Command func(ctx context.Context, stdout, stderr io.Writer, args ...string) error
+
+// CLI runs the docker binary that is found on the PATH.
+func CLI(ctx context.Context, stdout, stderr io.Writer, args ...string) error {
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	return cmd.Run()
+}
+
+// JobService runs jobs in local Docker containers.
+type JobService struct {
+	// Storage holds the project archives of jobs.
+	Storage *Storage
+	// MetadataService resolves the runner image of a framework version.
+	MetadataService framework.MetadataService
+	// ArtifactConfig describes which artifacts are downloaded.
+	ArtifactConfig config.ArtifactDownload
+	// Dir is where the projects of jobs are extracted to.
+	Dir string
+	// Docker runs the docker CLI. Defaults to CLI.
+	Docker Command
+
+	mu   sync.Mutex
+	jobs map[string]*container
+}
+
+// container represents the container of a job.
+type container struct {
+	// dir is the project directory of the job on the host.
+	dir string
+	// done is the final state of the job, once its container has been removed.
+	done *job.Job
+}
+
+// New returns a JobService that keeps the archives and projects of its jobs in dir.
+func New(dir string, metadata framework.MetadataService, artifactCfg config.ArtifactDownload) *JobService {
+	return &JobService{
+		Storage:         &Storage{Dir: filepath.Join(dir, "storage")},
+		MetadataService: metadata,
+		ArtifactConfig:  artifactCfg,
+		Dir:             filepath.Join(dir, "jobs"),
+		Docker:          CLI,
+	}
+}
+
+// StartJob extracts the archives of the job and runs its suite in a container of the framework's runner image.
+func (s *JobService) StartJob(ctx context.Context, opts job.StartOptions) (string, bool, error) {
+	if opts.RealDevice {
+		return "", false, errors.New("the docker backend does not support real devices")
+	}
+
+	image, err := s.image(ctx, opts.Framework, opts.FrameworkVersion)
+	if err != nil {
+		return "", false, err
+	}
+
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return "", false, err
+	}
+	dir, err := os.MkdirTemp(s.Dir, "job-")
+	if err != nil {
+		return "", false, err
+	}
+	for _, app := range append([]string{opts.App}, opts.OtherApps...) {
+		if app == "" {
+			continue
+		}
+		if err := s.Storage.Extract(app, dir); err != nil {
+			return "", false, err
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(dir, AssetsDir), 0755); err != nil {
+		return "", false, err
+	}
+
+	// The project is copied into the container as is, so the runner, which runs as a user of its own, needs to be
+	// able to read and write it.
+	if err := share(dir); err != nil {
+		return "", false, err
+	}
+
+	args := []string{"create", "--label", Label}
+	keys := make([]string, 0, len(opts.Env))
+	for k := range opts.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, "--env", fmt.Sprintf("%s=%s", k, opts.Env[k]))
+	}
+	args = append(args, image, "npm", "test", "--", "-r", path.Join(ProjectDir, RunnerConfigFile), "-s", opts.Suite)
+
+	out, err := s.run(ctx, args...)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create container: %w", err)
+	}
+	id := strings.TrimSpace(out)
+
+	if _, err := s.run(ctx, "cp", dir+string(filepath.Separator)+".", id+":"+ProjectDir); err != nil {
+		_, _ = s.run(ctx, "rm", "--force", id)
+		return "", false, fmt.Errorf("failed to copy the project into the container: %w", err)
+	}
+	if _, err := s.run(ctx, "start", id); err != nil {
+		_, _ = s.run(ctx, "rm", "--force", id)
+		return "", false, fmt.Errorf("failed to start container: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.jobs == nil {
+		s.jobs = make(map[string]*container)
+	}
+	s.jobs[id] = &container{dir: dir}
+
+	return id, false, nil
+}
+
+// ReadJob returns the job that runs in the container with the given id.
+func (s *JobService) ReadJob(ctx context.Context, id string, realDevice bool) (job.Job, error) {
+	c, err := s.container(id, realDevice)
+	if err != nil {
+		return job.Job{}, err
+	}
+	if c.done != nil {
+		return *c.done, nil
+	}
+
+	out, err := s.run(ctx, "inspect", "--format", "{{.State.Status}} {{.State.ExitCode}}", id)
+	if err != nil {
+		return job.Job{}, fmt.Errorf("failed to inspect container: %w", err)
+	}
+	var state string
+	var code int
+	if _, err := fmt.Sscan(out, &state, &code); err != nil {
+		return job.Job{}, fmt.Errorf("unexpected container state %q: %w", out, err)
+	}
+
+	j := job.Job{ID: id, Status: job.StateInProgress}
+	j.BaseConfig.PlatformName = "Docker"
+	switch state {
+	case "created":
+		j.Status = job.StateNew
+	case "exited", "dead":
+		j.Status = job.StateComplete
+		j.Passed = code == 0
+	}
+
+	return j, nil
+}
+
+// PollJob polls the job until it's done or timeout is reached. The container is removed once the job is done, or
+// once it timed out, in which case the job is stopped for good.
+func (s *JobService) PollJob(ctx context.Context, id string, interval, timeout time.Duration, realDevice bool) (job.Job, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	if timeout <= 0 {
+		timeout = 24 * time.Hour
+	}
+	deathclock := time.NewTimer(timeout)
+	defer deathclock.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			j, err := s.ReadJob(ctx, id, realDevice)
+			if err != nil {
+				return job.Job{}, err
+			}
+
+			if job.Done(j.Status) {
+				s.remove(ctx, j)
+				return j, nil
+			}
+		case <-deathclock.C:
+			j, err := s.ReadJob(ctx, id, realDevice)
+			if err != nil {
+				return job.Job{}, err
+			}
+			j.TimedOut = true
+			if !job.Done(j.Status) {
+				j.Status = job.StateComplete
+				j.Passed = false
+			}
+			s.remove(ctx, j)
+			return j, nil
+		}
+	}
+}
+
+// remove copies the assets of the finished job back to the host, saves its console output as an asset and removes its
+// container.
+func (s *JobService) remove(ctx context.Context, j job.Job) {
+	c, err := s.container(j.ID, false)
+	if err != nil || c.done != nil {
+		return
+	}
+
+	assets := filepath.Join(c.dir, AssetsDir)
+	if _, err := s.run(ctx, "cp", j.ID+":"+path.Join(ProjectDir, AssetsDir)+"/.", assets); err != nil {
+		log.Warn().Err(err).Str("container", j.ID).Msg("Failed to copy the assets from the container.")
+	}
+
+	logFile := filepath.Join(assets, "console.log")
+	if _, err := os.Stat(logFile); os.IsNotExist(err) {
+		var buf bytes.Buffer
+		if err := s.Docker(ctx, &buf, &buf, "logs", j.ID); err != nil {
+			log.Warn().Err(err).Str("container", j.ID).Msg("Failed to read the container logs.")
+		} else if err := os.WriteFile(logFile, buf.Bytes(), 0644); err != nil {
+			log.Warn().Err(err).Str("container", j.ID).Msg("Failed to save the container logs.")
+		}
+	}
+
+	if _, err := s.run(ctx, "rm", "--force", j.ID); err != nil {
+		log.Warn().Err(err).Str("container", j.ID).Msg("Failed to remove container.")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[j.ID].done = &j
+}
+
+// StopJob stops the container of the job.
+func (s *JobService) StopJob(ctx context.Context, id string, realDevice bool) (job.Job, error) {
+	c, err := s.container(id, realDevice)
+	if err != nil {
+		return job.Job{}, err
+	}
+	if c.done == nil {
+		if _, err := s.run(ctx, "stop", id); err != nil {
+			return job.Job{}, fmt.Errorf("failed to stop container: %w", err)
+		}
+	}
+	return s.ReadJob(ctx, id, realDevice)
+}
+
+// UploadAsset adds the file to the assets of the job.
+func (s *JobService) UploadAsset(jobID string, realDevice bool, fileName string, _ string, content []byte) error {
+	c, err := s.container(jobID, realDevice)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(c.dir, AssetsDir, filepath.Base(fileName)), content, 0644)
+}
+
+// GetJobAssetFileNames returns the names of the assets of the job.
+func (s *JobService) GetJobAssetFileNames(_ context.Context, jobID string, realDevice bool) ([]string, error) {
+	c, err := s.container(jobID, realDevice)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(filepath.Join(c.dir, AssetsDir))
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
+}
+
+// GetJobAssetFileContent returns the content of an asset of the job.
+func (s *JobService) GetJobAssetFileContent(_ context.Context, jobID, fileName string, realDevice bool) ([]byte, error) {
+	c, err := s.container(jobID, realDevice)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(filepath.Join(c.dir, AssetsDir, filepath.Base(fileName)))
+}
+
+// DownloadArtifact copies the assets of the job that match the artifact config to the artifacts folder of the suite.
+func (s *JobService) DownloadArtifact(jobID, suiteName string, realDevice bool) []string {
+	targetDir, err := config.GetSuiteArtifactFolder(suiteName, s.ArtifactConfig)
+	if err != nil {
+		log.Error().Msgf("Unable to create artifacts folder (%v)", err)
+		return []string{}
+	}
+	files, err := s.GetJobAssetFileNames(context.Background(), jobID, realDevice)
+	if err != nil {
+		log.Error().Msgf("Unable to fetch artifacts list (%v)", err)
+		return []string{}
+	}
+	var artifacts []string
+	for _, f := range files {
+		for _, pattern := range s.ArtifactConfig.Match {
+			if glob.Glob(pattern, f) {
+				content, err := s.GetJobAssetFileContent(context.Background(), jobID, f, realDevice)
+				if err == nil {
+					err = os.WriteFile(filepath.Join(targetDir, f), content, 0644)
+				}
+				if err != nil {
+					log.Error().Err(err).Msgf("Failed to download file: %s", f)
+				} else {
+					artifacts = append(artifacts, filepath.Join(targetDir, f))
+				}
+				break
+			}
+		}
+	}
+	return artifacts
+}
+
+// image returns the runner image of the given framework version.
+func (s *JobService) image(ctx context.Context, frameworkName, version string) (string, error) {
+	versions, err := s.MetadataService.Versions(ctx, frameworkName)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch the runner images of %s: %w", frameworkName, err)
+	}
+	for _, v := range versions {
+		if v.FrameworkVersion == version && v.DockerImage != "" {
+			return v.DockerImage, nil
+		}
+	}
+	return "", fmt.Errorf("no runner image found for %s %s", frameworkName, version)
+}
+
+// container returns the container of the job with the given id.
+func (s *JobService) container(id string, realDevice bool) (container, error) {
+	if realDevice {
+		return container{}, errors.New("the docker backend does not support real devices")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.jobs[id]
+	if !ok {
+		return container{}, fmt.Errorf("unknown container %s", id)
+	}
+	return *c, nil
+}
+
+// run runs the docker CLI and returns its output. The error output is part of the returned error, if any.
+func (s *JobService) run(ctx context.Context, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	if err := s.Docker(ctx, &stdout, &stderr, args...); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
+
+// share grants group and others the permissions that the owner has on dir and everything in it.
+func share(dir string) error {
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// Links are copied as is, and chmod would change whatever they point to.
+		if d.Type()&fs.ModeSymlink != 0 {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		perm := info.Mode().Perm()
+		owner := perm & 0700
+		return os.Chmod(p, perm|owner>>3|owner>>6)
+	})
+}
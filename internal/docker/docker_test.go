@@ -0,0 +1,224 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/saucelabs/saucectl/internal/config"
+	"github.com/saucelabs/saucectl/internal/framework"
+	"github.com/saucelabs/saucectl/internal/job"
+	"github.com/saucelabs/saucectl/internal/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDocker fakes the docker CLI with a single container.
+type fakeDocker struct {
+	mu    sync.Mutex
+	calls [][]string
+	state string
+}
+
+func (d *fakeDocker) run(_ context.Context, stdout, stderr io.Writer, args ...string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.calls = append(d.calls, args)
+
+	switch args[0] {
+	case "create":
+		fmt.Fprintln(stdout, "c0ffee")
+	case "inspect":
+		fmt.Fprint(stdout, d.state)
+	case "logs":
+		fmt.Fprint(stdout, "test output")
+	case "stop":
+		d.state = "exited 137"
+	case "pull":
+		fmt.Fprint(stderr, "pull access denied")
+		return errors.New("exit status 1")
+	}
+	return nil
+}
+
+func (d *fakeDocker) commands() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var cmds []string
+	for _, c := range d.calls {
+		cmds = append(cmds, c[0])
+	}
+	return cmds
+}
+
+func newTestService(t *testing.T, d *fakeDocker) *JobService {
+	s := New(t.TempDir(), &mocks.FakeFrameworkInfoReader{
+		VersionsFn: func(ctx context.Context, frameworkName string) ([]framework.Metadata, error) {
+			return []framework.Metadata{
+				{FrameworkName: "playwright", FrameworkVersion: "1.40.0", DockerImage: "saucelabs/stt-playwright-node:v1"},
+			}, nil
+		},
+	}, config.ArtifactDownload{})
+	s.Docker = d.run
+	return s
+}
+
+func startJob(t *testing.T, s *JobService) string {
+	app, err := s.Storage.UploadStream("app.zip", "", bytes.NewReader(zipFiles(t, map[string]string{
+		"tests/a.spec.js": "test",
+	})))
+	assert.NoError(t, err)
+	cfg, err := s.Storage.UploadStream("config.zip", "", bytes.NewReader(zipFiles(t, map[string]string{
+		RunnerConfigFile: "{}",
+	})))
+	assert.NoError(t, err)
+
+	id, isRDC, err := s.StartJob(context.Background(), job.StartOptions{
+		App:              "storage:" + app.ID,
+		OtherApps:        []string{"storage:" + cfg.ID},
+		Suite:            "my suite",
+		Framework:        "playwright",
+		FrameworkVersion: "1.40.0",
+		Env:              map[string]string{"B": "2", "A": "1"},
+	})
+	assert.NoError(t, err)
+	assert.False(t, isRDC)
+	return id
+}
+
+func TestJobService_StartJob(t *testing.T) {
+	d := &fakeDocker{}
+	s := newTestService(t, d)
+
+	id := startJob(t, s)
+	assert.Equal(t, "c0ffee", id)
+
+	dir := s.jobs[id].dir
+	assert.FileExists(t, filepath.Join(dir, "tests", "a.spec.js"))
+	assert.FileExists(t, filepath.Join(dir, RunnerConfigFile))
+	assert.DirExists(t, filepath.Join(dir, AssetsDir))
+
+	assert.Equal(t, []string{
+		"create", "--label", Label, "--env", "A=1", "--env", "B=2",
+		"saucelabs/stt-playwright-node:v1", "npm", "test", "--", "-r", ProjectDir + "/" + RunnerConfigFile,
+		"-s", "my suite",
+	}, d.calls[0])
+	assert.Equal(t, []string{"cp", dir + string(filepath.Separator) + ".", id + ":" + ProjectDir}, d.calls[1])
+	assert.Equal(t, []string{"start", id}, d.calls[2])
+
+	// The runner doesn't run as the owner of the project, but needs to read and write it.
+	info, err := os.Stat(filepath.Join(dir, AssetsDir))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0777), info.Mode().Perm())
+	info, err = os.Stat(filepath.Join(dir, RunnerConfigFile))
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0666), info.Mode().Perm()&0666)
+
+	_, _, err = s.StartJob(context.Background(), job.StartOptions{Framework: "playwright", FrameworkVersion: "0.1.0"})
+	assert.EqualError(t, err, "no runner image found for playwright 0.1.0")
+
+	_, _, err = s.StartJob(context.Background(), job.StartOptions{RealDevice: true})
+	assert.Error(t, err)
+}
+
+func TestJobService_ReadJob(t *testing.T) {
+	tests := []struct {
+		state  string
+		status string
+		passed bool
+	}{
+		{state: "created 0", status: job.StateNew},
+		{state: "running 0", status: job.StateInProgress},
+		{state: "exited 0", status: job.StateComplete, passed: true},
+		{state: "exited 1", status: job.StateComplete},
+	}
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			d := &fakeDocker{state: tt.state}
+			s := newTestService(t, d)
+			id := startJob(t, s)
+
+			j, err := s.ReadJob(context.Background(), id, false)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, j.Status)
+			assert.Equal(t, tt.passed, j.Passed)
+		})
+	}
+
+	s := newTestService(t, &fakeDocker{})
+	_, err := s.ReadJob(context.Background(), "unknown", false)
+	assert.EqualError(t, err, "unknown container unknown")
+}
+
+func TestJobService_PollJob(t *testing.T) {
+	d := &fakeDocker{state: "exited 1"}
+	s := newTestService(t, d)
+	id := startJob(t, s)
+
+	j, err := s.PollJob(context.Background(), id, time.Millisecond, time.Minute, false)
+	assert.NoError(t, err)
+	assert.Equal(t, job.StateComplete, j.Status)
+	assert.False(t, j.Passed)
+	assert.Equal(t, []string{"create", "cp", "start", "inspect", "cp", "logs", "rm"}, d.commands())
+	assert.Equal(t, []string{"cp", id + ":" + ProjectDir + "/" + AssetsDir + "/.", filepath.Join(s.jobs[id].dir, AssetsDir)}, d.calls[4])
+
+	// The container is gone, but the job and its assets remain.
+	j, err = s.ReadJob(context.Background(), id, false)
+	assert.NoError(t, err)
+	assert.Equal(t, job.StateComplete, j.Status)
+
+	content, err := s.GetJobAssetFileContent(context.Background(), id, "console.log", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "test output", string(content))
+}
+
+func TestJobService_PollJob_TimedOut(t *testing.T) {
+	d := &fakeDocker{state: "running 0"}
+	s := newTestService(t, d)
+	id := startJob(t, s)
+
+	j, err := s.PollJob(context.Background(), id, time.Hour, time.Millisecond, false)
+	assert.NoError(t, err)
+	assert.True(t, j.TimedOut)
+	assert.Equal(t, job.StateComplete, j.Status)
+	assert.False(t, j.Passed)
+
+	// The container of a job that timed out is removed as well, so there's nothing left to stop.
+	j, err = s.StopJob(context.Background(), id, false)
+	assert.NoError(t, err)
+	assert.Equal(t, job.StateComplete, j.Status)
+	assert.Equal(t, []string{"create", "cp", "start", "inspect", "cp", "logs", "rm"}, d.commands())
+}
+
+func TestJobService_Assets(t *testing.T) {
+	s := newTestService(t, &fakeDocker{})
+	s.ArtifactConfig = config.ArtifactDownload{Directory: t.TempDir(), Match: []string{"*.xml"}}
+	id := startJob(t, s)
+
+	assert.NoError(t, s.UploadAsset(id, false, "flags.json", "text/plain", []byte("{}")))
+	assert.NoError(t, os.WriteFile(filepath.Join(s.jobs[id].dir, AssetsDir, "junit.xml"), []byte("<xml/>"), 0644))
+
+	names, err := s.GetJobAssetFileNames(context.Background(), id, false)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"flags.json", "junit.xml"}, names)
+
+	artifacts := s.DownloadArtifact(id, "my suite", false)
+	assert.Len(t, artifacts, 1)
+	assert.True(t, strings.HasSuffix(artifacts[0], "junit.xml"))
+	b, err := os.ReadFile(artifacts[0])
+	assert.NoError(t, err)
+	assert.Equal(t, "<xml/>", string(b))
+}
+
+func TestJobService_run(t *testing.T) {
+	s := newTestService(t, &fakeDocker{})
+	_, err := s.run(context.Background(), "pull", "image")
+	assert.EqualError(t, err, "exit status 1: pull access denied")
+}
@@ -0,0 +1,208 @@
+package docker
+
+import (
+	"archive/zip"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	szip "github.com/saucelabs/saucectl/internal/archive/zip"
+	"github.com/saucelabs/saucectl/internal/hashio"
+	"github.com/saucelabs/saucectl/internal/storage"
+)
+
+// Storage is a storage.AppService that keeps files in a local directory instead of the Sauce Labs app storage, so
+// that project archives never leave the machine. Each file is stored as <Dir>/<id>/<filename>.
+type Storage struct {
+	Dir string
+}
+
+// UploadStream stores the contents of reader under the given filename.
+func (s *Storage) UploadStream(filename, description string, reader io.Reader) (storage.Item, error) {
+	id, err := newID()
+	if err != nil {
+		return storage.Item{}, err
+	}
+	dir := filepath.Join(s.Dir, id)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return storage.Item{}, err
+	}
+
+	f, err := os.Create(filepath.Join(dir, filepath.Base(filename)))
+	if err != nil {
+		return storage.Item{}, err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, reader); err != nil {
+		return storage.Item{}, err
+	}
+	if err := f.Close(); err != nil {
+		return storage.Item{}, err
+	}
+
+	item, err := s.Get(id)
+	item.Description = description
+	return item, err
+}
+
+// UploadStreamFunc stores the data served by open under the given filename.
+func (s *Storage) UploadStreamFunc(filename, description string, _ int64, open func() (io.ReadCloser, error)) (storage.Item, error) {
+	rc, err := open()
+	if err != nil {
+		return storage.Item{}, err
+	}
+	defer rc.Close()
+
+	return s.UploadStream(filename, description, rc)
+}
+
+// Download opens the file with the given id.
+func (s *Storage) Download(id string) (io.ReadCloser, int64, error) {
+	filename, err := s.path(id)
+	if err != nil {
+		return nil, 0, err
+	}
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, 0, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, 0, err
+	}
+	return f, info.Size(), nil
+}
+
+// Delete removes the file with the given id.
+func (s *Storage) Delete(id string) error {
+	if _, err := s.path(id); err != nil {
+		return err
+	}
+	return os.RemoveAll(filepath.Join(s.Dir, id))
+}
+
+// DownloadURL is not supported by the local storage.
+func (s *Storage) DownloadURL(_ string) (io.ReadCloser, int64, error) {
+	return nil, 0, errors.New("the local storage does not support downloading from a URL")
+}
+
+// List returns the files that match the name and checksum of opts. Other filters aren't supported and yield no files,
+// so that nothing is reused by accident.
+func (s *Storage) List(opts storage.ListOptions) (storage.List, error) {
+	if opts.Q != "" || opts.Kind != "" {
+		return storage.List{}, nil
+	}
+
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return storage.List{}, nil
+		}
+		return storage.List{}, err
+	}
+
+	var items []storage.Item
+	for _, e := range entries {
+		item, err := s.Get(e.Name())
+		if err != nil {
+			continue
+		}
+		if opts.Name != "" && !strings.EqualFold(item.Name, opts.Name) {
+			continue
+		}
+		if opts.SHA256 != "" && item.SHA256 != opts.SHA256 {
+			continue
+		}
+		items = append(items, item)
+		if opts.MaxResults > 0 && len(items) == opts.MaxResults {
+			break
+		}
+	}
+
+	return storage.List{Items: items, TotalItems: len(items)}, nil
+}
+
+// Get returns the file with the given id.
+func (s *Storage) Get(id string) (storage.Item, error) {
+	filename, err := s.path(id)
+	if err != nil {
+		return storage.Item{}, err
+	}
+	info, err := os.Stat(filename)
+	if err != nil {
+		return storage.Item{}, err
+	}
+	hash, err := hashio.SHA256(filename)
+	if err != nil {
+		return storage.Item{}, err
+	}
+
+	return storage.Item{
+		ID:       id,
+		Name:     info.Name(),
+		Size:     int(info.Size()),
+		Uploaded: info.ModTime(),
+		Kind:     storage.KindOther,
+		SHA256:   hash,
+	}, nil
+}
+
+// Describe is not supported by the local storage.
+func (s *Storage) Describe(_, _ string) (storage.Item, error) {
+	return storage.Item{}, errors.New("the local storage does not support descriptions")
+}
+
+// Extract extracts the zip archive with the given id into dir. The id may be prefixed with "storage:".
+func (s *Storage) Extract(id, dir string) error {
+	id = strings.TrimPrefix(id, "storage:")
+	filename, err := s.path(id)
+	if err != nil {
+		return err
+	}
+	if filepath.Ext(filename) != ".zip" {
+		return fmt.Errorf("unsupported archive %s: only zip archives can be run locally", filepath.Base(filename))
+	}
+
+	zr, err := zip.OpenReader(filename)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		if err := szip.Extract(dir, f); err != nil {
+			return fmt.Errorf("failed to extract %s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+// path returns the path of the file with the given id.
+func (s *Storage) path(id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\.`) {
+		return "", storage.ErrFileNotFound
+	}
+	entries, err := os.ReadDir(filepath.Join(s.Dir, id))
+	if err != nil || len(entries) != 1 {
+		return "", storage.ErrFileNotFound
+	}
+	return filepath.Join(s.Dir, id, entries[0].Name()), nil
+}
+
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
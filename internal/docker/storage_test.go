@@ -0,0 +1,88 @@
+package docker
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/saucelabs/saucectl/internal/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+// zipFiles returns a zip archive that contains files, keyed by name.
+func zipFiles(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestStorage(t *testing.T) {
+	s := Storage{Dir: t.TempDir()}
+
+	item, err := s.UploadStream("app.zip", "my app", strings.NewReader("content"))
+	assert.NoError(t, err)
+	assert.Equal(t, "app.zip", item.Name)
+	assert.Equal(t, "my app", item.Description)
+	assert.Equal(t, 7, item.Size)
+
+	_, err = s.UploadStream("other.zip", "", strings.NewReader("other content"))
+	assert.NoError(t, err)
+
+	list, err := s.List(storage.ListOptions{SHA256: item.SHA256, Name: "APP.zip"})
+	assert.NoError(t, err)
+	assert.Len(t, list.Items, 1)
+	assert.Equal(t, item.ID, list.Items[0].ID)
+
+	list, err = s.List(storage.ListOptions{Q: "app"})
+	assert.NoError(t, err)
+	assert.Empty(t, list.Items)
+
+	rc, size, err := s.Download(item.ID)
+	assert.NoError(t, err)
+	b, _ := io.ReadAll(rc)
+	rc.Close()
+	assert.Equal(t, int64(7), size)
+	assert.Equal(t, "content", string(b))
+
+	assert.NoError(t, s.Delete(item.ID))
+	_, err = s.Get(item.ID)
+	assert.ErrorIs(t, err, storage.ErrFileNotFound)
+	_, err = s.Get("../outside")
+	assert.ErrorIs(t, err, storage.ErrFileNotFound)
+}
+
+func TestStorage_Extract(t *testing.T) {
+	s := Storage{Dir: t.TempDir()}
+	dir := t.TempDir()
+
+	app, err := s.UploadStream("app.zip", "", bytes.NewReader(zipFiles(t, map[string]string{
+		"sauce-runner.json": "{}",
+		"tests/a.spec.js":   "test",
+	})))
+	assert.NoError(t, err)
+	assert.NoError(t, s.Extract("storage:"+app.ID, dir))
+
+	b, err := os.ReadFile(filepath.Join(dir, "tests", "a.spec.js"))
+	assert.NoError(t, err)
+	assert.Equal(t, "test", string(b))
+
+	tgz, err := s.UploadStream("app.tar.gz", "", strings.NewReader("content"))
+	assert.NoError(t, err)
+	assert.ErrorContains(t, s.Extract(tgz.ID, dir), "only zip archives")
+}
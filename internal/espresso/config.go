@@ -40,6 +40,7 @@ type Project struct {
 	Artifacts     config.Artifacts     `yaml:"artifacts,omitempty" json:"artifacts"`
 	Reporters     config.Reporters     `yaml:"reporters,omitempty" json:"-"`
 	Notifications config.Notifications `yaml:"notifications,omitempty" json:"-"`
+	EnvFlag       map[string]string    `yaml:"-" json:"-"`
 }
 
 // Espresso represents espresso apps configuration.
@@ -54,6 +55,7 @@ type Espresso struct {
 // Suite represents the espresso test suite configuration.
 type Suite struct {
 	Name               string                 `yaml:"name,omitempty" json:"name"`
+	Tags               []string               `yaml:"tags,omitempty" json:"-"`
 	TestApp            string                 `yaml:"testApp,omitempty" json:"testApp"`
 	TestAppDescription string                 `yaml:"testAppDescription,omitempty" json:"testAppDescription"`
 	Devices            []config.Device        `yaml:"devices,omitempty" json:"devices"`
@@ -62,6 +64,9 @@ type Suite struct {
 	Timeout            time.Duration          `yaml:"timeout,omitempty" json:"timeout"`
 	AppSettings        config.AppSettings     `yaml:"appSettings,omitempty" json:"appSettings"`
 	PassThreshold      int                    `yaml:"passThreshold,omitempty" json:"-"`
+	Retries            *int                   `yaml:"retries,omitempty" json:"-"`
+	Priority           int                    `yaml:"priority,omitempty" json:"-"`
+	DependsOn          []string               `yaml:"dependsOn,omitempty" json:"-"`
 	SmartRetry         config.SmartRetry      `yaml:"smartRetry,omitempty" json:"-"`
 }
 
@@ -144,6 +149,14 @@ func Validate(p Project) error {
 		return fmt.Errorf(msg.InvalidVisibility, p.Sauce.Visibility, strings.Join(config.ValidVisibilityValues, ","))
 	}
 
+	if err := p.Sauce.RetryPolicy.Validate(); err != nil {
+		return fmt.Errorf("invalid retry policy: %w", err)
+	}
+
+	if err := p.Sauce.AutoConcurrency.Validate(); err != nil {
+		return fmt.Errorf("invalid auto concurrency: %w", err)
+	}
+
 	if p.Espresso.App == "" {
 		return errors.New(msg.MissingAppPath)
 	}
@@ -164,8 +177,8 @@ func Validate(p Project) error {
 		}
 	}
 
-	if p.Sauce.LaunchOrder != "" && p.Sauce.LaunchOrder != config.LaunchOrderFailRate {
-		return fmt.Errorf(msg.InvalidLaunchingOption, p.Sauce.LaunchOrder, string(config.LaunchOrderFailRate))
+	if err := config.ValidateLaunchOrder(p.Sauce.LaunchOrder); err != nil {
+		return err
 	}
 
 	if len(p.Suites) == 0 {
@@ -185,10 +198,18 @@ func Validate(p Project) error {
 		if regio == region.USEast4 && len(suite.Emulators) > 0 {
 			return errors.New(msg.NoEmulatorSupport)
 		}
-		if p.Sauce.Retries < suite.PassThreshold-1 {
+		if p.Sauce.RetryPolicy.Budget(config.RetryOnFailure, config.SuiteRetries(p.Sauce.Retries, suite.Retries)) < suite.PassThreshold-1 {
 			return fmt.Errorf(msg.InvalidPassThreshold)
 		}
 		config.ValidateSmartRetry(suite.SmartRetry)
+		config.WarnUnsupportedEnv(suite.Name, "Espresso", p.EnvFlag)
+	}
+	var deps []config.SuiteDependencies
+	for _, s := range p.Suites {
+		deps = append(deps, config.SuiteDependencies{Suite: s.Name, DependsOn: s.DependsOn})
+	}
+	if err := config.ValidateDependencies(deps); err != nil {
+		return err
 	}
 	if p.Sauce.Retries < 0 {
 		log.Warn().Int("retries", p.Sauce.Retries).Msg(msg.InvalidReries)
@@ -225,15 +246,19 @@ func validateEmulators(suiteName string, emulators []config.Emulator) error {
 	return nil
 }
 
-// FilterSuites filters out suites in the project that don't match the given suite name.
-func FilterSuites(p *Project, suiteName string) error {
+// FilterSuites filters out suites in the project that aren't matched by the given selector.
+func FilterSuites(p *Project, sel config.SuiteSelector) error {
+	var suites []Suite
 	for _, s := range p.Suites {
-		if s.Name == suiteName {
-			p.Suites = []Suite{s}
-			return nil
+		if sel.Match(s.Name, s.Tags) {
+			suites = append(suites, s)
 		}
 	}
-	return fmt.Errorf(msg.SuiteNameNotFound, suiteName)
+	if len(suites) == 0 {
+		return sel.NoMatchError()
+	}
+	p.Suites = suites
+	return nil
 }
 
 func IsSharded(suites []Suite) bool {
@@ -248,23 +273,7 @@ func IsSharded(suites []Suite) bool {
 
 // SortByHistory sorts the suites in the order of job history
 func SortByHistory(suites []Suite, history insights.JobHistory) []Suite {
-	hash := map[string]Suite{}
-	for _, s := range suites {
-		hash[s.Name] = s
-	}
-	var res []Suite
-	for _, s := range history.TestCases {
-		if v, ok := hash[s.Name]; ok {
-			res = append(res, v)
-			delete(hash, s.Name)
-		}
-	}
-	for _, v := range suites {
-		if _, ok := hash[v.Name]; ok {
-			res = append(res, v)
-		}
-	}
-	return res
+	return insights.SortByHistory(suites, history, func(s Suite) string { return s.Name })
 }
 
 // IsSmartRetried checks if the suites contain a smartRetried suite
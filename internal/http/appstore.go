@@ -42,6 +42,37 @@ type Item struct {
 	Name            string `json:"name"`
 	Size            int    `json:"size"`
 	UploadTimestamp int64  `json:"upload_timestamp"`
+	Description     string `json:"description"`
+	Kind            string `json:"kind"`
+	SHA256          string `json:"sha256"`
+	// Metadata is only present for apps.
+	Metadata *Metadata `json:"metadata"`
+}
+
+// Metadata represents the app metadata that the app store extracts from uploaded apps.
+type Metadata struct {
+	// Identifier is the bundle identifier (iOS) or package name (Android).
+	Identifier string `json:"identifier"`
+	Version    string `json:"version"`
+}
+
+// toStorageItem converts the app store representation of an item to a storage.Item.
+func (i Item) toStorageItem() storage.Item {
+	item := storage.Item{
+		ID:          i.ID,
+		Name:        i.Name,
+		Size:        i.Size,
+		Uploaded:    time.Unix(i.UploadTimestamp, 0),
+		Description: i.Description,
+		Tags:        storage.ParseTags(i.Description),
+		Kind:        i.Kind,
+		SHA256:      i.SHA256,
+	}
+	if i.Metadata != nil {
+		item.Version = i.Metadata.Version
+		item.BundleID = i.Metadata.Identifier
+	}
+	return item
 }
 
 // AppStore implements a remote file storage for storage.AppService.
@@ -113,6 +144,9 @@ func (s *AppStore) DownloadURL(url string) (io.ReadCloser, int64, error) {
 }
 
 // UploadStream uploads the contents of reader and stores them under the given filename.
+// Failed uploads are retried with backoff. If reader implements io.ReadSeeker, it's rewound for every attempt,
+// otherwise its content is buffered in memory. The storage API doesn't support chunked uploads, so every attempt
+// restarts the upload from the beginning.
 func (s *AppStore) UploadStream(filename, description string, reader io.Reader) (storage.Item, error) {
 	multipartReader, contentType, err := multipartext.NewMultipartReader("payload", filename, description, reader)
 	if err != nil {
@@ -125,6 +159,75 @@ func (s *AppStore) UploadStream(filename, description string, reader io.Reader)
 	}
 
 	req.Header.Set("Content-Type", contentType)
+
+	return s.doUpload(req)
+}
+
+// UploadStreamFunc uploads the data served by open and stores it under the given filename.
+// Failed uploads are retried with backoff, for which open is called again, so that sources that can't be rewound
+// (e.g. downloads) are streamed without being buffered. The reader of a previous attempt is closed before the next
+// one is opened. size is the number of bytes served by open, or -1 if unknown.
+func (s *AppStore) UploadStreamFunc(filename, description string, size int64, open func() (io.ReadCloser, error)) (storage.Item, error) {
+	var current io.ReadCloser
+	defer func() {
+		if current != nil {
+			_ = current.Close()
+		}
+	}()
+
+	// The source is opened lazily, since the request is probed for its body before it's sent.
+	readerFunc, contentType, envelopeSize, err := multipartext.NewMultipartReaderFunc("payload", filename, description,
+		func() (io.Reader, error) {
+			return &lazyReader{open: func() (io.Reader, error) {
+				if current != nil {
+					_ = current.Close()
+					current = nil
+				}
+				r, err := open()
+				if err != nil {
+					return nil, err
+				}
+				current = r
+				return r, nil
+			}}, nil
+		})
+	if err != nil {
+		return storage.Item{}, err
+	}
+
+	req, err := retryablehttp.NewRequest(http.MethodPost, fmt.Sprintf("%s/v1/storage/upload", s.URL),
+		retryablehttp.ReaderFunc(readerFunc))
+	if err != nil {
+		return storage.Item{}, err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	if size >= 0 {
+		req.ContentLength = size + envelopeSize
+	}
+
+	return s.doUpload(req)
+}
+
+// lazyReader defers opening its source until it's read from for the first time.
+type lazyReader struct {
+	open func() (io.Reader, error)
+	r    io.Reader
+}
+
+func (l *lazyReader) Read(p []byte) (int, error) {
+	if l.r == nil {
+		r, err := l.open()
+		if err != nil {
+			return 0, err
+		}
+		l.r = r
+	}
+	return l.r.Read(p)
+}
+
+// doUpload sends the upload request req and returns the stored item.
+func (s *AppStore) doUpload(req *retryablehttp.Request) (storage.Item, error) {
 	req.SetBasicAuth(s.Username, s.AccessKey)
 
 	resp, err := s.HTTPClient.Do(req)
@@ -139,12 +242,7 @@ func (s *AppStore) UploadStream(filename, description string, reader io.Reader)
 			return storage.Item{}, err
 		}
 
-		return storage.Item{
-			ID:       ur.Item.ID,
-			Name:     ur.Item.Name,
-			Uploaded: time.Unix(ur.Item.UploadTimestamp, 0),
-			Size:     ur.Item.Size,
-		}, err
+		return ur.Item.toStorageItem(), err
 	case 401, 403:
 		return storage.Item{}, storage.ErrAccessDenied
 	case 429:
@@ -178,6 +276,12 @@ func (s *AppStore) List(opts storage.ListOptions) (storage.List, error) {
 	if opts.SHA256 != "" {
 		query.Set("sha256", opts.SHA256)
 	}
+	if opts.Page > 0 {
+		query.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.Kind != "" {
+		query.Set("kind", opts.Kind)
+	}
 
 	uri.RawQuery = query.Encode()
 
@@ -202,17 +306,16 @@ func (s *AppStore) List(opts storage.ListOptions) (storage.List, error) {
 
 		var items []storage.Item
 		for _, v := range listResp.Items {
-			items = append(items, storage.Item{
-				ID:       v.ID,
-				Name:     v.Name,
-				Size:     v.Size,
-				Uploaded: time.Unix(v.UploadTimestamp, 0),
-			})
+			items = append(items, v.toStorageItem())
 		}
 
+		// Items on previous pages don't count towards truncation.
+		page := max(listResp.Page, 1)
 		return storage.List{
-			Items:     items,
-			Truncated: listResp.TotalItems > len(items),
+			Items:      items,
+			Truncated:  listResp.TotalItems > (page-1)*listResp.PerPage+len(items),
+			Page:       listResp.Page,
+			TotalItems: listResp.TotalItems,
 		}, nil
 	case 401, 403:
 		return storage.List{}, storage.ErrAccessDenied
@@ -223,6 +326,72 @@ func (s *AppStore) List(opts storage.ListOptions) (storage.List, error) {
 	}
 }
 
+// Get returns the file with the given id.
+func (s *AppStore) Get(id string) (storage.Item, error) {
+	if id == "" {
+		return storage.Item{}, fmt.Errorf("no id specified")
+	}
+
+	req, err := retryablehttp.NewRequest(http.MethodGet, fmt.Sprintf("%s/v1/storage/files/%s", s.URL, id), nil)
+	if err != nil {
+		return storage.Item{}, err
+	}
+
+	req.SetBasicAuth(s.Username, s.AccessKey)
+
+	return s.doItemRequest(req)
+}
+
+// Describe replaces the description of the file with the given id.
+func (s *AppStore) Describe(id, description string) (storage.Item, error) {
+	if id == "" {
+		return storage.Item{}, fmt.Errorf("no id specified")
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"item": map[string]string{"description": description},
+	})
+	if err != nil {
+		return storage.Item{}, err
+	}
+
+	req, err := retryablehttp.NewRequest(http.MethodPut, fmt.Sprintf("%s/v1/storage/files/%s", s.URL, id), body)
+	if err != nil {
+		return storage.Item{}, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(s.Username, s.AccessKey)
+
+	return s.doItemRequest(req)
+}
+
+// doItemRequest sends a request whose response contains a single item.
+func (s *AppStore) doItemRequest(req *retryablehttp.Request) (storage.Item, error) {
+	resp, err := s.HTTPClient.Do(req)
+	if err != nil {
+		return storage.Item{}, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case 200:
+		var ur UploadResponse
+		if err := json.NewDecoder(resp.Body).Decode(&ur); err != nil {
+			return storage.Item{}, err
+		}
+		return ur.Item.toStorageItem(), nil
+	case 401, 403:
+		return storage.Item{}, storage.ErrAccessDenied
+	case 404:
+		return storage.Item{}, storage.ErrFileNotFound
+	case 429:
+		return storage.Item{}, storage.ErrTooManyRequest
+	default:
+		return storage.Item{}, s.newServerError(resp)
+	}
+}
+
 func (s *AppStore) Delete(id string) error {
 	if id == "" {
 		return fmt.Errorf("no id specified")
@@ -9,6 +9,7 @@ import (
 	"os"
 	"path"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -418,3 +419,253 @@ func TestAppStore_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestAppStore_UploadStream_Retry(t *testing.T) {
+	content := strings.Repeat("0123456789", 100000)
+	dir := fs.NewDir(t, "upload", fs.WithFile("app.zip", content))
+	defer dir.Remove()
+
+	// The stand-in server fails in different ways before it accepts the upload.
+	failures := []func(w http.ResponseWriter, r *http.Request){
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		},
+		func(w http.ResponseWriter, r *http.Request) {
+			// Simulate a dropped connection in the middle of the upload.
+			_, _ = io.CopyN(io.Discard, r.Body, int64(len(content)/2))
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("failed to hijack connection: %v", err)
+				return
+			}
+			_ = conn.Close()
+		},
+		func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		},
+	}
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests <= len(failures) {
+			failures[requests-1](w, r)
+			return
+		}
+
+		reader, err := r.MultipartReader()
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		p, err := reader.NextPart()
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received, _ := io.ReadAll(p)
+		if string(received) != content {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprintf(w, "received %d bytes, want %d", len(received), len(content))
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(UploadResponse{Item{ID: "app-id", Name: p.FileName(), Size: len(received)}})
+	}))
+	defer server.Close()
+
+	client := NewRetryableClient(10 * time.Second)
+	client.RetryWaitMin = time.Millisecond
+	client.RetryWaitMax = 10 * time.Millisecond
+	s := &AppStore{HTTPClient: client, URL: server.URL}
+
+	f, err := os.Open(dir.Join("app.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	got, err := s.UploadStream("app.zip", "", f)
+	if err != nil {
+		t.Fatalf("UploadStream() error = %v", err)
+	}
+	assert.Equal(t, storage.Item{ID: "app-id", Name: "app.zip", Size: len(content), Uploaded: time.Unix(0, 0)}, got)
+	assert.Equal(t, len(failures)+1, requests)
+}
+
+func TestAppStore_Describe(t *testing.T) {
+	item := Item{
+		ID:              "f7c0a1e2",
+		Name:            "app.zip",
+		UploadTimestamp: time.Now().Add(-1 * time.Hour).Unix(),
+		Description:     "Nightly build #release",
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/storage/files/"+item.ID {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			var req struct {
+				Item struct {
+					Description string `json:"description"`
+				} `json:"item"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			item.Description = req.Item.Description
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(UploadResponse{Item: item})
+	}))
+	defer server.Close()
+
+	s := NewAppStore(server.URL, "test", "test", 10*time.Second)
+
+	got, err := s.Get(item.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Nightly build #release", got.Description)
+	assert.Equal(t, []string{"release"}, got.Tags)
+
+	got, err = s.Describe(item.ID, "Nightly build #release #android")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"release", "android"}, got.Tags)
+
+	_, err = s.Get("unknown")
+	assert.ErrorIs(t, err, storage.ErrFileNotFound)
+}
+
+func TestAppStore_List_Pagination(t *testing.T) {
+	var items []Item
+	for i := 0; i < 5; i++ {
+		items = append(items, Item{
+			ID:       fmt.Sprintf("id-%d", i),
+			Name:     "app.apk",
+			Kind:     "android",
+			SHA256:   fmt.Sprintf("sha-%d", i),
+			Metadata: &Metadata{Identifier: "com.example.app", Version: fmt.Sprintf("1.0.%d", i)},
+		})
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("kind") != "android" {
+			_ = json.NewEncoder(w).Encode(ListResponse{})
+			return
+		}
+
+		// The page size is fixed, in order to test pagination with few items.
+		perPage := 2
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		page = max(page, 1)
+		start := min((page-1)*perPage, len(items))
+		end := min(start+perPage, len(items))
+
+		_ = json.NewEncoder(w).Encode(ListResponse{
+			Items:      items[start:end],
+			Page:       page,
+			PerPage:    perPage,
+			TotalItems: len(items),
+		})
+	}))
+	defer server.Close()
+
+	s := NewAppStore(server.URL, "test", "test", 10*time.Second)
+
+	list, err := s.List(storage.ListOptions{Kind: "android", Page: 2})
+	assert.NoError(t, err)
+	assert.True(t, list.Truncated)
+	assert.Equal(t, 2, list.Page)
+	assert.Equal(t, 5, list.TotalItems)
+	assert.Equal(t, []string{"id-2", "id-3"}, []string{list.Items[0].ID, list.Items[1].ID})
+	assert.Equal(t, "1.0.2", list.Items[0].Version)
+	assert.Equal(t, "com.example.app", list.Items[0].BundleID)
+	assert.Equal(t, "sha-2", list.Items[0].SHA256)
+	assert.Equal(t, "android", list.Items[0].Kind)
+
+	list, err = s.List(storage.ListOptions{Kind: "android", Page: 3})
+	assert.NoError(t, err)
+	assert.False(t, list.Truncated)
+	assert.Len(t, list.Items, 1)
+}
+
+func TestAppStore_UploadStreamFunc_Retry(t *testing.T) {
+	content := strings.Repeat("0123456789", 100000)
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			// Simulate a dropped connection in the middle of the upload.
+			_, _ = io.CopyN(io.Discard, r.Body, int64(len(content)/2))
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Errorf("failed to hijack connection: %v", err)
+				return
+			}
+			_ = conn.Close()
+			return
+		}
+
+		reader, err := r.MultipartReader()
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		p, err := reader.NextPart()
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received, _ := io.ReadAll(p)
+		if string(received) != content {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprintf(w, "received %d bytes, want %d", len(received), len(content))
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(UploadResponse{Item{ID: "app-id", Name: p.FileName(), Size: len(received)}})
+	}))
+	defer server.Close()
+
+	client := NewRetryableClient(10 * time.Second)
+	client.RetryWaitMin = time.Millisecond
+	client.RetryWaitMax = 10 * time.Millisecond
+	s := &AppStore{HTTPClient: client, URL: server.URL}
+
+	var opened, closed int
+	open := func() (io.ReadCloser, error) {
+		opened++
+		return &closeCounter{Reader: strings.NewReader(content), closed: &closed}, nil
+	}
+
+	got, err := s.UploadStreamFunc("app.zip", "", int64(len(content)), open)
+	if err != nil {
+		t.Fatalf("UploadStreamFunc() error = %v", err)
+	}
+	assert.Equal(t, storage.Item{ID: "app-id", Name: "app.zip", Size: len(content), Uploaded: time.Unix(0, 0)}, got)
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, 2, opened, "source must be opened once per attempt")
+	assert.Equal(t, 2, closed, "every opened source must be closed")
+}
+
+type closeCounter struct {
+	io.Reader
+	closed *int
+}
+
+func (c *closeCounter) Close() error {
+	*c.closed++
+	return nil
+}
@@ -56,6 +56,7 @@ type InsightsService struct {
 
 var LaunchOptions = map[config.LaunchOrder]string{
 	config.LaunchOrderFailRate: "fail_rate",
+	config.LaunchOrderDuration: "avg_duration",
 }
 
 func NewInsightsService(url string, creds iam.Credentials, timeout time.Duration) InsightsService {
@@ -80,15 +81,16 @@ func (c *InsightsService) GetHistory(ctx context.Context, user iam.User, launchO
 		return insights.JobHistory{}, err
 	}
 
-	jobHistory := mergeJobHistories([]insights.JobHistory{vdc, rdc})
+	jobHistory := mergeJobHistories([]insights.JobHistory{vdc, rdc}, launchOrder)
 	return jobHistory, nil
 }
 
-func mergeJobHistories(histories []insights.JobHistory) insights.JobHistory {
+// mergeJobHistories merges the histories into one, in descending order of the metric the launch order is based on.
+func mergeJobHistories(histories []insights.JobHistory, launchOrder config.LaunchOrder) insights.JobHistory {
 	testCasesMap := map[string]insights.TestCase{}
 	for _, history := range histories {
 		for _, tc := range history.TestCases {
-			addOrReplaceTestCase(testCasesMap, tc)
+			addOrReplaceTestCase(testCasesMap, tc, launchOrder)
 		}
 	}
 	var testCases []insights.TestCase
@@ -96,7 +98,7 @@ func mergeJobHistories(histories []insights.JobHistory) insights.JobHistory {
 		testCases = append(testCases, tc)
 	}
 	sort.Slice(testCases, func(i, j int) bool {
-		return testCases[i].FailRate > testCases[j].FailRate
+		return historyMetric(testCases[i], launchOrder) > historyMetric(testCases[j], launchOrder)
 	})
 	return insights.JobHistory{
 		TestCases: testCases,
@@ -104,18 +106,26 @@ func mergeJobHistories(histories []insights.JobHistory) insights.JobHistory {
 }
 
 // addOrReplaceTestCase adds or replaces the insights.TestCase in the map[string]insights.TestCase
-// If there is already one with the same name, only the highest fail rate is kept.
-func addOrReplaceTestCase(mp map[string]insights.TestCase, tc insights.TestCase) {
+// If there is already one with the same name, only the one with the highest metric for the launch order is kept.
+func addOrReplaceTestCase(mp map[string]insights.TestCase, tc insights.TestCase, launchOrder config.LaunchOrder) {
 	tcRef, present := mp[tc.Name]
 	if !present {
 		mp[tc.Name] = tc
 		return
 	}
-	if tc.FailRate > tcRef.FailRate {
+	if historyMetric(tc, launchOrder) > historyMetric(tcRef, launchOrder) {
 		mp[tc.Name] = tc
 	}
 }
 
+// historyMetric returns the metric of the test case that the launch order is based on.
+func historyMetric(tc insights.TestCase, launchOrder config.LaunchOrder) float64 {
+	if launchOrder == config.LaunchOrderDuration {
+		return tc.AvgDuration
+	}
+	return tc.FailRate
+}
+
 func (c *InsightsService) doGetHistory(ctx context.Context, user iam.User, launchOrder config.LaunchOrder, source string) (insights.JobHistory, error) {
 	start := time.Now().AddDate(0, 0, -7).Unix()
 	now := time.Now().Unix()
@@ -137,7 +147,7 @@ func (c *InsightsService) doGetHistory(ctx context.Context, user iam.User, launc
 		"until":   strconv.FormatInt(now, 10),
 		"limit":   "200",
 		"offset":  "0",
-		"sort_by": string(launchOrder),
+		"sort_by": LaunchOptions[launchOrder],
 	}
 	for k, v := range queries {
 		q.Add(k, v)
@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/saucelabs/saucectl/internal/config"
 	"github.com/saucelabs/saucectl/internal/iam"
 	"github.com/saucelabs/saucectl/internal/insights"
 )
@@ -296,7 +297,33 @@ func Test_mergeJobHistories(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			assert.Equalf(t, tt.want, mergeJobHistories(tt.histories), "mergeJobHistories(%v)", tt.histories)
+			assert.Equalf(t, tt.want, mergeJobHistories(tt.histories, config.LaunchOrderFailRate), "mergeJobHistories(%v)", tt.histories)
 		})
 	}
 }
+
+func Test_mergeJobHistories_Duration(t *testing.T) {
+	histories := []insights.JobHistory{
+		{
+			TestCases: []insights.TestCase{
+				{Name: "short", FailRate: 0.9, AvgDuration: 30},
+				{Name: "long", FailRate: 0.1, AvgDuration: 300},
+			},
+		},
+		{
+			TestCases: []insights.TestCase{
+				{Name: "short", FailRate: 0.5, AvgDuration: 60},
+				{Name: "medium", FailRate: 0.2, AvgDuration: 120},
+			},
+		},
+	}
+
+	want := insights.JobHistory{
+		TestCases: []insights.TestCase{
+			{Name: "long", FailRate: 0.1, AvgDuration: 300},
+			{Name: "medium", FailRate: 0.2, AvgDuration: 120},
+			{Name: "short", FailRate: 0.5, AvgDuration: 60},
+		},
+	}
+	assert.Equal(t, want, mergeJobHistories(histories, config.LaunchOrderDuration))
+}
@@ -52,7 +52,11 @@ func (c *UserService) Concurrency(ctx context.Context) (iam.Concurrency, error)
 		Concurrency iam.Concurrency `json:"concurrency"`
 	}
 
-	return body.Concurrency, json.NewDecoder(resp.Body).Decode(&body)
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return iam.Concurrency{}, err
+	}
+
+	return body.Concurrency, nil
 }
 
 func (c *UserService) User(ctx context.Context) (iam.User, error) {
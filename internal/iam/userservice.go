@@ -11,12 +11,37 @@ type User struct {
 
 // Concurrency represents the concurrency for an account.
 type Concurrency struct {
-	Org OrgConcurrency `json:"organization"`
+	Org  OrgConcurrency  `json:"organization"`
+	Team TeamConcurrency `json:"team"`
 }
 
 // OrgConcurrency represents the concurrency for an organization.
 type OrgConcurrency struct {
 	Allowed CloudConcurrency `json:"allowed"`
+	Current CloudConcurrency `json:"current"`
+}
+
+// TeamConcurrency represents the concurrency for the team of an account.
+type TeamConcurrency struct {
+	Allowed CloudConcurrency `json:"allowed"`
+	Current CloudConcurrency `json:"current"`
+}
+
+// Available returns the concurrency that is not in use yet. The team's allowance further limits that of the
+// organization, unless the team doesn't have one.
+func (c Concurrency) Available() CloudConcurrency {
+	return CloudConcurrency{
+		VDC: available(c.Org.Allowed.VDC, c.Org.Current.VDC, c.Team.Allowed.VDC, c.Team.Current.VDC),
+		RDC: available(c.Org.Allowed.RDC, c.Org.Current.RDC, c.Team.Allowed.RDC, c.Team.Current.RDC),
+	}
+}
+
+func available(orgAllowed, orgCurrent, teamAllowed, teamCurrent int) int {
+	n := orgAllowed - orgCurrent
+	if teamAllowed > 0 {
+		n = min(n, teamAllowed-teamCurrent)
+	}
+	return max(n, 0)
 }
 
 // CloudConcurrency represents a concurrency per cloud environment.
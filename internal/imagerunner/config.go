@@ -33,6 +33,7 @@ type Project struct {
 	Artifacts      config.Artifacts   `yaml:"artifacts,omitempty" json:"artifacts"`
 	DryRun         bool               `yaml:"-" json:"-"`
 	Env            map[string]string  `yaml:"env,omitempty" json:"env"`
+	EnvFile        []string           `yaml:"envFile,omitempty" json:"-"`
 	EnvFlag        map[string]string  `yaml:"-" json:"-"`
 	Reporters      config.Reporters   `yaml:"reporters,omitempty" json:"-"`
 }
@@ -43,12 +44,14 @@ type Defaults struct {
 
 type Suite struct {
 	Name            string            `yaml:"name,omitempty" json:"name"`
+	Tags            []string          `yaml:"tags,omitempty" json:"-"`
 	Image           string            `yaml:"image,omitempty" json:"image"`
 	ImagePullAuth   ImagePullAuth     `yaml:"imagePullAuth,omitempty" json:"imagePullAuth"`
 	EntryPoint      string            `yaml:"entrypoint,omitempty" json:"entrypoint"`
 	Files           []File            `yaml:"files,omitempty" json:"files"`
 	Artifacts       []string          `yaml:"artifacts,omitempty" json:"artifacts"`
 	Env             map[string]string `yaml:"env,omitempty" json:"env"`
+	EnvFile         []string          `yaml:"envFile,omitempty" json:"-"`
 	Timeout         time.Duration     `yaml:"timeout,omitempty" json:"timeout"`
 	Workload        string            `yaml:"workload,omitempty" json:"workload,omitempty"`
 	ResourceProfile string            `yaml:"resourceProfile,omitempty" json:"resourceProfile,omitempty"`
@@ -63,6 +66,7 @@ type SuiteService struct {
 	EntryPoint      string            `yaml:"entrypoint,omitempty" json:"entrypoint"`
 	Files           []File            `yaml:"files,omitempty" json:"files"`
 	Env             map[string]string `yaml:"env,omitempty" json:"env"`
+	EnvFile         []string          `yaml:"envFile,omitempty" json:"-"`
 	ResourceProfile string            `yaml:"resourceProfile,omitempty" json:"resourceProfile,omitempty"`
 }
 
@@ -83,6 +87,26 @@ func FromFile(cfgPath string) (Project, error) {
 		return p, err
 	}
 
+	var err error
+	if p.Env, err = config.ResolveEnvFiles(p.Env, p.EnvFile); err != nil {
+		return p, err
+	}
+	if p.Defaults.Env, err = config.ResolveEnvFiles(p.Defaults.Env, p.Defaults.EnvFile); err != nil {
+		return p, fmt.Errorf("defaults: %w", err)
+	}
+	for i := range p.Suites {
+		s := &p.Suites[i]
+		if s.Env, err = config.ResolveEnvFiles(s.Env, s.EnvFile); err != nil {
+			return p, fmt.Errorf("suite '%s': %w", s.Name, err)
+		}
+		for j := range s.Services {
+			svc := &s.Services[j]
+			if svc.Env, err = config.ResolveEnvFiles(svc.Env, svc.EnvFile); err != nil {
+				return p, fmt.Errorf("suite '%s', service '%s': %w", s.Name, svc.Name, err)
+			}
+		}
+	}
+
 	return p, nil
 }
 
@@ -137,11 +161,7 @@ func SetDefaults(p *Project) {
 		suite.Metadata["resourceProfile"] = suite.ResourceProfile
 
 		// Precedence: --env flag > root-level env vars > default env vars > suite env vars.
-		for _, env := range []map[string]string{p.Defaults.Env, p.Env, p.EnvFlag} {
-			for k, v := range env {
-				suite.Env[k] = v
-			}
-		}
+		suite.Env = config.MergeEnv(suite.Env, p.Defaults.Env, p.Env, p.EnvFlag)
 
 		for j := range suite.Services {
 			service := &suite.Services[j]
@@ -153,11 +173,7 @@ func SetDefaults(p *Project) {
 				service.Env = make(map[string]string)
 			}
 			// Precedence: --env flag > root-level env vars > default env vars > service env vars.
-			for _, env := range []map[string]string{p.Defaults.Env, p.Env, p.EnvFlag} {
-				for k, v := range env {
-					service.Env[k] = v
-				}
-			}
+			service.Env = config.MergeEnv(service.Env, p.Defaults.Env, p.Env, p.EnvFlag)
 		}
 	}
 }
@@ -219,13 +235,17 @@ func sliceContainsString(slice []string, val string) bool {
 	return false
 }
 
-// FilterSuites filters out suites in the project that don't match the given suite name.
-func FilterSuites(p *Project, suiteName string) error {
+// FilterSuites filters out suites in the project that aren't matched by the given selector.
+func FilterSuites(p *Project, sel config.SuiteSelector) error {
+	var suites []Suite
 	for _, s := range p.Suites {
-		if s.Name == suiteName {
-			p.Suites = []Suite{s}
-			return nil
+		if sel.Match(s.Name, s.Tags) {
+			suites = append(suites, s)
 		}
 	}
-	return fmt.Errorf(msg.SuiteNameNotFound, suiteName)
+	if len(suites) == 0 {
+		return sel.NoMatchError()
+	}
+	p.Suites = suites
+	return nil
 }
@@ -9,4 +9,28 @@ type JobHistory struct {
 type TestCase struct {
 	Name     string  `json:"name"`
 	FailRate float64 `json:"fail_rate"`
+	// AvgDuration is the average duration of the test case in seconds.
+	AvgDuration float64 `json:"avg_duration"`
+}
+
+// SortByHistory sorts items in the order of the job history. Items that aren't part of the history retain their
+// relative order and are placed last. name returns the name by which an item is looked up in the history.
+func SortByHistory[T any](items []T, history JobHistory, name func(T) string) []T {
+	hash := map[string]T{}
+	for _, item := range items {
+		hash[name(item)] = item
+	}
+	var res []T
+	for _, tc := range history.TestCases {
+		if v, ok := hash[tc.Name]; ok {
+			res = append(res, v)
+			delete(hash, tc.Name)
+		}
+	}
+	for _, item := range items {
+		if _, ok := hash[name(item)]; ok {
+			res = append(res, item)
+		}
+	}
+	return res
 }
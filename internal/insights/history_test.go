@@ -0,0 +1,20 @@
+package insights
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortByHistory(t *testing.T) {
+	type suite struct {
+		Name string
+	}
+	suites := []suite{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}}
+	history := JobHistory{
+		TestCases: []TestCase{{Name: "c"}, {Name: "unknown"}, {Name: "a"}},
+	}
+
+	got := SortByHistory(suites, history, func(s suite) string { return s.Name })
+	assert.Equal(t, []suite{{Name: "c"}, {Name: "a"}, {Name: "b"}, {Name: "d"}}, got)
+}
@@ -16,11 +16,17 @@ const (
 	StateFailed = "failed"
 )
 
+// The following states are only used by saucectl itself, for jobs that it never started.
+const (
+	// StateSkipped indicates that a job was skipped, since a suite it depends on didn't pass.
+	StateSkipped = "skipped"
+)
+
 var AllStates = []string{StatePassed, StateComplete, StateFailed, StateError, StateInProgress, StateQueued}
 
 // DoneStates represents states that a job doesn't transition out of, i.e. once the job is in one of these states,
 // it's done.
-var DoneStates = []string{StateComplete, StateError, StatePassed, StateFailed}
+var DoneStates = []string{StateComplete, StateError, StatePassed, StateFailed, StateSkipped}
 
 // Job represents test details and metadata of a test run (aka Job), that is usually associated with a particular test
 // execution instance (e.g. VM).
@@ -67,6 +73,14 @@ func Done(status string) bool {
 }
 
 // Service represents the interface for Job interactions.
+//
+// Service is the backend that jobs run on. Besides the Sauce Labs cloud, a backend may run jobs elsewhere, e.g. in
+// local Docker containers (see package docker), as long as it honors the following contract:
+//   - StartJob returns an ID by which the job can be read, stopped and its assets retrieved.
+//   - A job is done once its Status is one of DoneStates. Passed is the outcome of a done job.
+//   - PollJob flags a job that didn't finish in time as TimedOut, rather than returning an error.
+//   - Assets such as console.log, junit.xml and sauce-test-report.json are available by file name once the job is
+//     done, which is what reports, retries and artifact downloads rely on.
 type Service interface {
 	Starter
 	Reader
@@ -18,6 +18,14 @@ type StartOptions struct {
 	// Timeout is used for local/per-suite timeout.
 	Timeout time.Duration `json:"-"`
 
+	// SuiteName is the name of the suite that the job belongs to. A suite may consist of several jobs, e.g. one
+	// per device.
+	SuiteName string `json:"-"`
+	// Priority determines the order in which suites are launched. Suites with a higher priority are launched first.
+	Priority int `json:"-"`
+	// DependsOn are the names of the suites that have to pass before the job is launched.
+	DependsOn []string `json:"-"`
+
 	User           string                 `json:"username"`
 	AccessKey      string                 `json:"accessKey"`
 	App            string                 `json:"app,omitempty"`
@@ -53,6 +61,7 @@ type StartOptions struct {
 	Tunnel            TunnelOptions          `json:"tunnel,omitempty"`
 	ScreenResolution  string                 `json:"screenResolution,omitempty"`
 	Retries           int                    `json:"-"`
+	RetriesByReason   map[string]int         `json:"-"`
 	PassThreshold     int                    `json:"-"`
 	SmartRetry        SmartRetry             `json:"-"`
 	RunnerVersion     string                 `json:"runnerVersion,omitempty"`
@@ -3,8 +3,6 @@ package junit
 import (
 	"encoding/xml"
 	"fmt"
-
-	"golang.org/x/exp/maps"
 )
 
 // FileName is the name of the JUnit report.
@@ -36,6 +34,14 @@ type TestCase struct {
 	Error     *Error   `xml:"error,omitempty"`
 	Failure   *Failure `xml:"failure,omitempty"`
 	Skipped   *Skipped `xml:"skipped,omitempty"`
+	// FlakyFailures and FlakyErrors record the failures and errors of
+	// previous runs of a test that eventually passed.
+	FlakyFailures []Rerun `xml:"flakyFailure,omitempty"`
+	FlakyErrors   []Rerun `xml:"flakyError,omitempty"`
+	// RerunFailures and RerunErrors record the failures and errors of
+	// subsequent runs of a test that never passed.
+	RerunFailures []Rerun `xml:"rerunFailure,omitempty"`
+	RerunErrors   []Rerun `xml:"rerunError,omitempty"`
 }
 
 // IsError returns true if the test case errored. Multiple fields are taken
@@ -50,6 +56,11 @@ func (tc TestCase) IsFailure() bool {
 	return tc.Failure != nil || tc.Status == "failure" || tc.Status == "failed"
 }
 
+// key identifies the test case across reports.
+func (tc TestCase) key() string {
+	return fmt.Sprintf("%s.%s", tc.ClassName, tc.Name)
+}
+
 // IsSkipped returns true if the test case was skipped. Multiple fields are
 // taken into account to determine this.
 func (tc TestCase) IsSkipped() bool {
@@ -80,6 +91,20 @@ type Error struct {
 	Text string `xml:",chardata"`
 }
 
+// Rerun maps to any of the <flakyFailure>, <flakyError>, <rerunFailure> and
+// <rerunError> elements, as used by Maven Surefire, that record the outcome of
+// a single run of a retried test.
+type Rerun struct {
+	// Message is a short description of the failure or error.
+	Message string `xml:"message,attr,omitempty"`
+	// Type is the type of failure or error, e.g. "java.lang.AssertionError".
+	Type string `xml:"type,attr,omitempty"`
+	// StackTrace is a failure or error description or stack trace.
+	StackTrace string `xml:"stackTrace,omitempty"`
+	SystemOut  string `xml:"system-out,omitempty"`
+	SystemErr  string `xml:"system-err,omitempty"`
+}
+
 // Skipped maps to <skipped> element. Indicates a skipped test. Some frameworks
 // use the 'status' attribute on TestCase instead.
 type Skipped struct {
@@ -110,7 +135,8 @@ type TestSuite struct {
 }
 
 // AddTestCases adds test cases to the test suite. If unique is true, existing
-// test cases with the same name will be replaced.
+// test cases with the same name will be replaced in place, while new test cases
+// are appended.
 func (ts *TestSuite) AddTestCases(unique bool, tcs ...TestCase) {
 	if !unique {
 		ts.TestCases = append(ts.TestCases, tcs...)
@@ -118,20 +144,19 @@ func (ts *TestSuite) AddTestCases(unique bool, tcs ...TestCase) {
 	}
 
 	// index existing test cases by name
-	testMap := make(map[string]TestCase)
-	for _, tc := range ts.TestCases {
-		key := fmt.Sprintf("%s.%s", tc.ClassName, tc.Name)
-		testMap[key] = tc
+	index := make(map[string]int)
+	for i, tc := range ts.TestCases {
+		index[tc.key()] = i
 	}
 
-	// add new test cases
 	for _, tc := range tcs {
-		key := fmt.Sprintf("%s.%s", tc.ClassName, tc.Name)
-		testMap[key] = tc
+		if i, ok := index[tc.key()]; ok {
+			ts.TestCases[i] = tc
+			continue
+		}
+		index[tc.key()] = len(ts.TestCases)
+		ts.TestCases = append(ts.TestCases, tc)
 	}
-
-	// convert map back to slice
-	ts.TestCases = maps.Values(testMap)
 }
 
 // Compute updates some statistics for the test suite based on the test cases it
@@ -237,24 +262,155 @@ func Parse(data []byte) (TestSuites, error) {
 	return tss, err
 }
 
-// MergeReports merges multiple junit reports into a single report.
+// MergeReports merges multiple junit reports into a single report. Test cases
+// of later reports replace those of earlier reports with the same name. Test
+// suites and test cases retain the order in which they first appeared.
 func MergeReports(reports ...TestSuites) TestSuites {
-	suites := make(map[string]TestSuite)
+	var merged TestSuites
+	index := make(map[string]int)
 
 	for _, rep := range reports {
 		for _, suite := range rep.TestSuites {
-			indexedSuite, ok := suites[suite.Name]
+			i, ok := index[suite.Name]
 			if !ok {
-				suites[suite.Name] = suite
+				// Copy the test cases, since they're replaced in place.
+				suite.TestCases = append([]TestCase(nil), suite.TestCases...)
+				index[suite.Name] = len(merged.TestSuites)
+				merged.TestSuites = append(merged.TestSuites, suite)
 				continue
 			}
 
-			indexedSuite.AddTestCases(true, suite.TestCases...)
-			suites[suite.Name] = indexedSuite
+			merged.TestSuites[i].AddTestCases(true, suite.TestCases...)
 		}
 	}
 
-	return TestSuites{
-		TestSuites: maps.Values(suites),
+	return merged
+}
+
+// RecoveredTests returns the test cases that failed or errored in one of the
+// reports, but passed in the last report that contains them, e.g. since they
+// were retried. The returned test cases are those of the passing report.
+func RecoveredTests(reports ...TestSuites) []TestCase {
+	failed := make(map[string]bool)
+	last := make(map[string]TestCase)
+	var keys []string
+
+	for _, rep := range reports {
+		for _, tc := range rep.TestCases() {
+			k := tc.key()
+			if _, ok := last[k]; !ok {
+				keys = append(keys, k)
+			}
+			last[k] = tc
+			if tc.IsError() || tc.IsFailure() {
+				failed[k] = true
+			}
+		}
+	}
+
+	var recovered []TestCase
+	for _, k := range keys {
+		tc := last[k]
+		if failed[k] && !tc.IsError() && !tc.IsFailure() && !tc.IsSkipped() {
+			recovered = append(recovered, tc)
+		}
+	}
+	return recovered
+}
+
+// CollapseReports collapses the reports of multiple runs of the same tests,
+// e.g. retries, into a single report. Unlike MergeReports, it retains the
+// history of every test: a test passed if any of its runs passed, in which case
+// the failures and errors of its other runs are recorded as flaky failures and
+// errors. A test that never passed keeps its first failure or error, while
+// those of subsequent runs are recorded as rerun failures and errors. Test
+// suites and test cases retain the order in which they first appeared.
+func CollapseReports(reports ...TestSuites) TestSuites {
+	var collapsed TestSuites
+	index := make(map[string]int)
+	var runs []map[string][]TestCase
+	var keys [][]string
+
+	for _, rep := range reports {
+		for _, suite := range rep.TestSuites {
+			i, ok := index[suite.Name]
+			if !ok {
+				i = len(collapsed.TestSuites)
+				index[suite.Name] = i
+				collapsed.TestSuites = append(collapsed.TestSuites, suite)
+				collapsed.TestSuites[i].TestCases = nil
+				runs = append(runs, make(map[string][]TestCase))
+				keys = append(keys, nil)
+			}
+
+			for _, tc := range suite.TestCases {
+				k := tc.key()
+				if _, ok := runs[i][k]; !ok {
+					keys[i] = append(keys[i], k)
+				}
+				runs[i][k] = append(runs[i][k], tc)
+			}
+		}
+	}
+
+	for i := range collapsed.TestSuites {
+		for _, k := range keys[i] {
+			collapsed.TestSuites[i].TestCases = append(collapsed.TestSuites[i].TestCases, collapse(runs[i][k]))
+		}
+	}
+
+	return collapsed
+}
+
+// collapse collapses the runs of a single test into one test case.
+func collapse(runs []TestCase) TestCase {
+	for i, tc := range runs {
+		if tc.IsError() || tc.IsFailure() || tc.IsSkipped() {
+			continue
+		}
+
+		for j, r := range runs {
+			if j == i {
+				continue
+			}
+			if r.IsError() {
+				tc.FlakyErrors = append(tc.FlakyErrors, rerunOf(r))
+			} else if r.IsFailure() {
+				tc.FlakyFailures = append(tc.FlakyFailures, rerunOf(r))
+			}
+		}
+		return tc
+	}
+
+	for i, tc := range runs {
+		if !tc.IsError() && !tc.IsFailure() {
+			continue
+		}
+
+		for _, r := range runs[i+1:] {
+			if r.IsError() {
+				tc.RerunErrors = append(tc.RerunErrors, rerunOf(r))
+			} else if r.IsFailure() {
+				tc.RerunFailures = append(tc.RerunFailures, rerunOf(r))
+			}
+		}
+		return tc
+	}
+
+	// Skipped in every run.
+	return runs[len(runs)-1]
+}
+
+// rerunOf records the failure or error of the given test case run.
+func rerunOf(tc TestCase) Rerun {
+	r := Rerun{
+		SystemOut: tc.SystemOut,
+		SystemErr: tc.SystemErr,
+	}
+	if tc.Error != nil {
+		r.Message, r.Type, r.StackTrace = tc.Error.Message, tc.Error.Type, tc.Error.Text
+	} else if tc.Failure != nil {
+		r.Message, r.Type, r.StackTrace = tc.Failure.Message, tc.Failure.Type, tc.Failure.Text
 	}
+	return r
 }
@@ -174,4 +174,90 @@ func TestMergeReports(t *testing.T) {
 	got := MergeReports(input...)
 	assert.Equal(t, 1, len(got.TestSuites))
 	assert.Equal(t, 3, len(got.TestSuites[0].TestCases))
+
+	var names []string
+	for _, tc := range got.TestSuites[0].TestCases {
+		names = append(names, tc.Name)
+	}
+	assert.Equal(t, []string{"TestCase1", "TestCase2", "TestCase3"}, names)
+	assert.True(t, got.TestSuites[0].TestCases[1].IsError())
+	assert.False(t, input[0].TestSuites[0].TestCases[1].IsError(), "input must not be modified")
+}
+
+func TestRecoveredTests(t *testing.T) {
+	report := func(tcs ...TestCase) TestSuites {
+		return TestSuites{TestSuites: []TestSuite{{Name: "Suite", TestCases: tcs}}}
+	}
+	passed := func(name string) TestCase {
+		return TestCase{ClassName: "Class", Name: name}
+	}
+	failed := func(name string) TestCase {
+		return TestCase{ClassName: "Class", Name: name, Failure: &Failure{Message: "failed"}}
+	}
+	errored := func(name string) TestCase {
+		return TestCase{ClassName: "Class", Name: name, Error: &Error{Message: "errored"}}
+	}
+
+	got := RecoveredTests(
+		report(passed("stable"), failed("flaky"), errored("crashy"), failed("broken"), failed("fixed later")),
+		report(passed("flaky"), passed("crashy"), failed("broken"), failed("fixed later")),
+		report(passed("fixed later")),
+	)
+
+	var names []string
+	for _, tc := range got {
+		names = append(names, tc.Name)
+	}
+	assert.Equal(t, []string{"flaky", "crashy", "fixed later"}, names)
+
+	assert.Empty(t, RecoveredTests(report(failed("flaky"))))
+	assert.Empty(t, RecoveredTests())
+}
+
+func TestCollapseReports(t *testing.T) {
+	report := func(tcs ...TestCase) TestSuites {
+		return TestSuites{TestSuites: []TestSuite{{Name: "Suite", TestCases: tcs}}}
+	}
+	passed := func(name string) TestCase {
+		return TestCase{ClassName: "Class", Name: name}
+	}
+	failed := func(name, msg string) TestCase {
+		return TestCase{ClassName: "Class", Name: name, Failure: &Failure{Message: msg, Text: "trace"}}
+	}
+	errored := func(name, msg string) TestCase {
+		return TestCase{ClassName: "Class", Name: name, Error: &Error{Message: msg}, SystemOut: "out"}
+	}
+	skipped := func(name string) TestCase {
+		return TestCase{ClassName: "Class", Name: name, Skipped: &Skipped{}}
+	}
+
+	got := CollapseReports(
+		report(passed("stable"), failed("flaky", "first"), errored("crashy", "boom"), failed("broken", "first"), skipped("ignored")),
+		report(passed("flaky"), passed("crashy"), errored("broken", "second"), skipped("ignored")),
+		report(failed("flaky", "third"), failed("broken", "third")),
+	)
+
+	want := TestSuites{TestSuites: []TestSuite{{Name: "Suite", TestCases: []TestCase{
+		passed("stable"),
+		{
+			ClassName: "Class", Name: "flaky",
+			FlakyFailures: []Rerun{{Message: "first", StackTrace: "trace"}, {Message: "third", StackTrace: "trace"}},
+		},
+		{
+			ClassName: "Class", Name: "crashy",
+			FlakyErrors: []Rerun{{Message: "boom", SystemOut: "out"}},
+		},
+		{
+			ClassName: "Class", Name: "broken", Failure: &Failure{Message: "first", Text: "trace"},
+			RerunErrors:   []Rerun{{Message: "second", SystemOut: "out"}},
+			RerunFailures: []Rerun{{Message: "third", StackTrace: "trace"}},
+		},
+		skipped("ignored"),
+	}}}}
+	assert.Equal(t, want, got)
+
+	got.Compute()
+	assert.Equal(t, 5, got.Tests)
+	assert.Equal(t, 1, got.Failures)
+	assert.Equal(t, 1, got.Skipped)
 }
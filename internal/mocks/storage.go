@@ -9,18 +9,53 @@ import (
 
 // FakeProjectUploader mock struct
 type FakeProjectUploader struct {
-	UploadSuccess bool
+	UploadSuccess  bool
+	ListFn         func(opts storage.ListOptions) (storage.List, error)
+	UploadStreamFn func(filename, description string, reader io.Reader) (storage.Item, error)
 }
 
 func (fpu *FakeProjectUploader) UploadStream(filename, description string, reader io.Reader) (storage.Item, error) {
+	if fpu.UploadStreamFn != nil {
+		return fpu.UploadStreamFn(filename, description, reader)
+	}
 	panic("not implemented")
 }
 
+// UploadStreamFunc delegates to UploadStream with the reader of a single attempt.
+func (fpu *FakeProjectUploader) UploadStreamFunc(filename, description string, size int64, open func() (io.ReadCloser, error)) (storage.Item, error) {
+	reader, err := open()
+	if err != nil {
+		return storage.Item{}, err
+	}
+	defer reader.Close()
+
+	return fpu.UploadStream(filename, description, reader)
+}
+
 func (fpu *FakeProjectUploader) Download(id string) (io.ReadCloser, int64, error) {
 	panic("not implemented")
 }
 
+func (fpu *FakeProjectUploader) Delete(id string) error {
+	panic("not implemented")
+}
+
+func (fpu *FakeProjectUploader) Get(id string) (storage.Item, error) {
+	panic("not implemented")
+}
+
+func (fpu *FakeProjectUploader) Describe(id, description string) (storage.Item, error) {
+	panic("not implemented")
+}
+
+func (fpu *FakeProjectUploader) DownloadURL(url string) (io.ReadCloser, int64, error) {
+	panic("not implemented")
+}
+
 func (fpu *FakeProjectUploader) List(opts storage.ListOptions) (storage.List, error) {
+	if fpu.ListFn != nil {
+		return fpu.ListFn(opts)
+	}
 	return storage.List{
 		Items:     []storage.Item{},
 		Truncated: false,
@@ -66,6 +66,8 @@ const (
 	DuplicateSuiteName = "suite names must be unique, but found duplicate for '%s'"
 	// SuiteNameNotFound indicates it cannot find the specified suite by name
 	SuiteNameNotFound = "no suite named '%s' found"
+	// NoSuiteSelected indicates that no suite matches the given suite selection
+	NoSuiteSelected = "no suite matches the selection: %s"
 	// InvalidKeyValueInputFormat indicates wrong setting for key-value pairs
 	InvalidKeyValueInputFormat = "wrong input format; must be of key-value"
 	// InvalidGitRelease indicates the git release is malformed
@@ -100,6 +102,8 @@ const (
 	InvalidPassThreshold = "passThreshold should not be greater than retries+1"
 	// ShardingConfigurationNoMatchingTests indicates no test matching sharding configuration
 	ShardingConfigurationNoMatchingTests = "sharding configuration resulted in no matching tests"
+	// EnvNotSupported indicates that env vars are set for a suite whose target can't honor them.
+	EnvNotSupported = "suite '%s': environment variables are not supported on %s and will be ignored"
 )
 
 // apitesting config settings
@@ -14,6 +14,47 @@ var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
 // NewMultipartReader creates a new io.Reader that serves multipart form-data from src.
 // Also returns the form data content type (see multipart.Writer#FormDataContentType).
 func NewMultipartReader(field, filename, description string, src io.Reader) (io.Reader, string, error) {
+	head, tail, contentType, err := newEnvelope(field, filename, description)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if srcReadSeeker, ok := src.(io.ReadSeeker); ok {
+		mrs, err := MultiReadSeeker(
+			bytes.NewReader(head),
+			srcReadSeeker,
+			bytes.NewReader(tail),
+		)
+
+		return mrs, contentType, err
+	}
+
+	return io.MultiReader(bytes.NewReader(head), src, bytes.NewReader(tail)), contentType, nil
+}
+
+// NewMultipartReaderFunc is like NewMultipartReader, but serves the data from a new src every time the returned
+// function is called. This allows for requests to be retried with sources that can't be rewound, without having to
+// buffer them. The multipart envelope, including its boundary, is identical for every call.
+// Also returns the size of the envelope, i.e. the number of bytes that are added to the data from src.
+func NewMultipartReaderFunc(field, filename, description string, open func() (io.Reader, error)) (func() (io.Reader, error), string, int64, error) {
+	head, tail, contentType, err := newEnvelope(field, filename, description)
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	fn := func() (io.Reader, error) {
+		src, err := open()
+		if err != nil {
+			return nil, err
+		}
+		return io.MultiReader(bytes.NewReader(head), src, bytes.NewReader(tail)), nil
+	}
+
+	return fn, contentType, int64(len(head) + len(tail)), nil
+}
+
+// newEnvelope creates the multipart data that precedes (head) and succeeds (tail) the file data.
+func newEnvelope(field, filename, description string) (head []byte, tail []byte, contentType string, err error) {
 	// Create the multipart header.
 	buffy := &bytes.Buffer{}
 	writer := multipart.NewWriter(buffy)
@@ -25,36 +66,18 @@ func NewMultipartReader(field, filename, description string, src io.Reader) (io.
 	// Create the actual part that will hold the data. Though we won't actually write the data just yet, since we want
 	// to stream it later.
 	if _, err := writer.CreatePart(header); err != nil {
-		return nil, "", err
+		return nil, nil, "", err
 	}
 	headerSize := buffy.Len()
 
 	if err := writer.WriteField("description", description); err != nil {
-		return nil, "", err
+		return nil, nil, "", err
 	}
 
 	// Finish the multipart message.
 	if err := writer.Close(); err != nil {
-		return nil, "", err
-	}
-
-	if srcReadSeeker, ok := src.(io.ReadSeeker); ok {
-		mrs, err := MultiReadSeeker(
-			bytes.NewReader(buffy.Bytes()[:headerSize]),
-			srcReadSeeker,
-			bytes.NewReader(buffy.Bytes()[headerSize:]),
-		)
-
-		return mrs,
-			writer.FormDataContentType(),
-			err
+		return nil, nil, "", err
 	}
 
-	return io.MultiReader(
-			bytes.NewReader(buffy.Bytes()[:headerSize]),
-			src,
-			bytes.NewReader(buffy.Bytes()[headerSize:]),
-		),
-		writer.FormDataContentType(),
-		nil
+	return buffy.Bytes()[:headerSize], buffy.Bytes()[headerSize:], writer.FormDataContentType(), nil
 }
@@ -0,0 +1,245 @@
+// Package payload inspects the archives that saucectl uploads, so that users can find out what makes them large.
+package payload
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Options represents the options for listing the payload.
+type Options struct {
+	// Enabled turns the payload listing on.
+	Enabled bool
+	// Top is the number of largest files and directories to list per archive. Lists all files if 0.
+	Top int
+	// JSONFile is the file to which the listing is written in JSON format. Not written if empty.
+	JSONFile string
+}
+
+// Entry is a file or directory within an archive.
+type Entry struct {
+	Path string `json:"path"`
+	// Size is the uncompressed size in bytes. For directories, it's the sum of all files within.
+	Size int64 `json:"size"`
+}
+
+// Finding is an entry that most likely ended up in the archive by accident.
+type Finding struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// Archive describes the contents of an archive.
+type Archive struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	// Size is the size of the archive in bytes.
+	Size int64 `json:"size"`
+	// UncompressedSize is the sum of all uncompressed file sizes in bytes.
+	UncompressedSize int64 `json:"uncompressedSize"`
+	FileCount        int   `json:"fileCount"`
+	// Files are either all files, or only the largest files, depending on Options.Top.
+	Files       []Entry   `json:"files"`
+	Directories []Entry   `json:"directories,omitempty"`
+	Findings    []Finding `json:"findings,omitempty"`
+}
+
+// suspiciousDirs are directories that are rarely needed to run tests.
+var suspiciousDirs = map[string]string{
+	".git":              "version control data",
+	".hg":               "version control data",
+	".svn":              "version control data",
+	".idea":             "IDE settings",
+	".vscode":           "IDE settings",
+	"coverage":          "coverage report",
+	".nyc_output":       "coverage report",
+	"dist":              "build output",
+	"build":             "build output",
+	"out":               "build output",
+	"target":            "build output",
+	".next":             "build output",
+	".nuxt":             "build output",
+	"videos":            "test artifacts",
+	"screenshots":       "test artifacts",
+	"test-results":      "test artifacts",
+	"playwright-report": "test artifacts",
+}
+
+// suspiciousExtensions are file types that are rarely needed to run tests.
+var suspiciousExtensions = map[string]string{
+	".mp4":  "video",
+	".mov":  "video",
+	".avi":  "video",
+	".mkv":  "video",
+	".webm": "video",
+	".zip":  "archive",
+	".tar":  "archive",
+	".gz":   "archive",
+	".tgz":  "archive",
+	".zst":  "archive",
+	".7z":   "archive",
+	".rar":  "archive",
+	".apk":  "mobile app",
+	".aab":  "mobile app",
+	".ipa":  "mobile app",
+	".dmg":  "disk image",
+	".iso":  "disk image",
+	".log":  "log file",
+}
+
+// Inspect inspects the archive at filename. Supported formats are zip, tar.gz and tar.zst. The archive is identified by name, e.g. 'app' or 'node_modules'.
+func Inspect(name, filename string, top int) (Archive, error) {
+	a := Archive{Name: name, Path: filename}
+
+	finfo, err := os.Stat(filename)
+	if err != nil {
+		return a, err
+	}
+	a.Size = finfo.Size()
+
+	files, err := listFiles(filename)
+	if err != nil {
+		return a, err
+	}
+
+	dirs := map[string]int64{}
+	flagged := map[string]bool{}
+	for _, f := range files {
+		a.FileCount++
+		a.UncompressedSize += f.Size
+		a.Files = append(a.Files, f)
+
+		for dir := path.Dir(f.Path); dir != "." && dir != "/"; dir = path.Dir(dir) {
+			dirs[dir] += f.Size
+		}
+
+		if finding, ok := inspectPath(name, f.Path); ok && !flagged[finding.Path] {
+			flagged[finding.Path] = true
+			a.Findings = append(a.Findings, finding)
+		}
+	}
+
+	if top <= 0 {
+		sort.Slice(a.Files, func(i, j int) bool { return a.Files[i].Path < a.Files[j].Path })
+		return a, nil
+	}
+
+	a.Files = largest(a.Files, top)
+	for dir, size := range dirs {
+		a.Directories = append(a.Directories, Entry{Path: dir + "/", Size: size})
+	}
+	a.Directories = largest(a.Directories, top)
+
+	return a, nil
+}
+
+// inspectPath checks whether the file at p in the named archive looks like it's been added by accident.
+// Findings within directories refer to the directory, rather than each file within.
+func inspectPath(archiveName, p string) (Finding, bool) {
+	// Dependencies are expected to contain all kinds of files, so only the most obvious mistakes are flagged.
+	isDependency := archiveName == "node_modules"
+
+	segments := strings.Split(p, "/")
+	for i, s := range segments[:len(segments)-1] {
+		reason, ok := suspiciousDirs[s]
+		if !ok || (isDependency && s != ".git") {
+			continue
+		}
+		return Finding{Path: strings.Join(segments[:i+1], "/") + "/", Reason: reason}, true
+	}
+
+	if isDependency {
+		return Finding{}, false
+	}
+	if reason, ok := suspiciousExtensions[strings.ToLower(path.Ext(p))]; ok {
+		return Finding{Path: p, Reason: reason}, true
+	}
+
+	return Finding{}, false
+}
+
+// largest returns the n largest entries, sorted by size in descending order.
+func largest(entries []Entry, n int) []Entry {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Size == entries[j].Size {
+			return entries[i].Path < entries[j].Path
+		}
+		return entries[i].Size > entries[j].Size
+	})
+	if len(entries) > n {
+		entries = entries[:n]
+	}
+	return entries
+}
+
+// listFiles returns the files in the archive at filename, along with their uncompressed sizes. The archive format is
+// derived from the file extension.
+func listFiles(filename string) ([]Entry, error) {
+	if strings.HasSuffix(filename, ".zip") {
+		r, err := zip.OpenReader(filename)
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+
+		var files []Entry
+		for _, f := range r.File {
+			if f.FileInfo().IsDir() {
+				continue
+			}
+			files = append(files, Entry{Path: f.Name, Size: int64(f.UncompressedSize64)})
+		}
+		return files, nil
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var r io.Reader
+	switch {
+	case strings.HasSuffix(filename, ".tar.gz"):
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, err
+		}
+		defer gr.Close()
+		r = gr
+	case strings.HasSuffix(filename, ".tar.zst"):
+		zr, err := zstd.NewReader(f)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		r = zr
+	default:
+		return nil, errors.New("unsupported archive format")
+	}
+
+	var files []Entry
+	tr := tar.NewReader(r)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if h.Typeflag != tar.TypeReg {
+			continue
+		}
+		files = append(files, Entry{Path: h.Name, Size: h.Size})
+	}
+}
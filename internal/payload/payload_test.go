@@ -0,0 +1,169 @@
+package payload
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func createZip(t *testing.T, files map[string]int) string {
+	t.Helper()
+
+	name := filepath.Join(t.TempDir(), "app.zip")
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+	for p, size := range files {
+		fw, err := w.Create(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write([]byte(strings.Repeat("x", size))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return name
+}
+
+func TestInspect(t *testing.T) {
+	filename := createZip(t, map[string]int{
+		"app/cypress/e2e/login.cy.js":  100,
+		"app/cypress/e2e/search.cy.js": 200,
+		"app/cypress/videos/login.mp4": 5000,
+		"app/.git/objects/ab/cdef":     1000,
+		"app/package.json":             50,
+		"app/fixtures/data.zip":        300,
+	})
+
+	tests := []struct {
+		name      string
+		top       int
+		wantFiles []Entry
+		wantDirs  []Entry
+	}{
+		{
+			name: "top 2",
+			top:  2,
+			wantFiles: []Entry{
+				{Path: "app/cypress/videos/login.mp4", Size: 5000},
+				{Path: "app/.git/objects/ab/cdef", Size: 1000},
+			},
+			wantDirs: []Entry{
+				{Path: "app/", Size: 6650},
+				{Path: "app/cypress/", Size: 5300},
+			},
+		},
+		{
+			name: "all files",
+			top:  0,
+			wantFiles: []Entry{
+				{Path: "app/.git/objects/ab/cdef", Size: 1000},
+				{Path: "app/cypress/e2e/login.cy.js", Size: 100},
+				{Path: "app/cypress/e2e/search.cy.js", Size: 200},
+				{Path: "app/cypress/videos/login.mp4", Size: 5000},
+				{Path: "app/fixtures/data.zip", Size: 300},
+				{Path: "app/package.json", Size: 50},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Inspect("app", filename, tt.top)
+			if err != nil {
+				t.Fatalf("Inspect() error = %v", err)
+			}
+
+			if got.FileCount != 6 {
+				t.Errorf("Inspect() FileCount = %d, want 6", got.FileCount)
+			}
+			if got.UncompressedSize != 6650 {
+				t.Errorf("Inspect() UncompressedSize = %d, want 6650", got.UncompressedSize)
+			}
+			if !reflect.DeepEqual(got.Files, tt.wantFiles) {
+				t.Errorf("Inspect() Files = %v, want %v", got.Files, tt.wantFiles)
+			}
+			if !reflect.DeepEqual(got.Directories, tt.wantDirs) {
+				t.Errorf("Inspect() Directories = %v, want %v", got.Directories, tt.wantDirs)
+			}
+
+			findings := map[string]string{}
+			for _, f := range got.Findings {
+				findings[f.Path] = f.Reason
+			}
+			wantFindings := map[string]string{
+				"app/.git/":             "version control data",
+				"app/cypress/videos/":   "test artifacts",
+				"app/fixtures/data.zip": "archive",
+			}
+			if !reflect.DeepEqual(findings, wantFindings) {
+				t.Errorf("Inspect() Findings = %v, want %v", findings, wantFindings)
+			}
+		})
+	}
+}
+
+func Test_inspectPath(t *testing.T) {
+	tests := []struct {
+		name        string
+		archiveName string
+		path        string
+		want        Finding
+		wantOK      bool
+	}{
+		{
+			name:        "regular file",
+			archiveName: "app",
+			path:        "app/cypress/e2e/login.cy.js",
+		},
+		{
+			name:        "build output",
+			archiveName: "app",
+			path:        "app/dist/bundle.js",
+			want:        Finding{Path: "app/dist/", Reason: "build output"},
+			wantOK:      true,
+		},
+		{
+			name:        "video",
+			archiveName: "app",
+			path:        "app/recording.MP4",
+			want:        Finding{Path: "app/recording.MP4", Reason: "video"},
+			wantOK:      true,
+		},
+		{
+			name:        "build output in dependencies",
+			archiveName: "node_modules",
+			path:        "node_modules/lodash/dist/lodash.js",
+		},
+		{
+			name:        "archive in dependencies",
+			archiveName: "node_modules",
+			path:        "node_modules/some-pkg/vendor.zip",
+		},
+		{
+			name:        "vcs data in dependencies",
+			archiveName: "node_modules",
+			path:        "node_modules/some-pkg/.git/HEAD",
+			want:        Finding{Path: "node_modules/some-pkg/.git/", Reason: "version control data"},
+			wantOK:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := inspectPath(tt.archiveName, tt.path)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("inspectPath() = %v, %v; want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
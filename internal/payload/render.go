@@ -0,0 +1,72 @@
+package payload
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dustin/go-humanize"
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+// Render writes a human-readable listing of the archives to w.
+func Render(w io.Writer, archives []Archive) {
+	for _, a := range archives {
+		_, _ = fmt.Fprintf(w, "\n%s: %s compressed, %s uncompressed, %d files (%s)\n",
+			color.New(color.Bold).Sprint(a.Name), humanize.Bytes(uint64(a.Size)),
+			humanize.Bytes(uint64(a.UncompressedSize)), a.FileCount, a.Path)
+
+		if len(a.Directories) > 0 {
+			_, _ = fmt.Fprintln(w, renderEntries("Largest Directories", a.Directories))
+		}
+		if len(a.Files) > 0 {
+			title := "Files"
+			if len(a.Directories) > 0 {
+				title = "Largest Files"
+			}
+			_, _ = fmt.Fprintln(w, renderEntries(title, a.Files))
+		}
+
+		if len(a.Findings) > 0 {
+			_, _ = fmt.Fprintln(w, color.YellowString("Possibly included by accident. Consider adding them to your .sauceignore file:"))
+			for _, f := range a.Findings {
+				_, _ = fmt.Fprintf(w, "  %s (%s)\n", f.Path, f.Reason)
+			}
+		}
+	}
+}
+
+func renderEntries(title string, entries []Entry) string {
+	t := table.NewWriter()
+	t.SetStyle(table.StyleLight)
+	t.AppendHeader(table.Row{"Size", title})
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{
+			Name:        "Size",
+			AlignHeader: text.AlignLeft,
+			Align:       text.AlignRight,
+			Transformer: func(val interface{}) string {
+				s, _ := val.(int64)
+				return humanize.Bytes(uint64(s))
+			},
+		},
+	})
+
+	for _, e := range entries {
+		t.AppendRow(table.Row{e.Size, e.Path})
+	}
+
+	return t.Render()
+}
+
+// WriteJSON writes the archives as JSON to the file name.
+func WriteJSON(name string, archives []Archive) error {
+	b, err := json.MarshalIndent(archives, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, b, 0644)
+}
@@ -51,6 +51,7 @@ type Project struct {
 	Reporters     config.Reporters     `yaml:"reporters,omitempty" json:"-"`
 	Defaults      config.Defaults      `yaml:"defaults,omitempty" json:"defaults"`
 	Env           map[string]string    `yaml:"env,omitempty" json:"env"`
+	EnvFile       []string             `yaml:"envFile,omitempty" json:"-"`
 	EnvFlag       map[string]string    `yaml:"-" json:"-"`
 	Notifications config.Notifications `yaml:"notifications,omitempty" json:"-"`
 }
@@ -64,6 +65,8 @@ type Playwright struct {
 // Suite represents the playwright test suite configuration.
 type Suite struct {
 	Name              string            `yaml:"name,omitempty" json:"name"`
+	Tags              []string          `yaml:"tags,omitempty" json:"-"`
+	Sauceignore       string            `yaml:"sauceignore,omitempty" json:"-"`
 	Mode              string            `yaml:"mode,omitempty" json:"-"`
 	Timeout           time.Duration     `yaml:"timeout,omitempty" json:"timeout"`
 	PlaywrightVersion string            `yaml:"playwrightVersion,omitempty" json:"playwrightVersion,omitempty"`
@@ -73,11 +76,15 @@ type Suite struct {
 	Params            SuiteConfig       `yaml:"params,omitempty" json:"param,omitempty"`
 	ScreenResolution  string            `yaml:"screenResolution,omitempty" json:"screenResolution,omitempty"`
 	Env               map[string]string `yaml:"env,omitempty" json:"env,omitempty"`
+	EnvFile           []string          `yaml:"envFile,omitempty" json:"-"`
 	NumShards         int               `yaml:"numShards,omitempty" json:"-"`
 	Shard             string            `yaml:"shard,omitempty" json:"-"`
 	PreExec           []string          `yaml:"preExec,omitempty" json:"preExec"`
 	TimeZone          string            `yaml:"timeZone,omitempty" json:"timeZone"`
 	PassThreshold     int               `yaml:"passThreshold,omitempty" json:"-"`
+	Retries           *int              `yaml:"retries,omitempty" json:"-"`
+	Priority          int               `yaml:"priority,omitempty" json:"-"`
+	DependsOn         []string          `yaml:"dependsOn,omitempty" json:"-"`
 	SmartRetry        config.SmartRetry `yaml:"smartRetry,omitempty" json:"-"`
 	ShardGrepEnabled  bool              `yaml:"shardGrepEnabled,omitempty" json:"-"`
 }
@@ -113,6 +120,17 @@ func FromFile(cfgPath string) (Project, error) {
 		return p, err
 	}
 
+	var err error
+	if p.Env, err = config.ResolveEnvFiles(p.Env, p.EnvFile); err != nil {
+		return p, err
+	}
+	for i := range p.Suites {
+		s := &p.Suites[i]
+		if s.Env, err = config.ResolveEnvFiles(s.Env, s.EnvFile); err != nil {
+			return p, fmt.Errorf("suite '%s': %w", s.Name, err)
+		}
+	}
+
 	p.ConfigFilePath = cfgPath
 
 	return p, nil
@@ -167,16 +185,9 @@ func SetDefaults(p *Project) {
 
 	// Apply global env vars onto every suite.
 	// Precedence: --env flag > root-level env vars > suite-level env vars.
-	for _, env := range []map[string]string{p.EnvFlag, p.Env} {
-		for k, v := range env {
-			for ks := range p.Suites {
-				s := &p.Suites[ks]
-				if s.Env == nil {
-					s.Env = map[string]string{}
-				}
-				s.Env[k] = v
-			}
-		}
+	// Env vars from an envFile have lower precedence than env vars on the same level.
+	for i := range p.Suites {
+		p.Suites[i].Env = config.MergeEnv(p.Suites[i].Env, p.Env, p.EnvFlag)
 	}
 }
 
@@ -214,6 +225,7 @@ func checkShards(p *Project) error {
 // shardInSuites divides suites into shards based on the pattern.
 func shardInSuites(rootDir string, suites []Suite, ccy int, sauceignoreFile string) ([]Suite, error) {
 	var shardedSuites []Suite
+	shards := make(map[string][]string)
 
 	for _, s := range suites {
 		if s.Shard != "spec" && s.Shard != "concurrency" {
@@ -233,7 +245,7 @@ func shardInSuites(rootDir string, suites []Suite, ccy int, sauceignoreFile stri
 			return []Suite{}, err
 		}
 
-		files = sauceignore.ExcludeSauceIgnorePatterns(files, sauceignoreFile)
+		files = sauceignore.ExcludeSauceIgnorePatterns(files, config.SuiteSauceignore(sauceignoreFile, s.Sauceignore))
 		testFiles := fpath.ExcludeFiles(files, excludedFiles)
 
 		if s.ShardGrepEnabled && (s.Params.Grep != "" || s.Params.GrepInvert != "") {
@@ -251,6 +263,7 @@ func shardInSuites(rootDir string, suites []Suite, ccy int, sauceignoreFile stri
 			for _, f := range testFiles {
 				replica := s
 				replica.Name = fmt.Sprintf("%s - %s", s.Name, f)
+				shards[s.Name] = append(shards[s.Name], replica.Name)
 				replica.TestMatch = []string{f}
 				shardedSuites = append(shardedSuites, replica)
 			}
@@ -260,11 +273,15 @@ func shardInSuites(rootDir string, suites []Suite, ccy int, sauceignoreFile stri
 			for i, group := range groups {
 				replica := s
 				replica.Name = fmt.Sprintf("%s - %d/%d", s.Name, i+1, len(groups))
+				shards[s.Name] = append(shards[s.Name], replica.Name)
 				replica.TestMatch = group
 				shardedSuites = append(shardedSuites, replica)
 			}
 		}
 	}
+	for i := range shardedSuites {
+		shardedSuites[i].DependsOn = config.ShardedDependencies(shardedSuites[i].DependsOn, shards)
+	}
 	return shardedSuites, nil
 }
 
@@ -272,6 +289,7 @@ func shardInSuites(rootDir string, suites []Suite, ccy int, sauceignoreFile stri
 // the numShards setting on each suite. A suite is only sharded if numShards > 1.
 func shardSuitesByNumShards(suites []Suite) []Suite {
 	var shardedSuites []Suite
+	shards := make(map[string][]string)
 	for _, s := range suites {
 		// Use the original suite if there is nothing to shard.
 		if s.NumShards <= 1 {
@@ -283,9 +301,15 @@ func shardSuitesByNumShards(suites []Suite) []Suite {
 			replica := s
 			replica.Params.Shard = fmt.Sprintf("%d/%d", i, s.NumShards)
 			replica.Name = fmt.Sprintf("%s (shard %s)", replica.Name, replica.Params.Shard)
+			shards[s.Name] = append(shards[s.Name], replica.Name)
 			shardedSuites = append(shardedSuites, replica)
 		}
 	}
+
+	for i := range shardedSuites {
+		shardedSuites[i].DependsOn = config.ShardedDependencies(shardedSuites[i].DependsOn, shards)
+	}
+
 	return shardedSuites
 }
 
@@ -318,13 +342,25 @@ func Validate(p *Project) error {
 		return fmt.Errorf(msg.InvalidVisibility, p.Sauce.Visibility, strings.Join(config.ValidVisibilityValues, ","))
 	}
 
+	if err := p.Sauce.Archive.Validate(); err != nil {
+		return fmt.Errorf("invalid archive settings: %w", err)
+	}
+
+	if err := p.Sauce.RetryPolicy.Validate(); err != nil {
+		return fmt.Errorf("invalid retry policy: %w", err)
+	}
+
+	if err := p.Sauce.AutoConcurrency.Validate(); err != nil {
+		return fmt.Errorf("invalid auto concurrency: %w", err)
+	}
+
 	err := config.ValidateRegistries(p.Npm.Registries)
 	if err != nil {
 		return err
 	}
 
-	if p.Sauce.LaunchOrder != "" && p.Sauce.LaunchOrder != config.LaunchOrderFailRate {
-		return fmt.Errorf(msg.InvalidLaunchingOption, p.Sauce.LaunchOrder, string(config.LaunchOrderFailRate))
+	if err := config.ValidateLaunchOrder(p.Sauce.LaunchOrder); err != nil {
+		return err
 	}
 
 	suiteNames := make(map[string]bool)
@@ -343,11 +379,18 @@ func Validate(p *Project) error {
 				return fmt.Errorf(msg.IllegalSymbol, c, s.Name)
 			}
 		}
-		if p.Sauce.Retries < s.PassThreshold-1 {
+		if p.Sauce.RetryPolicy.Budget(config.RetryOnFailure, config.SuiteRetries(p.Sauce.Retries, s.Retries)) < s.PassThreshold-1 {
 			return fmt.Errorf(msg.InvalidPassThreshold)
 		}
 	}
 
+	var deps []config.SuiteDependencies
+	for _, s := range p.Suites {
+		deps = append(deps, config.SuiteDependencies{Suite: s.Name, DependsOn: s.DependsOn})
+	}
+	if err := config.ValidateDependencies(deps); err != nil {
+		return err
+	}
 	if p.Sauce.Retries < 0 {
 		log.Warn().Int("retries", p.Sauce.Retries).Msg(msg.InvalidReries)
 	}
@@ -375,15 +418,19 @@ func isSupportedBrowser(browser string) bool {
 	return false
 }
 
-// FilterSuites filters out suites in the project that don't match the given suite name.
-func FilterSuites(p *Project, suiteName string) error {
+// FilterSuites filters out suites in the project that aren't matched by the given selector.
+func FilterSuites(p *Project, sel config.SuiteSelector) error {
+	var suites []Suite
 	for _, s := range p.Suites {
-		if s.Name == suiteName {
-			p.Suites = []Suite{s}
-			return nil
+		if sel.Match(s.Name, s.Tags) {
+			suites = append(suites, s)
 		}
 	}
-	return fmt.Errorf(msg.SuiteNameNotFound, suiteName)
+	if len(suites) == 0 {
+		return sel.NoMatchError()
+	}
+	p.Suites = suites
+	return nil
 }
 
 func IsSharded(suites []Suite) bool {
@@ -397,23 +444,7 @@ func IsSharded(suites []Suite) bool {
 
 // SortByHistory sorts the suites in the order of job history
 func SortByHistory(suites []Suite, history insights.JobHistory) []Suite {
-	hash := map[string]Suite{}
-	for _, s := range suites {
-		hash[s.Name] = s
-	}
-	var res []Suite
-	for _, s := range history.TestCases {
-		if v, ok := hash[s.Name]; ok {
-			res = append(res, v)
-			delete(hash, s.Name)
-		}
-	}
-	for _, v := range suites {
-		if _, ok := hash[v.Name]; ok {
-			res = append(res, v)
-		}
-	}
-	return res
+	return insights.SortByHistory(suites, history, func(s Suite) string { return s.Name })
 }
 
 // FilterFailedTests takes the failed tests in the report and sets them as a test filter in the suite.
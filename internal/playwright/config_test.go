@@ -229,6 +229,22 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestSetDefaults_Env(t *testing.T) {
+	p := Project{
+		Env:     map[string]string{"A": "root", "B": "root"},
+		EnvFlag: map[string]string{"A": "flag"},
+		Suites: []Suite{
+			{Name: "suite #1", Env: map[string]string{"B": "suite", "C": "suite"}},
+			{Name: "suite #2"},
+		},
+	}
+
+	SetDefaults(&p)
+
+	assert.Equal(t, map[string]string{"A": "flag", "B": "root", "C": "suite"}, p.Suites[0].Env)
+	assert.Equal(t, map[string]string{"A": "flag", "B": "root"}, p.Suites[1].Env)
+}
+
 func TestPlaywright_SortByHistory(t *testing.T) {
 	testCases := []struct {
 		name    string
@@ -45,6 +45,7 @@ type Project struct {
 // Suite represents the playwright test suite configuration.
 type Suite struct {
 	Name           string        `yaml:"name,omitempty" json:"name"`
+	Tags           []string      `yaml:"tags,omitempty" json:"-"`
 	Timeout        time.Duration `yaml:"timeout,omitempty" json:"timeout"`
 	Recording      string        `yaml:"recording,omitempty" json:"recording,omitempty"`
 	BrowserName    string        `yaml:"browserName,omitempty" json:"browserName,omitempty"`
@@ -53,6 +54,9 @@ type Suite struct {
 
 	Recordings    []string `yaml:"recordings,omitempty" json:"-"`
 	PassThreshold int      `yaml:"passThreshold,omitempty" json:"-"`
+	Retries       *int     `yaml:"retries,omitempty" json:"-"`
+	Priority      int      `yaml:"priority,omitempty" json:"-"`
+	DependsOn     []string `yaml:"dependsOn,omitempty" json:"-"`
 }
 
 // FromFile creates a new replay Project based on the filepath cfgPath.
@@ -120,8 +124,20 @@ func Validate(p *Project) error {
 		return fmt.Errorf(msg.InvalidVisibility, p.Sauce.Visibility, strings.Join(config.ValidVisibilityValues, ","))
 	}
 
-	if p.Sauce.LaunchOrder != "" && p.Sauce.LaunchOrder != config.LaunchOrderFailRate {
-		return fmt.Errorf(msg.InvalidLaunchingOption, p.Sauce.LaunchOrder, string(config.LaunchOrderFailRate))
+	if err := p.Sauce.Archive.Validate(); err != nil {
+		return fmt.Errorf("invalid archive settings: %w", err)
+	}
+
+	if err := p.Sauce.RetryPolicy.Validate(); err != nil {
+		return fmt.Errorf("invalid retry policy: %w", err)
+	}
+
+	if err := p.Sauce.AutoConcurrency.Validate(); err != nil {
+		return fmt.Errorf("invalid auto concurrency: %w", err)
+	}
+
+	if err := config.ValidateLaunchOrder(p.Sauce.LaunchOrder); err != nil {
+		return err
 	}
 
 	if len(p.Suites) == 0 {
@@ -142,11 +158,18 @@ func Validate(p *Project) error {
 		if !rgx.MatchString(s.BrowserName) {
 			return fmt.Errorf("browser %s is not supported, please use chrome instead or leave empty for defaults", s.BrowserName)
 		}
-		if p.Sauce.Retries < s.PassThreshold-1 {
+		if p.Sauce.RetryPolicy.Budget(config.RetryOnFailure, config.SuiteRetries(p.Sauce.Retries, s.Retries)) < s.PassThreshold-1 {
 			return fmt.Errorf(msg.InvalidPassThreshold)
 		}
 	}
 
+	var deps []config.SuiteDependencies
+	for _, s := range p.Suites {
+		deps = append(deps, config.SuiteDependencies{Suite: s.Name, DependsOn: s.DependsOn})
+	}
+	if err := config.ValidateDependencies(deps); err != nil {
+		return err
+	}
 	if p.Sauce.Retries < 0 {
 		log.Warn().Int("retries", p.Sauce.Retries).Msg(msg.InvalidReries)
 	}
@@ -154,20 +177,25 @@ func Validate(p *Project) error {
 	return nil
 }
 
-// FilterSuites filters out suites in the project that don't match the given suite name.
-func FilterSuites(p *Project, suiteName string) error {
+// FilterSuites filters out suites in the project that aren't matched by the given selector.
+func FilterSuites(p *Project, sel config.SuiteSelector) error {
+	var suites []Suite
 	for _, s := range p.Suites {
-		if s.Name == suiteName {
-			p.Suites = []Suite{s}
-			return nil
+		if sel.Match(s.Name, s.Tags) {
+			suites = append(suites, s)
 		}
 	}
-	return fmt.Errorf(msg.SuiteNameNotFound, suiteName)
+	if len(suites) == 0 {
+		return sel.NoMatchError()
+	}
+	p.Suites = suites
+	return nil
 }
 
 // ShardSuites automatically shards the suites for each recording.
 func ShardSuites(suites []Suite) ([]Suite, error) {
 	var shardedSuites []Suite
+	shards := make(map[string][]string)
 	for _, s := range suites {
 		testFiles, err := fpath.FindFiles(".", s.Recordings, fpath.FindByShellPattern)
 		if err != nil {
@@ -184,6 +212,7 @@ func ShardSuites(suites []Suite) ([]Suite, error) {
 			}
 			replica := s
 			replica.Name = fmt.Sprintf("%s - %s", s.Name, f)
+			shards[s.Name] = append(shards[s.Name], replica.Name)
 			replica.Recording = f
 			shardedSuites = append(shardedSuites, replica)
 		}
@@ -193,26 +222,14 @@ func ShardSuites(suites []Suite) ([]Suite, error) {
 		return shardedSuites, errors.New("no viable suites found")
 	}
 
+	for i := range shardedSuites {
+		shardedSuites[i].DependsOn = config.ShardedDependencies(shardedSuites[i].DependsOn, shards)
+	}
+
 	return shardedSuites, nil
 }
 
 // SortByHistory sorts the suites in the order of job history
 func SortByHistory(suites []Suite, history insights.JobHistory) []Suite {
-	hash := map[string]Suite{}
-	for _, s := range suites {
-		hash[s.Name] = s
-	}
-	var res []Suite
-	for _, s := range history.TestCases {
-		if v, ok := hash[s.Name]; ok {
-			res = append(res, v)
-			delete(hash, s.Name)
-		}
-	}
-	for _, v := range suites {
-		if _, ok := hash[v.Name]; ok {
-			res = append(res, v)
-		}
-	}
-	return res
+	return insights.SortByHistory(suites, history, func(s Suite) string { return s.Name })
 }
@@ -150,7 +150,8 @@ func TestFilterSuites(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := FilterSuites(tt.args.p, tt.args.suiteName); (err != nil) != tt.wantErr {
+			sel, _ := config.NewSuiteSelector([]string{tt.args.suiteName}, nil, nil)
+			if err := FilterSuites(tt.args.p, sel); (err != nil) != tt.wantErr {
 				t.Errorf("FilterSuites() error = %v, wantErr %v", err, tt.wantErr)
 			}
 
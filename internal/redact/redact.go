@@ -0,0 +1,171 @@
+// Package redact removes sensitive values from configuration files and command line flags before they leave the
+// machine, e.g. as job assets.
+package redact
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/saucelabs/saucectl/internal/yaml"
+)
+
+// Mask is the replacement for redacted values.
+const Mask = "[REDACTED]"
+
+// DefaultKeys are the keys whose values are always redacted. A key matches if it equals the trailing segments of the
+// dot-separated path of a value (case-insensitive). Each segment may contain shell wildcards.
+var DefaultKeys = []string{
+	"authToken",
+	"token",
+	"password",
+	"accessKey",
+	"cypress.key",
+	"env.*",
+	"envFlag.*",
+	"tunnel.name",
+	"tunnel.owner",
+	"tunnel-name",
+	"tunnel-owner",
+	"imagePullAuth.user",
+}
+
+// Redactor redacts values by key or by pattern.
+type Redactor struct {
+	keys     [][]string
+	patterns []*regexp.Regexp
+}
+
+// New creates a new Redactor that redacts the DefaultKeys in addition to the given keys. Values (or parts of values)
+// that match any of the regular expressions in patterns are redacted as well.
+func New(keys []string, patterns []string) (*Redactor, error) {
+	r := &Redactor{}
+	for _, k := range append(DefaultKeys, keys...) {
+		segments := strings.Split(strings.ToLower(k), ".")
+		for _, s := range segments {
+			if _, err := path.Match(s, ""); err != nil {
+				return nil, fmt.Errorf("invalid redaction key '%s': %w", k, err)
+			}
+		}
+		r.keys = append(r.keys, segments)
+	}
+
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern '%s': %w", p, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+
+	return r, nil
+}
+
+// YAML redacts the given YAML content. Comments and formatting are retained as much as possible.
+func (r *Redactor) YAML(content []byte) ([]byte, error) {
+	doc, err := yaml.ParseDocument(content)
+	if err != nil {
+		return nil, err
+	}
+	r.node(yaml.Root(doc), nil)
+
+	return yaml.EncodeDocument(doc)
+}
+
+func (r *Redactor) node(n *yaml.Node, keyPath []string) {
+	switch {
+	case yaml.IsMapping(n):
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			r.node(n.Content[i+1], append(keyPath, n.Content[i].Value))
+		}
+	case yaml.IsSequence(n):
+		for _, c := range n.Content {
+			r.node(c, keyPath)
+		}
+	case yaml.IsScalar(n):
+		if v := r.value(n.Value, keyPath); v != n.Value {
+			n.Value = v
+			n.Tag = "!!str"
+			n.Style = 0
+		}
+	}
+}
+
+// Map returns a redacted copy of m.
+func (r *Redactor) Map(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		// Flags are named after their config path, e.g. 'npm.registry'.
+		out[k] = r.any(v, strings.Split(k, "."))
+	}
+	return out
+}
+
+func (r *Redactor) any(v interface{}, keyPath []string) interface{} {
+	switch val := v.(type) {
+	case string:
+		return r.value(val, keyPath)
+	case []string:
+		out := make([]string, len(val))
+		for i, s := range val {
+			out[i] = r.value(s, keyPath)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, s := range val {
+			out[i] = r.any(s, keyPath)
+		}
+		return out
+	case map[string]string:
+		out := make(map[string]string, len(val))
+		for k, s := range val {
+			out[k] = r.value(s, append(keyPath, k))
+		}
+		return out
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, s := range val {
+			out[k] = r.any(s, append(keyPath, k))
+		}
+		return out
+	}
+	return v
+}
+
+func (r *Redactor) value(v string, keyPath []string) string {
+	if v == "" {
+		return v
+	}
+	if r.matchKey(keyPath) {
+		return Mask
+	}
+	for _, re := range r.patterns {
+		v = re.ReplaceAllString(v, Mask)
+	}
+	return v
+}
+
+func (r *Redactor) matchKey(keyPath []string) bool {
+	for _, k := range r.keys {
+		if len(k) > len(keyPath) {
+			continue
+		}
+		tail := keyPath[len(keyPath)-len(k):]
+		matched := true
+		for i, segment := range k {
+			if ok, _ := path.Match(segment, strings.ToLower(tail[i])); !ok {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
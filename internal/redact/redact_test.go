@@ -0,0 +1,122 @@
+package redact
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRedactor_YAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []string
+		patterns []string
+		content  string
+		want     string
+	}{
+		{
+			name: "default keys",
+			content: `sauce:
+  region: us-west-1
+  tunnel:
+    name: my-tunnel # the tunnel
+npm:
+  registries:
+    - url: https://registry.npmjs.org
+      authToken: abc123
+env:
+  FOO: bar
+suites:
+  - name: chrome
+    config:
+      env:
+        BAZ: qux
+`,
+			want: `sauce:
+  region: us-west-1
+  tunnel:
+    name: '[REDACTED]' # the tunnel
+npm:
+  registries:
+    - url: https://registry.npmjs.org
+      authToken: '[REDACTED]'
+env:
+  FOO: '[REDACTED]'
+suites:
+  - name: chrome
+    config:
+      env:
+        BAZ: '[REDACTED]'
+`,
+		},
+		{
+			name:     "custom keys and patterns",
+			keys:     []string{"sauce.metadata.build", "*Secret*"},
+			patterns: []string{`ghp_[A-Za-z0-9]+`},
+			content: `sauce:
+  metadata:
+    build: build-123
+    tags:
+      - token ghp_abc123 in a tag
+mySecretValue: 42
+`,
+			want: `sauce:
+  metadata:
+    build: '[REDACTED]'
+    tags:
+      - token [REDACTED] in a tag
+mySecretValue: '[REDACTED]'
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := New(tt.keys, tt.patterns)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := r.YAML([]byte(tt.content))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("YAML() got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactor_Map(t *testing.T) {
+	r, err := New(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flags := map[string]interface{}{
+		"env":          map[string]string{"FOO": "bar"},
+		"tunnel-name":  "my-tunnel",
+		"cypress.key":  "record-key",
+		"select-suite": "chrome",
+	}
+	want := map[string]interface{}{
+		"env":          map[string]string{"FOO": Mask},
+		"tunnel-name":  Mask,
+		"cypress.key":  Mask,
+		"select-suite": "chrome",
+	}
+
+	if got := r.Map(flags); !reflect.DeepEqual(got, want) {
+		t.Errorf("Map() got = %v, want %v", got, want)
+	}
+	if flags["tunnel-name"] != "my-tunnel" {
+		t.Errorf("Map() must not modify its input")
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	if _, err := New(nil, []string{"("}); err == nil {
+		t.Errorf("New() expected error for invalid pattern")
+	}
+	if _, err := New([]string{"["}, nil); err == nil {
+		t.Errorf("New() expected error for invalid key")
+	}
+}
@@ -104,6 +104,8 @@ func statusToEmoji(status string) string {
 		return ":interrobang:"
 	case job.StateError, job.StateFailed:
 		return ":x:"
+	case job.StateSkipped:
+		return ":fast_forward:"
 	default:
 		return ":warning:"
 	}
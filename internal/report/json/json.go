@@ -9,6 +9,7 @@ import (
 
 	"github.com/rs/zerolog/log"
 	"github.com/saucelabs/saucectl/internal/report"
+	"github.com/saucelabs/saucectl/internal/secret"
 )
 
 // Reporter represents struct to report in json format
@@ -30,6 +31,7 @@ func (r *Reporter) Render() {
 		log.Err(err).Msg("failed to generate test result.")
 		return
 	}
+	body = secret.MaskBytes(body)
 
 	if r.WebhookURL != "" {
 		resp, err := http.Post(r.WebhookURL, "application/json", bytes.NewBuffer(body))
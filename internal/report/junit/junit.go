@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/rs/zerolog/log"
@@ -12,11 +13,22 @@ import (
 	"github.com/saucelabs/saucectl/internal/report"
 )
 
+// Supported report modes, which determine how the attempts of a retried suite are reported.
+const (
+	// ModeMerge reports the last result of each test across all attempts.
+	ModeMerge = "merge"
+	// ModeCollapse reports each test once, as passed if any attempt passed. The failures of the other attempts are
+	// recorded as <flakyFailure> or <rerunFailure> elements, in the style of Maven Surefire.
+	ModeCollapse = "collapse"
+)
+
 // Reporter is a junit implementation for report.Reporter.
 type Reporter struct {
 	TestResults []report.TestResult
 	Filename    string
-	lock        sync.Mutex
+	// Mode determines how retries are reported. Defaults to ModeMerge.
+	Mode string
+	lock sync.Mutex
 }
 
 // Add adds the test result to the summary.
@@ -44,11 +56,29 @@ func (r *Reporter) Render() {
 			allTestSuites = append(allTestSuites, attempt.TestSuites)
 		}
 
-		combinedReports := junit.MergeReports(allTestSuites...)
+		var combinedReports junit.TestSuites
+		if r.Mode == ModeCollapse {
+			combinedReports = junit.CollapseReports(allTestSuites...)
+			t.Properties = append(t.Properties, attemptProperties(v)...)
+		} else {
+			combinedReports = junit.MergeReports(allTestSuites...)
+		}
 		for _, ts := range combinedReports.TestSuites {
 			t.TestCases = append(t.TestCases, ts.TestCases...)
 		}
 
+		// Document the tests that only passed on retry.
+		var recovered []string
+		for _, tc := range junit.RecoveredTests(allTestSuites...) {
+			recovered = append(recovered, fmt.Sprintf("%s.%s", tc.ClassName, tc.Name))
+		}
+		if len(recovered) > 0 {
+			t.Properties = append(t.Properties, junit.Property{
+				Name:  "recovered",
+				Value: strings.Join(recovered, ","),
+			})
+		}
+
 		tt.TestSuites = append(tt.TestSuites, t)
 	}
 
@@ -92,6 +122,10 @@ func extractProperties(r report.TestResult) []junit.Property {
 			Name:  "platform",
 			Value: r.Platform,
 		},
+		{
+			Name:  "skipReason",
+			Value: r.SkipReason,
+		},
 	}
 
 	var filtered []junit.Property
@@ -107,6 +141,31 @@ func extractProperties(r report.TestResult) []junit.Property {
 	return filtered
 }
 
+// attemptProperties documents the number of attempts of a suite and the IDs of their jobs.
+func attemptProperties(r report.TestResult) []junit.Property {
+	var ids []string
+	for _, a := range r.Attempts {
+		if a.ID != "" {
+			ids = append(ids, a.ID)
+		}
+	}
+
+	props := []junit.Property{
+		{
+			Name:  "attempts",
+			Value: strconv.Itoa(len(r.Attempts)),
+		},
+	}
+	if len(ids) > 0 {
+		props = append(props, junit.Property{
+			Name:  "jobIDs",
+			Value: strings.Join(ids, ","),
+		})
+	}
+
+	return props
+}
+
 // Reset resets the reporter to its initial state. This action will delete all test results.
 func (r *Reporter) Reset() {
 	r.lock.Lock()
@@ -8,12 +8,14 @@ import (
 	"time"
 
 	"github.com/saucelabs/saucectl/internal/job"
+	"github.com/saucelabs/saucectl/internal/junit"
 	"github.com/saucelabs/saucectl/internal/report"
 )
 
 func TestReporter_Render(t *testing.T) {
 	type fields struct {
 		TestResults []report.TestResult
+		Mode        string
 	}
 	tests := []struct {
 		name   string
@@ -92,6 +94,105 @@ func TestReporter_Render(t *testing.T) {
     </properties>
   </testsuite>
 </testsuites>
+`,
+		},
+		{
+			name: "with retries",
+			fields: fields{
+				TestResults: []report.TestResult{
+					{
+						Name:       "Pixel",
+						Duration:   60 * time.Second,
+						Status:     job.StatePassed,
+						DeviceName: "Google Pixel 7",
+						Attempts: []report.Attempt{
+							{
+								Status: job.StateFailed,
+								TestSuites: junit.TestSuites{TestSuites: []junit.TestSuite{{
+									Name: "LoginTest",
+									TestCases: []junit.TestCase{
+										{Name: "logsIn", ClassName: "com.example.LoginTest"},
+										{Name: "logsOut", ClassName: "com.example.LoginTest", Failure: &junit.Failure{Message: "flaky"}},
+									},
+								}}},
+							},
+							{
+								Status: job.StatePassed,
+								TestSuites: junit.TestSuites{TestSuites: []junit.TestSuite{{
+									Name: "LoginTest",
+									TestCases: []junit.TestCase{
+										{Name: "logsOut", ClassName: "com.example.LoginTest"},
+									},
+								}}},
+							},
+						},
+					},
+				},
+			},
+			want: `<testsuites tests="2">
+  <testsuite name="Pixel" tests="2" time="60">
+    <properties>
+      <property name="device" value="Google Pixel 7"></property>
+      <property name="recovered" value="com.example.LoginTest.logsOut"></property>
+    </properties>
+    <testcase name="logsIn" time="" timestamp="" classname="com.example.LoginTest"></testcase>
+    <testcase name="logsOut" time="" timestamp="" classname="com.example.LoginTest"></testcase>
+  </testsuite>
+</testsuites>
+`,
+		},
+		{
+			name: "collapsed retries",
+			fields: fields{
+				Mode: ModeCollapse,
+				TestResults: []report.TestResult{
+					{
+						Name:       "Pixel",
+						Duration:   60 * time.Second,
+						Status:     job.StatePassed,
+						DeviceName: "Google Pixel 7",
+						Attempts: []report.Attempt{
+							{
+								ID:     "job-1",
+								Status: job.StateFailed,
+								TestSuites: junit.TestSuites{TestSuites: []junit.TestSuite{{
+									Name: "LoginTest",
+									TestCases: []junit.TestCase{
+										{Name: "logsIn", ClassName: "com.example.LoginTest"},
+										{Name: "logsOut", ClassName: "com.example.LoginTest", Failure: &junit.Failure{Message: "flaky", Text: "trace"}},
+									},
+								}}},
+							},
+							{
+								ID:     "job-2",
+								Status: job.StatePassed,
+								TestSuites: junit.TestSuites{TestSuites: []junit.TestSuite{{
+									Name: "LoginTest",
+									TestCases: []junit.TestCase{
+										{Name: "logsOut", ClassName: "com.example.LoginTest"},
+									},
+								}}},
+							},
+						},
+					},
+				},
+			},
+			want: `<testsuites tests="2">
+  <testsuite name="Pixel" tests="2" time="60">
+    <properties>
+      <property name="device" value="Google Pixel 7"></property>
+      <property name="attempts" value="2"></property>
+      <property name="jobIDs" value="job-1,job-2"></property>
+      <property name="recovered" value="com.example.LoginTest.logsOut"></property>
+    </properties>
+    <testcase name="logsIn" time="" timestamp="" classname="com.example.LoginTest"></testcase>
+    <testcase name="logsOut" time="" timestamp="" classname="com.example.LoginTest">
+      <flakyFailure message="flaky">
+        <stackTrace>trace</stackTrace>
+      </flakyFailure>
+    </testcase>
+  </testsuite>
+</testsuites>
 `,
 		},
 	}
@@ -107,6 +208,7 @@ func TestReporter_Render(t *testing.T) {
 			r := &Reporter{
 				TestResults: tt.fields.TestResults,
 				Filename:    f.Name(),
+				Mode:        tt.fields.Mode,
 			}
 			r.Render()
 
@@ -41,6 +41,10 @@ type TestResult struct {
 	TimedOut      bool          `json:"-"`
 	PassThreshold bool          `json:"-"`
 	Attempts      []Attempt     `json:"-"`
+	// SkipReason explains why the suite was skipped, e.g. since a suite it depends on didn't pass.
+	SkipReason string `json:"skipReason,omitempty"`
+	// BudgetSkipped indicates that the suite wasn't launched, since the run budget was exhausted.
+	BudgetSkipped bool `json:"budgetSkipped,omitempty"`
 }
 
 // ArtifactType represents the type of assets (e.g. a junit report). Semantically similar to Content-Type.
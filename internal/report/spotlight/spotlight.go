@@ -59,6 +59,11 @@ func (r *Reporter) Render() {
 
 	for _, ts := range r.TestResults {
 		r.println("", jobStatusSymbol(ts.Status), ts.Name)
+		if ts.SkipReason != "" {
+			r.println("   ● Skipped:", ts.SkipReason)
+			r.println()
+			continue
+		}
 		r.println("   ● URL:", ts.URL)
 
 		var junitReports []junit.TestSuites
@@ -119,6 +124,8 @@ func jobStatusSymbol(status string) string {
 	case job.StateInProgress, job.StateQueued, job.StateNew, imagerunner.StateRunning, imagerunner.StatePending,
 		imagerunner.StateUploading:
 		return color.BlueString("*")
+	case job.StateSkipped:
+		return color.YellowString("-")
 	default:
 		return color.RedString("✖")
 	}
@@ -98,8 +98,14 @@ func (r *Reporter) Render() {
 		errors     int
 		inProgress int
 		totalDur   time.Duration
+		// budgetSkipped are listed separately, since they were never launched.
+		budgetSkipped []report.TestResult
 	)
 	for _, ts := range r.TestResults {
+		if ts.BudgetSkipped {
+			budgetSkipped = append(budgetSkipped, ts)
+			continue
+		}
 		if !job.Done(ts.Status) && !imagerunner.Done(ts.Status) && !ts.TimedOut {
 			inProgress++
 		}
@@ -119,10 +125,17 @@ func (r *Reporter) Render() {
 			statusText(ts.Status), ts.Browser, ts.Platform, ts.DeviceName, len(ts.Attempts)})
 	}
 
-	t.AppendFooter(footer(errors, inProgress, len(r.TestResults), calDuration(r.TestResults)))
+	t.AppendFooter(footer(errors, inProgress, len(budgetSkipped), len(r.TestResults), calDuration(r.TestResults)))
 
 	_, _ = fmt.Fprintln(r.Dst)
 	t.Render()
+
+	if len(budgetSkipped) > 0 {
+		_, _ = fmt.Fprintf(r.Dst, "\nSuites skipped by budget:\n")
+		for _, ts := range budgetSkipped {
+			_, _ = fmt.Fprintf(r.Dst, "  %s  %s (%s)\n", statusSymbol(ts.Status), ts.Name, ts.SkipReason)
+		}
+	}
 }
 
 // Reset resets the reporter to its initial state. This action will delete all test results.
@@ -137,11 +150,14 @@ func (r *Reporter) ArtifactRequirements() []report.ArtifactType {
 	return nil
 }
 
-func footer(errors, inProgress, tests int, dur time.Duration) table.Row {
+func footer(errors, inProgress, budgetSkipped, tests int, dur time.Duration) table.Row {
 	if errors != 0 {
 		relative := float64(errors) / float64(tests) * 100
 		return table.Row{statusSymbol(job.StateError), fmt.Sprintf("%d of %d suites have failed (%.0f%%)", errors, tests, relative), dur.Truncate(1 * time.Second)}
 	}
+	if budgetSkipped != 0 {
+		return table.Row{statusSymbol(job.StateSkipped), fmt.Sprintf("%d of %d suites were skipped by budget", budgetSkipped, tests), dur.Truncate(1 * time.Second)}
+	}
 	if inProgress != 0 {
 		return table.Row{statusSymbol(job.StateInProgress), "All suites have launched", dur.Truncate(1 * time.Second)}
 	}
@@ -155,6 +171,8 @@ func statusText(status string) string {
 	case job.StateInProgress, job.StateQueued, job.StateNew, imagerunner.StateRunning, imagerunner.StatePending,
 		imagerunner.StateUploading:
 		return color.BlueString(status)
+	case job.StateSkipped:
+		return color.YellowString(status)
 	default:
 		return color.RedString(status)
 	}
@@ -167,6 +185,8 @@ func statusSymbol(status string) string {
 	case job.StateInProgress, job.StateQueued, job.StateNew, imagerunner.StateRunning, imagerunner.StatePending,
 		imagerunner.StateUploading:
 		return color.BlueString("*")
+	case job.StateSkipped:
+		return color.YellowString("-")
 	default:
 		return color.RedString("✖")
 	}
@@ -102,6 +102,42 @@ func TestReporter_Render(t *testing.T) {
   ✖    Chrome                                2m51s    failed    Chrome     Windows 10           3  
 ───────────────────────────────────────────────────────────────────────────────────────────────────
   ✖    1 of 2 suites have failed (50%)       2m51s                                                 
+`,
+		},
+		{
+			name: "skipped by budget",
+			fields: fields{
+				TestResults: []report.TestResult{
+					{
+						Name:      "Firefox",
+						Duration:  34479 * time.Millisecond,
+						StartTime: startTime,
+						EndTime:   startTime.Add(34479 * time.Millisecond),
+						Status:    job.StatePassed,
+						Browser:   "Firefox",
+						Platform:  "Windows 10",
+						Attempts: []report.Attempt{
+							{Status: job.StatePassed},
+						},
+					},
+					{
+						Name:          "Chrome",
+						Status:        job.StateSkipped,
+						Browser:       "Chrome",
+						SkipReason:    "run budget exhausted: time budget exceeded",
+						BudgetSkipped: true,
+					},
+				},
+			},
+			want: `
+       Name                                    Duration    Status    Browser    Platform      Attempts  
+────────────────────────────────────────────────────────────────────────────────────────────────────────
+  ✔    Firefox                                      34s    passed    Firefox    Windows 10           1  
+────────────────────────────────────────────────────────────────────────────────────────────────────────
+  -    1 of 2 suites were skipped by budget         34s                                                 
+
+Suites skipped by budget:
+  -  Chrome (run budget exhausted: time budget exceeded)
 `,
 		},
 	}
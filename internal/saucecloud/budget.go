@@ -0,0 +1,83 @@
+package saucecloud
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/saucelabs/saucectl/internal/insights"
+	"github.com/saucelabs/saucectl/internal/job"
+)
+
+// budget limits which suites are launched during a run, by the number of suites that failed and by the time that is
+// left. Suites that are already running are not affected.
+type budget struct {
+	mu sync.Mutex
+
+	// maxFailures is the number of failed suites after which no more suites are launched. Unlimited if 0.
+	maxFailures int
+	// failures is the number of suites that failed so far.
+	failures int
+
+	// deadline is the time by which suites need to finish. No deadline, if zero.
+	deadline time.Time
+	// durations are the historical durations of suites, keyed by suite name.
+	durations map[string]time.Duration
+}
+
+func newBudget(maxFailures int, timeBudget time.Duration, history insights.JobHistory) *budget {
+	b := &budget{
+		maxFailures: maxFailures,
+		durations:   make(map[string]time.Duration),
+	}
+	if timeBudget > 0 {
+		b.deadline = time.Now().Add(timeBudget)
+	}
+	for _, tc := range history.TestCases {
+		b.durations[tc.Name] = time.Duration(tc.AvgDuration * float64(time.Second))
+	}
+	return b
+}
+
+// exhausted returns the reason why a suite may no longer be launched, or an empty string if it may. Suites without
+// a historical duration are launched as long as there is time left.
+func (b *budget) exhausted(suite string) string {
+	if b == nil {
+		return ""
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.maxFailures > 0 && b.failures >= b.maxFailures {
+		return fmt.Sprintf("reached the maximum of %d failed suites", b.maxFailures)
+	}
+
+	if b.deadline.IsZero() {
+		return ""
+	}
+	left := time.Until(b.deadline)
+	if left <= 0 {
+		return "time budget exceeded"
+	}
+	if d, ok := b.durations[suite]; ok && d > left {
+		return fmt.Sprintf("expected to take %s, but only %s of the time budget are left",
+			d.Round(time.Second), left.Round(time.Second))
+	}
+
+	return ""
+}
+
+// done records the final result of a suite.
+func (b *budget) done(res result) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !res.skipped && job.Done(res.job.Status) && !res.job.Passed {
+		b.failures++
+	}
+}
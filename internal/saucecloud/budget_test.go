@@ -0,0 +1,100 @@
+package saucecloud
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/saucelabs/saucectl/internal/insights"
+	"github.com/saucelabs/saucectl/internal/job"
+	"github.com/saucelabs/saucectl/internal/mocks"
+	"github.com/saucelabs/saucectl/internal/saucecloud/retry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBudget_MaxFailures(t *testing.T) {
+	b := newBudget(2, 0, insights.JobHistory{})
+
+	b.done(result{job: job.Job{Status: job.StateFailed}})
+	b.done(result{job: job.Job{Status: job.StatePassed, Passed: true}})
+	b.done(result{job: job.Job{Status: job.StateSkipped}, skipped: true})
+	b.done(result{job: job.Job{Status: job.StateInProgress}})
+	assert.Empty(t, b.exhausted("a"))
+
+	b.done(result{job: job.Job{Status: job.StateError}})
+	assert.Equal(t, "reached the maximum of 2 failed suites", b.exhausted("a"))
+}
+
+func TestBudget_TimeBudget(t *testing.T) {
+	b := newBudget(0, time.Hour, insights.JobHistory{
+		TestCases: []insights.TestCase{
+			{Name: "quick", AvgDuration: 60},
+			{Name: "slow", AvgDuration: 2 * 60 * 60},
+		},
+	})
+
+	assert.Empty(t, b.exhausted("quick"))
+	assert.Empty(t, b.exhausted("unknown"))
+	assert.Contains(t, b.exhausted("slow"), "expected to take 2h0m0s")
+
+	b.deadline = time.Now().Add(-time.Second)
+	assert.Equal(t, "time budget exceeded", b.exhausted("unknown"))
+}
+
+func TestRunJobsBudgetSkipped(t *testing.T) {
+	r := CloudRunner{budget: newBudget(1, 0, insights.JobHistory{})}
+	r.budget.done(result{job: job.Job{Status: job.StateFailed}})
+
+	opts := make(chan job.StartOptions)
+	results := make(chan result)
+
+	go r.runJobs(opts, results)
+	opts <- job.StartOptions{DisplayName: "dummy", SuiteName: "dummy"}
+	close(opts)
+	res := <-results
+	assert.True(t, res.skipped)
+	assert.True(t, res.budgetSkipped)
+	assert.Equal(t, job.StateSkipped, res.job.Status)
+	assert.EqualError(t, res.err, "run budget exhausted: reached the maximum of 1 failed suites")
+}
+
+func TestRunJobsBudgetExhaustedBeforeRetry(t *testing.T) {
+	var starts int
+	b := newBudget(1, 0, insights.JobHistory{})
+	r := CloudRunner{
+		budget:  b,
+		Retrier: &retry.BasicRetrier{},
+		JobService: JobService{
+			VDCStarter: &mocks.FakeJobStarter{
+				StartJobFn: func(ctx context.Context, opts job.StartOptions) (jobID string, isRDC bool, err error) {
+					starts++
+					return "1", false, nil
+				},
+			},
+			VDCReader: &mocks.FakeJobReader{
+				PollJobFn: func(ctx context.Context, id string, interval time.Duration, timeout time.Duration) (job.Job, error) {
+					// Another suite fails while this one is running.
+					b.done(result{job: job.Job{Status: job.StateFailed}})
+					return job.Job{ID: id, Status: job.StateFailed}, nil
+				},
+			},
+			VDCWriter: &mocks.FakeJobWriter{UploadAssetFn: func(jobID string, fileName string, contentType string, content []byte) error {
+				return nil
+			}},
+		},
+	}
+
+	opts := make(chan job.StartOptions, 1)
+	results := make(chan result)
+
+	go r.runJobs(opts, results)
+	opts <- job.StartOptions{DisplayName: "dummy", SuiteName: "dummy", Retries: 2}
+	res := <-results
+	close(opts)
+
+	assert.Equal(t, 1, starts)
+	assert.False(t, res.budgetSkipped)
+	assert.Equal(t, job.StateFailed, res.job.Status)
+	assert.Len(t, res.attempts, 1)
+	assert.EqualError(t, res.err, "run budget exhausted: reached the maximum of 1 failed suites")
+}
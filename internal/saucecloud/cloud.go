@@ -2,15 +2,21 @@ package saucecloud
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"os"
 	"os/signal"
 	"path"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"golang.org/x/text/cases"
@@ -20,6 +26,7 @@ import (
 	ptable "github.com/jedib0t/go-pretty/v6/table"
 	"github.com/rs/zerolog/log"
 	"github.com/saucelabs/saucectl/internal/apps"
+	"github.com/saucelabs/saucectl/internal/archive"
 	"github.com/saucelabs/saucectl/internal/build"
 	"github.com/saucelabs/saucectl/internal/config"
 	"github.com/saucelabs/saucectl/internal/espresso"
@@ -30,13 +37,16 @@ import (
 	"github.com/saucelabs/saucectl/internal/job"
 	"github.com/saucelabs/saucectl/internal/junit"
 	"github.com/saucelabs/saucectl/internal/msg"
+	"github.com/saucelabs/saucectl/internal/payload"
 	"github.com/saucelabs/saucectl/internal/progress"
+	"github.com/saucelabs/saucectl/internal/redact"
 	"github.com/saucelabs/saucectl/internal/region"
 	"github.com/saucelabs/saucectl/internal/report"
 	"github.com/saucelabs/saucectl/internal/saucecloud/retry"
 	"github.com/saucelabs/saucectl/internal/saucecloud/zip"
 	"github.com/saucelabs/saucectl/internal/sauceignore"
 	"github.com/saucelabs/saucectl/internal/saucereport"
+	"github.com/saucelabs/saucectl/internal/secret"
 	"github.com/saucelabs/saucectl/internal/storage"
 	"github.com/saucelabs/saucectl/internal/tunnel"
 )
@@ -55,16 +65,67 @@ type CloudRunner struct {
 	UserService            iam.UserService
 	BuildService           build.Reader
 	Retrier                retry.Retrier
+	// RetryPolicy describes when, how often and how soon suites are retried.
+	RetryPolicy config.RetryPolicy
+	// AutoConcurrency sizes the worker pool according to the concurrency that is available to the account, if enabled.
+	AutoConcurrency config.AutoConcurrency
+	Redactor        *redact.Redactor
 
 	Reporters []report.Reporter
 
 	Async    bool
 	FailFast bool
 
+	// Local indicates that jobs don't run in the Sauce Labs cloud, but on a local backend (see JobService). Jobs
+	// then have neither a Sauce Labs URL nor a build, and aren't reported to Insights.
+	Local bool
+
+	// MaxFailures stops launching suites once that many suites have failed. Unlimited if 0.
+	MaxFailures int
+	// TimeBudget stops launching suites that aren't expected to finish within it, based on their historical
+	// durations. It starts once the workers are launched. Unlimited if 0.
+	TimeBudget time.Duration
+
+	// GracePeriod bounds how long an interrupted run waits for running suites to stop. Unbounded if 0.
+	GracePeriod time.Duration
+	// TimingsPath is the file that the durations of suites are recorded in, which serves as their history if Insights
+	// is not available. Durations are not recorded if empty.
+	TimingsPath string
+	// ManifestPath is the file that the run manifest is written to, if the run is interrupted.
+	ManifestPath string
+	// Resume is the manifest of a previous run. Suites that passed in that run aren't launched again.
+	Resume Manifest
+
 	NPMDependencies []string
 
+	// PayloadListing describes how to list the contents of the project archives before they are uploaded.
+	PayloadListing payload.Options
+
+	// Archive describes how to create the project archives.
+	Archive archive.Options
+
 	interrupted bool
-	Cache       Cache
+	// signaled indicates that the run was interrupted by a signal, as opposed to FailFast, and can be resumed.
+	signaled bool
+	// graceExpired is closed once the grace period of an interrupted run has expired.
+	graceExpired chan struct{}
+	Cache        Cache
+
+	// schedule submits the jobs of the current run to the workers.
+	schedule *scheduler
+
+	// budget limits the suites that are launched, according to MaxFailures and TimeBudget.
+	budget *budget
+
+	// vdcThrottle and rdcThrottle limit the running jobs per cloud, when auto concurrency is enabled.
+	vdcThrottle *throttle
+	rdcThrottle *throttle
+
+	// attempts counts the jobs that have been started across all suites, which is capped by RetryPolicy.MaxAttempts.
+	attempts atomic.Int64
+
+	// suiteApps are the project archives of suites that have a sauceignore file of their own, keyed by that file.
+	suiteApps map[string]string
 }
 
 type Cache struct {
@@ -75,6 +136,7 @@ type Cache struct {
 type result struct {
 	name      string
 	browser   string
+	suite     string
 	job       job.Job
 	skipped   bool
 	err       error
@@ -84,22 +146,119 @@ type result struct {
 	retries   int
 	attempts  []report.Attempt
 
+	// budgetSkipped indicates that the suite wasn't launched, since the run budget was exhausted.
+	budgetSkipped bool
+	// resumed indicates that the suite wasn't launched, since it passed in the resumed run.
+	resumed bool
+	// manifest identifies the suite in the run manifest.
+	manifest ManifestSuite
+
 	details insights.Details
 }
 
 // ConsoleLogAsset represents job asset log file name.
 const ConsoleLogAsset = "console.log"
 
+// interruptSignals are the signals that interrupt a run, e.g. when it's cancelled by the user or by CI.
+var interruptSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+
+// createWorkerPool launches the workers and returns the channel to submit jobs to, as well as the channel that
+// receives their results. Jobs are scheduled by priority and dependencies, which requires all of them to be known.
+// They are therefore only launched once the returned job channel is closed. The result channel is never closed, since
+// the workers of an interrupted run may still deliver results after they are no longer waited on.
 func (r *CloudRunner) createWorkerPool(ccy int, maxRetries int) (chan job.StartOptions, chan result, error) {
+	queue := make(chan job.StartOptions)
 	jobOpts := make(chan job.StartOptions, maxRetries+1)
+	if r.AutoConcurrency.Enabled {
+		ccy = r.autoConcurrency(ccy)
+	}
 	results := make(chan result, ccy)
 
+	if r.MaxFailures > 0 || r.TimeBudget > 0 {
+		r.budget = r.newBudget()
+	}
+
 	log.Info().Int("concurrency", ccy).Msg("Launching workers.")
 	for i := 0; i < ccy; i++ {
 		go r.runJobs(jobOpts, results)
 	}
 
-	return jobOpts, results, nil
+	r.schedule = newScheduler(r.Async)
+	go r.schedule.run(queue, jobOpts, results)
+
+	return queue, results, nil
+}
+
+// newBudget creates the run budget. The time budget is based on the historical durations of suites, which are
+// retrieved from Insights or the local suite timings.
+func (r *CloudRunner) newBudget() *budget {
+	var history insights.JobHistory
+	if r.TimeBudget > 0 {
+		var err error
+		history, err = r.getHistory(config.LaunchOrderDuration)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to retrieve the suite durations. Suites are launched as long as there is time left.")
+		}
+	}
+
+	return newBudget(r.MaxFailures, r.TimeBudget, history)
+}
+
+// autoConcurrency returns the number of workers according to the concurrency that is available to the account, and
+// sets up the throttles that back off when jobs get queued nonetheless. Returns fallback if the concurrency can't be
+// determined.
+func (r *CloudRunner) autoConcurrency(fallback int) int {
+	ccy, err := r.UserService.Concurrency(context.Background())
+	if err != nil {
+		log.Warn().Err(err).Int("concurrency", fallback).Msg("Failed to determine the available concurrency.")
+		return fallback
+	}
+
+	available := ccy.Available()
+	vdc := r.AutoConcurrency.Size(available.VDC)
+	rdc := r.AutoConcurrency.Size(available.RDC)
+	r.vdcThrottle = newThrottle(vdc)
+	r.rdcThrottle = newThrottle(rdc)
+
+	log.Info().Int("vdc", vdc).Int("rdc", rdc).Int("reserve", r.AutoConcurrency.Reserve).
+		Msg("Sized concurrency according to the available concurrency.")
+
+	return max(vdc, rdc)
+}
+
+// throttleFor returns the throttle of the cloud a job runs in, or nil if jobs aren't throttled.
+func (r *CloudRunner) throttleFor(realDevice bool) *throttle {
+	if realDevice {
+		return r.rdcThrottle
+	}
+	return r.vdcThrottle
+}
+
+// checkQueued lets the throttle know whether a freshly started job got queued, i.e. whether the account ran out of
+// concurrency. The job is polled until it leaves the queue, and only counts as queued if it's still queued once the
+// grace period of the throttle has passed.
+func (r *CloudRunner) checkQueued(id string, opts job.StartOptions, t *throttle) {
+	deadline := time.Now().Add(t.grace)
+	for {
+		j, err := r.JobService.ReadJob(context.Background(), id, opts.RealDevice)
+		if err != nil {
+			log.Debug().Err(err).Str("suite", opts.DisplayName).Msg("Failed to check whether the suite is queued.")
+			return
+		}
+		if j.Status != job.StateQueued && j.Status != job.StateNew {
+			t.started(false)
+			return
+		}
+		if !time.Now().Before(deadline) {
+			queued := j.Status == job.StateQueued
+			if queued {
+				log.Warn().Str("suite", opts.DisplayName).Msg("Suite is queued, since the account is out of concurrency. Reducing concurrency.")
+			}
+			t.started(queued)
+			return
+		}
+		time.Sleep(t.interval)
+	}
 }
 
 func (r *CloudRunner) collectResults(artifactCfg config.ArtifactDownload, results chan result, expected int) bool {
@@ -124,8 +283,28 @@ func (r *CloudRunner) collectResults(artifactCfg config.ArtifactDownload, result
 		}
 	}(r)
 
+	var manifest Manifest
+	var collected []result
+collect:
 	for i := 0; i < expected; i++ {
-		res := <-results
+		var res result
+		// Results that are already available are preferred over the expiry of the grace period.
+		select {
+		case res = <-results:
+		default:
+			select {
+			case res = <-results:
+			case <-r.graceExpired:
+				log.Warn().Int("suites", expected-completed).Msg("Grace period expired. Not waiting for the remaining suites.")
+				passed = false
+				break collect
+			}
+		}
+
+		r.schedule.done(res)
+		r.budget.done(res)
+		manifest.add(res)
+		collected = append(collected, res)
 		// in case one of test suites not passed
 		// ignore jobs that are still in progress (i.e. async execution or client timeout)
 		// since their status is unknown
@@ -135,6 +314,21 @@ func (r *CloudRunner) collectResults(artifactCfg config.ArtifactDownload, result
 		completed++
 		inProgress--
 
+		if res.resumed {
+			// Suites that passed in the resumed run are reported as such, but there is nothing else to do about them.
+			for _, rep := range r.Reporters {
+				rep.Add(report.TestResult{
+					Name:    res.name,
+					Status:  job.StatePassed,
+					Browser: res.browser,
+					URL:     r.jobURL(res.job.ID),
+					Origin:  "sauce",
+					RDC:     res.job.IsRDC,
+				})
+			}
+			continue
+		}
+
 		if !res.skipped {
 			platform := res.job.BaseConfig.PlatformName
 			if res.job.BaseConfig.PlatformVersion != "" {
@@ -156,11 +350,9 @@ func (r *CloudRunner) collectResults(artifactCfg config.ArtifactDownload, result
 			}
 
 			r.FetchJUnitReports(&res, artifacts)
+			logRecoveredTests(res)
 
-			var url string
-			if res.job.ID != "" {
-				url = fmt.Sprintf("%s/tests/%s", r.Region.AppBaseURL(), res.job.ID)
-			}
+			url := r.jobURL(res.job.ID)
 			buildURL := r.getBuildURL(res.job.ID, res.job.IsRDC)
 			tr := report.TestResult{
 				Name:       res.name,
@@ -182,6 +374,19 @@ func (r *CloudRunner) collectResults(artifactCfg config.ArtifactDownload, result
 			for _, rep := range r.Reporters {
 				rep.Add(tr)
 			}
+		} else if res.job.Status == job.StateSkipped {
+			// Suites skipped due to a dependency are reported, since they were planned to run.
+			tr := report.TestResult{
+				Name:          res.name,
+				Status:        job.StateSkipped,
+				Browser:       res.browser,
+				Origin:        "sauce",
+				SkipReason:    res.err.Error(),
+				BudgetSkipped: res.budgetSkipped,
+			}
+			for _, rep := range r.Reporters {
+				rep.Add(tr)
+			}
 		}
 		r.logSuite(res)
 
@@ -190,23 +395,51 @@ func (r *CloudRunner) collectResults(artifactCfg config.ArtifactDownload, result
 		// * Timed out jobs will be requested to stop, but stopping a job
 		//   is either not possible (rdc) or async (vdc) so its actual status is not known now.
 		//   Skip reporting to be safe.
+		// * Jobs of a local backend are unknown to Insights.
 		isFinished := !r.Async && !res.job.TimedOut
-		if isFinished {
+		if isFinished && !r.Local {
 			r.reportSuiteToInsights(res)
 		}
 	}
 	close(done)
 
-	if !r.interrupted {
-		for _, rep := range r.Reporters {
-			rep.Render()
+	r.recordTimings(collected)
+
+	// Reporters are rendered even if the run was interrupted, so that partial results aren't lost.
+	for _, rep := range r.Reporters {
+		rep.Render()
+	}
+
+	if r.interrupted {
+		passed = false
+	}
+
+	if r.signaled && r.ManifestPath != "" {
+		manifest.Interrupted = true
+		if err := manifest.Write(r.ManifestPath); err != nil {
+			log.Error().Err(err).Msg("Failed to write the run manifest.")
+		} else {
+			log.Info().Str("manifest", r.ManifestPath).
+				Msgf("Run interrupted. Resume it with '--resume %s'.", r.ManifestPath)
 		}
 	}
 
 	return passed
 }
 
+// jobURL returns the URL of the job's details page, or an empty string if the job has none.
+func (r *CloudRunner) jobURL(jobID string) string {
+	if jobID == "" || r.Local {
+		return ""
+	}
+	return fmt.Sprintf("%s/tests/%s", r.Region.AppBaseURL(), jobID)
+}
+
 func (r *CloudRunner) getBuildURL(jobID string, isRDC bool) string {
+	if r.Local {
+		return ""
+	}
+
 	var buildSource build.Source
 	if !isRDC {
 		if r.Cache.VDCBuildURL != "" {
@@ -246,6 +479,10 @@ func (r *CloudRunner) runJob(opts job.StartOptions) (j job.Job, skipped bool, er
 	sigChan := r.registerInterruptOnSignal(id, opts.RealDevice, opts.DisplayName)
 	defer unregisterSignalCapture(sigChan)
 
+	if t := r.throttleFor(opts.RealDevice); t != nil {
+		go r.checkQueued(id, opts, t)
+	}
+
 	r.uploadSauceConfig(id, opts.RealDevice, opts.ConfigFilePath)
 	r.uploadCLIFlags(id, opts.RealDevice, opts.CLIFlags)
 
@@ -257,8 +494,10 @@ func (r *CloudRunner) runJob(opts job.StartOptions) (j job.Job, skipped bool, er
 		return j, true, err
 	}
 
-	jobDetailsPage := fmt.Sprintf("%s/tests/%s", r.Region.AppBaseURL(), id)
-	l := log.Info().Str("url", jobDetailsPage).Str("suite", opts.DisplayName).Str("platform", opts.PlatformName)
+	l := log.Info().Str("url", r.jobURL(id)).Str("suite", opts.DisplayName).Str("platform", opts.PlatformName)
+	if r.Local {
+		l.Str("container", id)
+	}
 
 	if opts.RealDevice {
 		l.Str("deviceName", opts.DeviceName).Str("platformVersion", opts.PlatformVersion).Str("deviceId", opts.DeviceID)
@@ -335,6 +574,8 @@ func (r *CloudRunner) runJobs(jobOpts chan job.StartOptions, results chan<- resu
 			results <- result{
 				name:     opts.DisplayName,
 				browser:  opts.BrowserName,
+				suite:    opts.SuiteName,
+				manifest: newManifestSuite(opts),
 				skipped:  true,
 				err:      nil,
 				attempts: opts.PrevAttempts,
@@ -345,21 +586,71 @@ func (r *CloudRunner) runJobs(jobOpts chan job.StartOptions, results chan<- resu
 		}
 
 		if opts.Attempt == 0 {
+			if prev, ok := r.Resume.passed(opts); ok {
+				log.Info().Str("suite", opts.DisplayName).Str("jobID", prev.JobID).
+					Msg("Skipping suite, since it passed in the resumed run.")
+				results <- result{
+					name:     opts.DisplayName,
+					browser:  opts.BrowserName,
+					suite:    opts.SuiteName,
+					manifest: newManifestSuite(opts),
+					job:      job.Job{ID: prev.JobID, IsRDC: prev.RDC, Status: job.StatePassed, Passed: true},
+					resumed:  true,
+					retries:  opts.Retries,
+					details:  details,
+				}
+				continue
+			}
 			opts.StartTime = start
 		}
 
+		// The budget applies to every launch, including retries, which are no longer attempted once it's exhausted.
+		if reason := r.budget.exhausted(opts.SuiteName); reason != "" {
+			res := result{
+				name:     opts.DisplayName,
+				browser:  opts.BrowserName,
+				suite:    opts.SuiteName,
+				manifest: newManifestSuite(opts),
+				err:      fmt.Errorf("run budget exhausted: %s", reason),
+				attempts: opts.PrevAttempts,
+				retries:  opts.Retries,
+				details:  details,
+			}
+			if opts.Attempt == 0 {
+				log.Warn().Str("suite", opts.DisplayName).Str("reason", reason).
+					Msg("Skipping suite, since the run budget is exhausted.")
+				res.job = job.Job{Status: job.StateSkipped}
+				res.skipped = true
+				res.budgetSkipped = true
+			} else {
+				log.Warn().Str("suite", opts.DisplayName).Str("reason", reason).
+					Msg("Not retrying suite, since the run budget is exhausted.")
+				last := opts.PrevAttempts[len(opts.PrevAttempts)-1]
+				res.job = job.Job{ID: last.ID, Status: job.StateFailed, IsRDC: opts.RealDevice}
+				res.startTime = opts.StartTime
+				res.endTime = time.Now()
+			}
+			results <- res
+			continue
+		}
+
+		r.attempts.Add(1)
+		t := r.throttleFor(opts.RealDevice)
+		t.acquire()
 		jobData, skipped, err := r.runJob(opts)
+		t.release()
 
 		if jobData.Passed {
 			opts.CurrentPassCount++
 		}
 
-		if opts.Attempt < opts.Retries && ((!jobData.Passed && !skipped) || (opts.CurrentPassCount < opts.PassThreshold)) {
+		if reason, ok := r.shouldRetry(opts, jobData, skipped); ok {
 			if !jobData.Passed {
-				log.Warn().Err(err).Msg("Suite errored.")
+				log.Warn().Err(err).Str("reason", string(reason)).Msg("Suite errored.")
 			}
 
 			opts.Attempt++
+			opts.RetriesByReason = countRetry(opts.RetriesByReason, string(reason))
 			opts.PrevAttempts = append(opts.PrevAttempts, report.Attempt{
 				ID:         jobData.ID,
 				Duration:   time.Since(start),
@@ -368,7 +659,15 @@ func (r *CloudRunner) runJobs(jobOpts chan job.StartOptions, results chan<- resu
 				Status:     jobData.Status,
 				TestSuites: junit.TestSuites{},
 			})
-			go r.Retrier.Retry(jobOpts, opts, jobData)
+
+			delay := r.RetryPolicy.Backoff(opts.Attempt)
+			go func(opts job.StartOptions, previous job.Job) {
+				if delay > 0 {
+					log.Info().Str("suite", opts.DisplayName).Str("delay", delay.String()).Msg("Delaying retry.")
+					time.Sleep(delay)
+				}
+				r.Retrier.Retry(jobOpts, opts, previous)
+			}(opts, jobData)
 			continue
 		}
 
@@ -392,6 +691,8 @@ func (r *CloudRunner) runJobs(jobOpts chan job.StartOptions, results chan<- resu
 		results <- result{
 			name:      opts.DisplayName,
 			browser:   opts.BrowserName,
+			suite:     opts.SuiteName,
+			manifest:  newManifestSuite(opts),
 			job:       jobData,
 			skipped:   skipped,
 			err:       err,
@@ -411,6 +712,60 @@ func (r *CloudRunner) runJobs(jobOpts chan job.StartOptions, results chan<- resu
 	}
 }
 
+// shouldRetry classifies the outcome of a job and reports whether the suite is to be retried according to the retry
+// policy. A suite is retried at most opts.Retries times overall, and every reason may limit its share of those
+// retries with a budget of its own. Suites that passed, but have yet to reach their pass threshold, are rerun within
+// the budget for failures.
+func (r *CloudRunner) shouldRetry(opts job.StartOptions, j job.Job, skipped bool) (config.RetryReason, bool) {
+	if skipped {
+		return "", false
+	}
+
+	var reason config.RetryReason
+	switch {
+	case j.Passed:
+		if opts.CurrentPassCount >= opts.PassThreshold {
+			return "", false
+		}
+		reason = config.RetryOnFailure
+	case j.TimedOut:
+		reason = config.RetryOnTimeout
+	case j.ID == "" || j.Status == job.StateError:
+		// The job either failed to start, couldn't be polled or errored, none of which is the fault of the tests.
+		reason = config.RetryOnError
+	default:
+		reason = config.RetryOnFailure
+	}
+
+	if !j.Passed && !r.RetryPolicy.RetriesOn(reason) {
+		return reason, false
+	}
+	if opts.Attempt >= opts.Retries {
+		return reason, false
+	}
+	if opts.RetriesByReason[string(reason)] >= r.RetryPolicy.Budget(reason, opts.Retries) {
+		return reason, false
+	}
+	if limit := r.RetryPolicy.MaxAttempts; limit > 0 && r.attempts.Load() >= int64(limit) {
+		log.Warn().Str("suite", opts.DisplayName).Int("maxAttempts", limit).
+			Msg("Reached the maximum number of attempts for this run. Not retrying suite.")
+		return reason, false
+	}
+
+	return reason, true
+}
+
+// countRetry returns a copy of retries, in which the count for the given reason is incremented. The map is copied,
+// since it's shared by all attempts of a suite.
+func countRetry(retries map[string]int, reason string) map[string]int {
+	counts := make(map[string]int, len(retries)+1)
+	for k, v := range retries {
+		counts[k] = v
+	}
+	counts[reason]++
+	return counts
+}
+
 // remoteArchiveProject archives the contents of the folder and uploads to remote storage.
 // It returns app uri as the uploaded project, otherApps as the collection of runner config and node_modules bundle.
 func (r *CloudRunner) remoteArchiveProject(project interface{}, folder string, sauceignoreFile string, dryRun bool) (app string, otherApps []string, err error) {
@@ -422,21 +777,11 @@ func (r *CloudRunner) remoteArchiveProject(project interface{}, folder string, s
 		defer os.RemoveAll(tempDir)
 	}
 
-	var files []string
-
-	contents, err := os.ReadDir(folder)
+	files, err := projectFiles(folder)
 	if err != nil {
 		return
 	}
 
-	for _, file := range contents {
-		// we never want mode_modules as part of the app payload
-		if file.Name() == "node_modules" {
-			continue
-		}
-		files = append(files, filepath.Join(folder, file.Name()))
-	}
-
 	archives := make(map[uploadType]string)
 
 	matcher, err := sauceignore.NewMatcherFromFile(sauceignoreFile)
@@ -444,13 +789,19 @@ func (r *CloudRunner) remoteArchiveProject(project interface{}, folder string, s
 		return
 	}
 
-	appZip, err := zip.ArchiveFiles("app", tempDir, folder, files, matcher)
-	if err != nil {
-		return
+	streams := make(map[uploadType]projectStream)
+	if dryRun {
+		var appZip string
+		appZip, err = zip.ArchiveFiles("app", tempDir, folder, files, matcher, r.Archive)
+		if err != nil {
+			return
+		}
+		archives[projectUpload] = appZip
+	} else {
+		streams[projectUpload] = projectStream{name: "app", sourceDir: folder, files: files, matcher: matcher}
 	}
-	archives[projectUpload] = appZip
 
-	modZip, err := zip.ArchiveNodeModules(tempDir, folder, matcher, r.NPMDependencies)
+	modZip, err := r.archiveNodeModules(tempDir, folder, matcher, sauceignoreFile, dryRun)
 	if err != nil {
 		return
 	}
@@ -464,13 +815,13 @@ func (r *CloudRunner) remoteArchiveProject(project interface{}, folder string, s
 	}
 	archives[runnerConfigUpload] = configZip
 
-	var uris = map[uploadType]string{}
-	for k, v := range archives {
-		uri, err := r.uploadProject(v, "", k, dryRun)
-		if err != nil {
-			return "", []string{}, err
-		}
-		uris[k] = uri
+	if err = r.listPayload(archives); err != nil {
+		return
+	}
+
+	uris, err := r.uploadArchives(archives, streams, dryRun)
+	if err != nil {
+		return "", []string{}, err
 	}
 
 	app = uris[projectUpload]
@@ -483,6 +834,137 @@ func (r *CloudRunner) remoteArchiveProject(project interface{}, folder string, s
 	return
 }
 
+// remoteArchiveSuiteApps archives the contents of the folder once for each of the given suite sauceignore files that
+// differs from the project's, and uploads them to remote storage, so that suites with a sauceignore file of their own
+// get a project archive of their own. Use appForSuite to look up the archive of a suite.
+func (r *CloudRunner) remoteArchiveSuiteApps(folder string, projectSauceignore string, suiteSauceignores []string, dryRun bool) error {
+	var sauceignoreFiles []string
+	for _, f := range suiteSauceignores {
+		if f != "" && f != projectSauceignore && !slices.Contains(sauceignoreFiles, f) {
+			sauceignoreFiles = append(sauceignoreFiles, f)
+		}
+	}
+	if len(sauceignoreFiles) == 0 {
+		return nil
+	}
+
+	tempDir, err := os.MkdirTemp(os.TempDir(), "saucectl-app-payload-")
+	if err != nil {
+		return err
+	}
+	if !dryRun {
+		defer os.RemoveAll(tempDir)
+	}
+
+	files, err := projectFiles(folder)
+	if err != nil {
+		return err
+	}
+
+	r.suiteApps = map[string]string{}
+	for i, sauceignoreFile := range sauceignoreFiles {
+		matcher, err := sauceignore.NewMatcherFromFile(sauceignoreFile)
+		if err != nil {
+			return err
+		}
+
+		name := fmt.Sprintf("app-%d", i)
+		archives := map[uploadType]string{}
+		streams := map[uploadType]projectStream{}
+		if dryRun {
+			appZip, err := zip.ArchiveFiles(name, tempDir, folder, files, matcher, r.Archive)
+			if err != nil {
+				return err
+			}
+			archives[projectUpload] = appZip
+		} else {
+			streams[projectUpload] = projectStream{name: name, sourceDir: folder, files: files, matcher: matcher}
+		}
+
+		if err := r.listPayload(archives); err != nil {
+			return err
+		}
+
+		uris, err := r.uploadArchives(archives, streams, dryRun)
+		if err != nil {
+			return fmt.Errorf("sauceignore %s: %w", sauceignoreFile, err)
+		}
+		r.suiteApps[sauceignoreFile] = uris[projectUpload]
+	}
+
+	return nil
+}
+
+// appForSuite returns the project archive for a suite with the given sauceignore file. Returns app, the archive that
+// has been created with the project's sauceignore file, if the suite doesn't have one of its own.
+func (r *CloudRunner) appForSuite(app string, sauceignoreFile string) string {
+	if suiteApp, ok := r.suiteApps[sauceignoreFile]; ok {
+		return suiteApp
+	}
+	return app
+}
+
+// projectFiles returns the top-level files and folders of a project that make up its archive.
+func projectFiles(folder string) ([]string, error) {
+	contents, err := os.ReadDir(folder)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, file := range contents {
+		// we never want mode_modules as part of the app payload
+		if file.Name() == "node_modules" {
+			continue
+		}
+		files = append(files, filepath.Join(folder, file.Name()))
+	}
+
+	return files, nil
+}
+
+// archiveNodeModules archives the node_modules folder of the project and returns the path of the archive, or a
+// storage reference if an identical archive has already been uploaded. Archives are cached by a key derived from the
+// lockfiles, both locally and in Sauce storage, so that unchanged dependencies are neither archived nor uploaded again.
+func (r *CloudRunner) archiveNodeModules(tempDir, folder string, matcher sauceignore.Matcher, sauceignoreFile string, dryRun bool) (string, error) {
+	if dryRun {
+		return zip.ArchiveNodeModules(tempDir, folder, matcher, r.NPMDependencies, r.Archive)
+	}
+
+	key, err := zip.NodeModulesCacheKey(folder, matcher, r.NPMDependencies, sauceignoreFile)
+	if err != nil {
+		log.Warn().Err(err).Msg("Unable to determine the node_modules cache key. Skipping cache.")
+	}
+	if key == "" {
+		return zip.ArchiveNodeModules(tempDir, folder, matcher, r.NPMDependencies, r.Archive)
+	}
+
+	name := zip.NodeModulesArchiveName(key, r.Archive.Format)
+	log.Info().Str("key", key).Msg("Checking if node_modules have already been uploaded previously")
+	if storageID, _ := r.findStoredFileByName(name); storageID != "" {
+		log.Info().Msgf("Skipping node_modules archiving, using storage:%s", storageID)
+		return fmt.Sprintf("storage:%s", storageID), nil
+	}
+
+	if cached, ok := zip.CachedNodeModules(key, r.Archive.Format); ok {
+		log.Info().Str("file", cached).Msg("Skipping node_modules archiving, using local cache.")
+		return cached, nil
+	}
+
+	modZip, err := zip.ArchiveNodeModules(tempDir, folder, matcher, r.NPMDependencies, r.Archive)
+	if err != nil || modZip == "" {
+		return modZip, err
+	}
+
+	cached, err := zip.CacheNodeModules(modZip, key, r.Archive.Format)
+	if err != nil {
+		log.Warn().Err(err).Msg("Unable to cache node_modules archive.")
+		return modZip, nil
+	}
+
+	return cached, nil
+}
+
 // remoteArchiveFiles archives the files to a remote storage.
 func (r *CloudRunner) remoteArchiveFiles(project interface{}, files []string, sauceignoreFile string, dryRun bool) (string, error) {
 	tempDir, err := os.MkdirTemp(os.TempDir(), "saucectl-app-payload-")
@@ -500,11 +982,16 @@ func (r *CloudRunner) remoteArchiveFiles(project interface{}, files []string, sa
 		return "", err
 	}
 
-	zipName, err := zip.ArchiveFiles("app", tempDir, ".", files, matcher)
-	if err != nil {
-		return "", err
+	streams := make(map[uploadType]projectStream)
+	if dryRun {
+		zipName, err := zip.ArchiveFiles("app", tempDir, ".", files, matcher, r.Archive)
+		if err != nil {
+			return "", err
+		}
+		archives[projectUpload] = zipName
+	} else {
+		streams[projectUpload] = projectStream{name: "app", sourceDir: ".", files: files, matcher: matcher}
 	}
-	archives[projectUpload] = zipName
 
 	configZip, err := zip.ArchiveRunnerConfig(project, tempDir)
 	if err != nil {
@@ -512,14 +999,18 @@ func (r *CloudRunner) remoteArchiveFiles(project interface{}, files []string, sa
 	}
 	archives[runnerConfigUpload] = configZip
 
-	var uris []string
-	for k, v := range archives {
-		uri, err := r.uploadProject(v, "", k, dryRun)
-		if err != nil {
-			return "", err
-		}
-		uris = append(uris, uri)
+	if err := r.listPayload(archives); err != nil {
+		return "", err
+	}
 
+	uploaded, err := r.uploadArchives(archives, streams, dryRun)
+	if err != nil {
+		return "", err
+	}
+
+	var uris []string
+	for _, k := range []uploadType{projectUpload, runnerConfigUpload} {
+		uris = append(uris, uploaded[k])
 	}
 
 	return strings.Join(uris, ","), nil
@@ -573,6 +1064,30 @@ func (r *CloudRunner) FetchJUnitReports(res *result, artifacts []report.Artifact
 	}
 }
 
+// logRecoveredTests logs the tests that failed in an earlier attempt of the suite, but passed on retry.
+// Requires the JUnit reports of the attempts to have been fetched.
+func logRecoveredTests(res result) {
+	if len(res.attempts) < 2 {
+		return
+	}
+
+	var reports []junit.TestSuites
+	for _, a := range res.attempts {
+		reports = append(reports, a.TestSuites)
+	}
+
+	recovered := junit.RecoveredTests(reports...)
+	if len(recovered) == 0 {
+		return
+	}
+
+	var names []string
+	for _, tc := range recovered {
+		names = append(names, fmt.Sprintf("%s.%s", tc.ClassName, tc.Name))
+	}
+	log.Info().Str("suite", res.name).Strs("tests", names).Msg("Tests passed on retry.")
+}
+
 type uploadType string
 
 var (
@@ -597,7 +1112,97 @@ func (r *CloudRunner) uploadProjects(filenames []string, pType uploadType, dryRu
 	return IDs, nil
 }
 
+// listPayload prints the contents of the given archives, if enabled by PayloadListing.
+func (r *CloudRunner) listPayload(archives map[uploadType]string) error {
+	if !r.PayloadListing.Enabled {
+		return nil
+	}
+
+	names := map[uploadType]string{
+		projectUpload:      "app",
+		nodeModulesUpload:  "node_modules",
+		runnerConfigUpload: "runner config",
+	}
+
+	var inspected []payload.Archive
+	for _, k := range []uploadType{projectUpload, nodeModulesUpload, runnerConfigUpload} {
+		filename, ok := archives[k]
+		if !ok || apps.IsStorageReference(filename) {
+			continue
+		}
+		a, err := payload.Inspect(names[k], filename, r.PayloadListing.Top)
+		if err != nil {
+			return fmt.Errorf("failed to inspect %s archive: %w", k, err)
+		}
+		inspected = append(inspected, a)
+	}
+
+	payload.Render(os.Stdout, inspected)
+
+	if r.PayloadListing.JSONFile != "" {
+		if err := payload.WriteJSON(r.PayloadListing.JSONFile, inspected); err != nil {
+			return fmt.Errorf("failed to write payload listing: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// uploadArchives uploads the given archives and project streams concurrently and returns their storage references by
+// upload type. A single progress indicator is shown for all uploads.
+func (r *CloudRunner) uploadArchives(archives map[uploadType]string, streams map[uploadType]projectStream, dryRun bool) (map[uploadType]string, error) {
+	type uploadResult struct {
+		pType uploadType
+		uri   string
+		err   error
+	}
+
+	total := len(archives) + len(streams)
+	results := make(chan uploadResult, total)
+	for k, v := range archives {
+		go func(pType uploadType, filename string) {
+			// Archives are generated by saucectl, so mark them as such, which allows them to be pruned later on.
+			uri, err := r.upload(filename, storage.GeneratedDescription, pType, dryRun, false)
+			results <- uploadResult{pType: pType, uri: uri, err: err}
+		}(k, v)
+	}
+	for k, v := range streams {
+		go func(pType uploadType, s projectStream) {
+			uri, err := r.uploadStream(s, storage.GeneratedDescription, pType)
+			results <- uploadResult{pType: pType, uri: uri, err: err}
+		}(k, v)
+	}
+
+	if !dryRun {
+		progress.Show("Uploading %d archives", total)
+		defer progress.Stop()
+	}
+
+	uris := map[uploadType]string{}
+	var errs []error
+	for i := 0; i < total; i++ {
+		res := <-results
+		if res.err != nil {
+			errs = append(errs, fmt.Errorf("%s upload: %w", res.pType, res.err))
+			continue
+		}
+		uris[res.pType] = res.uri
+		if !dryRun {
+			progress.Show("Uploading %d archives (%d done)", total, i+1)
+		}
+	}
+
+	return uris, errors.Join(errs...)
+}
+
 func (r *CloudRunner) uploadProject(filename, description string, pType uploadType, dryRun bool) (string, error) {
+	return r.upload(filename, description, pType, dryRun, true)
+}
+
+// upload uploads the file to the Sauce Labs app storage and returns its storage reference. Files that have been
+// uploaded before aren't uploaded again. Failed uploads are retried by the storage client. If showProgress is false,
+// the caller is responsible for displaying progress.
+func (r *CloudRunner) upload(filename, description string, pType uploadType, dryRun bool, showProgress bool) (string, error) {
 	if dryRun {
 		log.Info().Str("file", filename).Msgf("Skipping upload in dry run.")
 		return "", nil
@@ -610,9 +1215,13 @@ func (r *CloudRunner) uploadProject(filename, description string, pType uploadTy
 	if apps.IsRemote(filename) {
 		log.Info().Msgf("Downloading from remote: %s", filename)
 
-		progress.Show("Downloading %s", filename)
+		if showProgress {
+			progress.Show("Downloading %s", filename)
+		}
 		dest, err := r.download(filename)
-		progress.Stop()
+		if showProgress {
+			progress.Stop()
+		}
 		if err != nil {
 			return "", fmt.Errorf("unable to download app from %s: %w", filename, err)
 		}
@@ -641,10 +1250,14 @@ func (r *CloudRunner) uploadProject(filename, description string, pType uploadTy
 	}
 	defer file.Close()
 
-	progress.Show("Uploading %s %s", pType, filename)
+	if showProgress {
+		progress.Show("Uploading %s %s", pType, filename)
+	}
 	start := time.Now()
 	resp, err := r.ProjectUploader.UploadStream(filepath.Base(filename), description, file)
-	progress.Stop()
+	if showProgress {
+		progress.Stop()
+	}
 	if err != nil {
 		return "", err
 	}
@@ -663,6 +1276,12 @@ func (r *CloudRunner) isFileStored(filename string) (storageID string, err error
 
 	log.Info().Msgf("Checksum: %s", hash)
 
+	return r.findStoredFile(hash)
+}
+
+// findStoredFile looks up the file with the given checksum in the Sauce Labs app storage.
+// Returns an empty string if no file was found.
+func (r *CloudRunner) findStoredFile(hash string) (storageID string, err error) {
 	l, err := r.ProjectUploader.List(storage.ListOptions{
 		SHA256:     hash,
 		MaxResults: 1,
@@ -677,6 +1296,128 @@ func (r *CloudRunner) isFileStored(filename string) (storageID string, err error
 	return l.Items[0].ID, nil
 }
 
+// projectStream describes a project archive that's created while it's being uploaded, so that it never has to be
+// written to disk.
+type projectStream struct {
+	// name is the name of the archive without its extension.
+	name      string
+	sourceDir string
+	files     []string
+	matcher   sauceignore.Matcher
+}
+
+// uploadStream archives the files of the project stream and uploads the archive to the Sauce Labs app storage.
+// Archives are reproducible, so the archive is created once upfront without being kept, in order to compute its
+// checksum and size. That way, archives that have been uploaded before aren't uploaded again. Otherwise, the archive
+// is created once more for every upload attempt, while it's being uploaded. An attempt fails as soon as that archive
+// differs from the one that was checked, e.g. because project files changed in the meantime.
+func (r *CloudRunner) uploadStream(s projectStream, description string, pType uploadType) (string, error) {
+	start := time.Now()
+	h := sha256.New()
+	var size byteCounter
+	fileCount, longestPathLength, err := zip.WriteFiles(io.MultiWriter(h, &size), s.sourceDir, s.files, s.matcher, r.Archive)
+	if err != nil {
+		return "", err
+	}
+	hash := hex.EncodeToString(h.Sum(nil))
+
+	log.Info().
+		Dur("durationMs", time.Since(start)).
+		Int64("size", int64(size)).
+		Int("fileCount", fileCount).
+		Int("longestPathLength", longestPathLength).
+		Str("checksum", hash).
+		Msg("Archive created.")
+	zip.WarnLimits(fileCount, longestPathLength)
+
+	log.Info().Msgf("Checking if %s has already been uploaded previously", pType)
+	if storageID, _ := r.findStoredFile(hash); storageID != "" {
+		log.Info().Msgf("Skipping upload, using storage:%s", storageID)
+		return fmt.Sprintf("storage:%s", storageID), nil
+	}
+
+	open := func() (io.ReadCloser, error) {
+		pr, pw := io.Pipe()
+		go func() {
+			w := &checkedWriter{w: pw, h: sha256.New(), size: int64(size)}
+			_, _, err := zip.WriteFiles(w, s.sourceDir, s.files, s.matcher, r.Archive)
+			if err == nil {
+				err = w.check(hash)
+			}
+			_ = pw.CloseWithError(err)
+		}()
+		return pr, nil
+	}
+
+	start = time.Now()
+	resp, err := r.ProjectUploader.UploadStreamFunc(s.name+r.Archive.Format.Extension(), description, int64(size), open)
+	if err != nil {
+		return "", err
+	}
+	if resp.SHA256 != "" && resp.SHA256 != hash {
+		return "", fmt.Errorf("archive got corrupted while being uploaded; expected checksum %s, got %s", hash, resp.SHA256)
+	}
+	log.Info().Dur("durationMs", time.Since(start)).Str("storageId", resp.ID).
+		Msgf("%s uploaded.", cases.Title(language.English).String(string(pType)))
+
+	return fmt.Sprintf("storage:%s", resp.ID), nil
+}
+
+// errProjectChanged is returned when a project archive differs from the one that was checked before its upload.
+var errProjectChanged = errors.New("project files changed while being uploaded")
+
+// checkedWriter passes writes through to w, as long as they don't exceed the expected size, and computes their
+// checksum along the way.
+type checkedWriter struct {
+	w       io.Writer
+	h       hash.Hash
+	size    int64
+	written int64
+}
+
+func (c *checkedWriter) Write(p []byte) (int, error) {
+	if c.written+int64(len(p)) > c.size {
+		return 0, errProjectChanged
+	}
+	n, err := c.w.Write(p)
+	c.h.Write(p[:n])
+	c.written += int64(n)
+	return n, err
+}
+
+// check returns an error if the written data doesn't have the expected size and checksum.
+func (c *checkedWriter) check(checksum string) error {
+	if c.written != c.size || hex.EncodeToString(c.h.Sum(nil)) != checksum {
+		return errProjectChanged
+	}
+	return nil
+}
+
+// byteCounter counts the bytes that are written to it.
+type byteCounter int64
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	*c += byteCounter(len(p))
+	return len(p), nil
+}
+
+// findStoredFileByName looks up the file with the given name in the Sauce Labs app storage.
+// Returns an empty string if no file was found.
+func (r *CloudRunner) findStoredFileByName(name string) (storageID string, err error) {
+	l, err := r.ProjectUploader.List(storage.ListOptions{
+		Name:       name,
+		MaxResults: 1,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(l.Items) == 0 || !strings.EqualFold(l.Items[0].Name, name) {
+		return "", nil
+	}
+
+	return l.Items[0].ID, nil
+}
+
 // logSuite display the result of a suite
 func (r *CloudRunner) logSuite(res result) {
 	// Job isn't done, hence nothing more to log about it.
@@ -693,7 +1434,7 @@ func (r *CloudRunner) logSuite(res result) {
 		return
 	}
 
-	jobDetailsPage := fmt.Sprintf("%s/tests/%s", r.Region.AppBaseURL(), res.job.ID)
+	jobDetailsPage := r.jobURL(res.job.ID)
 
 	if res.job.TimedOut {
 		log.Error().Str("suite", res.name).Str("url", jobDetailsPage).Msg("Suite timed out.")
@@ -798,10 +1539,10 @@ func (r *CloudRunner) stopSuiteExecution(jobID string, realDevice bool, suiteNam
 	}
 }
 
-// registerInterruptOnSignal stops execution on Sauce Cloud when a SIGINT is captured.
+// registerInterruptOnSignal stops execution on Sauce Cloud when a SIGINT or SIGTERM is captured.
 func (r *CloudRunner) registerInterruptOnSignal(jobID string, realDevice bool, suiteName string) chan os.Signal {
 	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt)
+	signal.Notify(sigChan, interruptSignals...)
 
 	go func(c <-chan os.Signal, jobID, suiteName string) {
 		sig := <-c
@@ -813,22 +1554,32 @@ func (r *CloudRunner) registerInterruptOnSignal(jobID string, realDevice bool, s
 	return sigChan
 }
 
-// registerSkipSuitesOnSignal prevent new suites from being executed when a SIGINT is captured.
+// registerSkipSuitesOnSignal prevent new suites from being executed when a SIGINT or SIGTERM is captured. Suites in
+// progress are waited on for the grace period. A second signal exits right away.
 func (r *CloudRunner) registerSkipSuitesOnSignal() chan os.Signal {
 	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, os.Interrupt)
+	signal.Notify(sigChan, interruptSignals...)
+
+	expired := make(chan struct{})
+	r.graceExpired = expired
 
 	go func(c <-chan os.Signal, cr *CloudRunner) {
+		signaled := false
 		for {
 			sig := <-c
 			if sig == nil {
 				return
 			}
-			if cr.interrupted {
+			if signaled {
 				os.Exit(1)
 			}
+			signaled = true
 			println("\nStopping run. Waiting for all in progress tests to be stopped... (press Ctrl-c again to exit without waiting)\n")
+			cr.signaled = true
 			cr.interrupted = true
+			if cr.GracePeriod > 0 {
+				time.AfterFunc(cr.GracePeriod, func() { close(expired) })
+			}
 		}
 	}(sigChan, r)
 	return sigChan
@@ -857,6 +1608,15 @@ func (r *CloudRunner) uploadSauceConfig(jobID string, realDevice bool, cfgFile s
 		log.Warn().Msgf("failed to read configuration: %v", err)
 		return
 	}
+	if r.Redactor != nil {
+		content, err = r.Redactor.YAML(content)
+		if err != nil {
+			// Better not attach the configuration at all, than risk leaking secrets.
+			log.Warn().Msgf("failed to redact configuration: %v", err)
+			return
+		}
+	}
+	content = secret.MaskBytes(content)
 	if err := r.JobService.UploadAsset(jobID, realDevice, filepath.Base(cfgFile), "text/plain", content); err != nil {
 		log.Warn().Msgf("failed to attach configuration: %v", err)
 	}
@@ -864,11 +1624,15 @@ func (r *CloudRunner) uploadSauceConfig(jobID string, realDevice bool, cfgFile s
 
 // uploadCLIFlags adds commandline parameters as an asset.
 func (r *CloudRunner) uploadCLIFlags(jobID string, realDevice bool, content interface{}) {
+	if flags, ok := content.(map[string]interface{}); ok && r.Redactor != nil {
+		content = r.Redactor.Map(flags)
+	}
 	encoded, err := json.Marshal(content)
 	if err != nil {
 		log.Warn().Msgf("Failed to encode CLI flags: %v", err)
 		return
 	}
+	encoded = secret.MaskBytes(encoded)
 	if err := r.JobService.UploadAsset(jobID, realDevice, "flags.json", "text/plain", encoded); err != nil {
 		log.Warn().Msgf("Failed to report CLI flags: %v", err)
 	}
@@ -922,7 +1686,16 @@ func (r *CloudRunner) getAvailableVersionsMessage(frameworkName string) string {
 	return m
 }
 
+// getHistory returns the job history that the launch order is based on.
 func (r *CloudRunner) getHistory(launchOrder config.LaunchOrder) (insights.JobHistory, error) {
+	if launchOrder == config.LaunchOrderDuration {
+		return r.durationHistory()
+	}
+	return r.insightsHistory(launchOrder)
+}
+
+// insightsHistory returns the job history from Insights.
+func (r *CloudRunner) insightsHistory(launchOrder config.LaunchOrder) (insights.JobHistory, error) {
 	user, err := r.UserService.User(context.Background())
 	if err != nil {
 		return insights.JobHistory{}, err
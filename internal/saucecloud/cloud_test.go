@@ -1,23 +1,36 @@
 package saucecloud
 
 import (
+	"archive/tar"
+	stdzip "archive/zip"
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
+	"sync"
 	"syscall"
 	"testing"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
+	"github.com/saucelabs/saucectl/internal/archive"
+	"github.com/saucelabs/saucectl/internal/config"
 	"github.com/saucelabs/saucectl/internal/job"
 	"github.com/saucelabs/saucectl/internal/junit"
 	"github.com/saucelabs/saucectl/internal/mocks"
+	"github.com/saucelabs/saucectl/internal/redact"
 	"github.com/saucelabs/saucectl/internal/saucecloud/retry"
 	"github.com/saucelabs/saucectl/internal/saucecloud/zip"
 	"github.com/saucelabs/saucectl/internal/sauceignore"
 	"github.com/saucelabs/saucectl/internal/saucereport"
+	"github.com/saucelabs/saucectl/internal/storage"
 	"github.com/stretchr/testify/assert"
 	"gotest.tools/v3/fs"
 )
@@ -41,6 +54,7 @@ func TestSignalDetection(t *testing.T) {
 			return
 		default:
 			if r.interrupted {
+				assert.True(t, r.signaled)
 				return
 			}
 			time.Sleep(1 * time.Nanosecond) // allow context switch
@@ -239,6 +253,136 @@ func TestRunJobRetries(t *testing.T) {
 	}
 }
 
+func TestRunJobRetries_Policy(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   config.RetryPolicy
+		retries  int
+		startErr error
+		job      job.Job
+		// jobs are the outcomes of consecutive attempts, which take precedence over job.
+		jobs         []job.Job
+		wantAttempts int
+	}{
+		{
+			name:         "failures use retries",
+			retries:      2,
+			job:          job.Job{ID: "1", Status: job.StateFailed},
+			wantAttempts: 3,
+		},
+		{
+			name:         "failures are not retried",
+			policy:       config.RetryPolicy{RetryOn: []config.RetryReason{config.RetryOnError}},
+			retries:      2,
+			job:          job.Job{ID: "1", Status: job.StateFailed},
+			wantAttempts: 1,
+		},
+		{
+			name:         "budgets are capped by retries",
+			policy:       config.RetryPolicy{Budgets: map[config.RetryReason]int{config.RetryOnError: 3}},
+			retries:      1,
+			startErr:     errors.New("no capacity"),
+			wantAttempts: 2,
+		},
+		{
+			name:    "retries are shared by reasons",
+			retries: 1,
+			jobs: []job.Job{
+				{ID: "1", Status: job.StateError},
+				{ID: "1", Status: job.StateFailed},
+				{ID: "1", Status: job.StateFailed},
+			},
+			wantAttempts: 2,
+		},
+		{
+			name:    "budgets limit reasons within retries",
+			policy:  config.RetryPolicy{Budgets: map[config.RetryReason]int{config.RetryOnError: 1}},
+			retries: 3,
+			jobs: []job.Job{
+				{ID: "1", Status: job.StateError},
+				{ID: "1", Status: job.StateFailed},
+				{ID: "1", Status: job.StateError},
+				{ID: "1", Status: job.StateFailed},
+			},
+			wantAttempts: 3,
+		},
+		{
+			name:         "error state",
+			policy:       config.RetryPolicy{RetryOn: []config.RetryReason{config.RetryOnFailure}},
+			retries:      2,
+			job:          job.Job{ID: "1", Status: job.StateError},
+			wantAttempts: 1,
+		},
+		{
+			name:         "timeouts",
+			policy:       config.RetryPolicy{Budgets: map[config.RetryReason]int{config.RetryOnTimeout: 1}},
+			retries:      3,
+			job:          job.Job{ID: "1", TimedOut: true},
+			wantAttempts: 2,
+		},
+		{
+			name:         "max attempts",
+			policy:       config.RetryPolicy{MaxAttempts: 2},
+			retries:      5,
+			job:          job.Job{ID: "1", Status: job.StateFailed},
+			wantAttempts: 2,
+		},
+		{
+			name:         "backoff",
+			policy:       config.RetryPolicy{Delay: time.Millisecond, Multiplier: 2},
+			retries:      2,
+			job:          job.Job{ID: "1", Status: job.StateFailed},
+			wantAttempts: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var polls int
+			r := CloudRunner{
+				Retrier:     &retry.BasicRetrier{},
+				RetryPolicy: tt.policy,
+				JobService: JobService{
+					VDCStarter: &mocks.FakeJobStarter{
+						StartJobFn: func(ctx context.Context, opts job.StartOptions) (jobID string, isRDC bool, err error) {
+							return "1", false, tt.startErr
+						},
+					},
+					VDCReader: &mocks.FakeJobReader{
+						PollJobFn: func(ctx context.Context, id string, interval time.Duration, timeout time.Duration) (job.Job, error) {
+							polls++
+							if len(tt.jobs) > 0 {
+								return tt.jobs[polls-1], nil
+							}
+							return tt.job, nil
+						},
+					},
+					VDCStopper: &mocks.FakeJobStopper{
+						StopJobFn: func(ctx context.Context, jobID string) (job.Job, error) {
+							return job.Job{ID: jobID}, nil
+						},
+					},
+					VDCWriter: &mocks.FakeJobWriter{UploadAssetFn: func(jobID string, fileName string, contentType string, content []byte) error {
+						return nil
+					}},
+				},
+			}
+
+			opts := make(chan job.StartOptions, 1)
+			results := make(chan result)
+
+			go r.runJobs(opts, results)
+			opts <- job.StartOptions{
+				DisplayName: "retry job",
+				Retries:     tt.retries,
+			}
+			res := <-results
+			close(opts)
+			close(results)
+			assert.Equal(t, tt.wantAttempts, len(res.attempts))
+		})
+	}
+}
+
 func TestRunJobTimeoutRDC(t *testing.T) {
 	r := CloudRunner{
 		JobService: JobService{
@@ -411,7 +555,7 @@ func TestCloudRunner_archiveNodeModules(t *testing.T) {
 			r := &CloudRunner{
 				NPMDependencies: tt.fields.NPMDependencies,
 			}
-			got, err := zip.ArchiveNodeModules(tt.args.tempDir, tt.args.rootDir, tt.args.matcher, r.NPMDependencies)
+			got, err := zip.ArchiveNodeModules(tt.args.tempDir, tt.args.rootDir, tt.args.matcher, r.NPMDependencies, r.Archive)
 			if !tt.wantErr(t, err, fmt.Sprintf("archiveNodeModules(%v, %v, %v)", tt.args.tempDir, tt.args.rootDir, tt.args.matcher)) {
 				return
 			}
@@ -420,6 +564,272 @@ func TestCloudRunner_archiveNodeModules(t *testing.T) {
 	}
 }
 
+func TestCloudRunner_archiveNodeModules_Cache(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", os.Getenv("HOME"))
+
+	projectDir := fs.NewDir(t, "project",
+		fs.WithFile("package-lock.json", `{"lockfileVersion": 3}`),
+		fs.WithDir("node_modules",
+			fs.WithDir("mod1",
+				fs.WithFile("package.json", "{}"),
+			),
+		),
+	)
+	defer projectDir.Remove()
+
+	matcher := sauceignore.NewMatcher([]sauceignore.Pattern{})
+	key, err := zip.NodeModulesCacheKey(projectDir.Path(), matcher, []string{"mod1"}, "")
+	if err != nil || key == "" {
+		t.Fatalf("failed to determine cache key: %q, %v", key, err)
+	}
+	cachedName := filepath.Join(zip.CacheDir(), zip.NodeModulesArchiveName(key, archive.FormatZip))
+
+	var stored []storage.Item
+	r := &CloudRunner{
+		NPMDependencies: []string{"mod1"},
+		ProjectUploader: &mocks.FakeProjectUploader{
+			ListFn: func(opts storage.ListOptions) (storage.List, error) {
+				return storage.List{Items: stored}, nil
+			},
+		},
+	}
+
+	// Nothing is cached yet, so node_modules is archived into the local cache.
+	got, err := r.archiveNodeModules(t.TempDir(), projectDir.Path(), matcher, "", false)
+	assert.NoError(t, err)
+	assert.Equal(t, cachedName, got)
+	assert.FileExists(t, cachedName)
+
+	// The locally cached archive is reused.
+	got, err = r.archiveNodeModules(t.TempDir(), projectDir.Path(), matcher, "", false)
+	assert.NoError(t, err)
+	assert.Equal(t, cachedName, got)
+
+	// An archive that has already been uploaded takes precedence.
+	stored = []storage.Item{{ID: "stored-id", Name: zip.NodeModulesArchiveName(key, archive.FormatZip)}}
+	got, err = r.archiveNodeModules(t.TempDir(), projectDir.Path(), matcher, "", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "storage:stored-id", got)
+}
+
+func TestCloudRunner_uploadArchives(t *testing.T) {
+	dir := fs.NewDir(t, "archives",
+		fs.WithFile("app.zip", "app"),
+		fs.WithFile("node_modules.zip", "node_modules"),
+		fs.WithFile("config.zip", "config"),
+		fs.WithFile("broken.zip", "broken"),
+	)
+	defer dir.Remove()
+
+	// Every upload waits for all others to start, which only succeeds if they run concurrently.
+	var started sync.WaitGroup
+	started.Add(3)
+	r := &CloudRunner{
+		ProjectUploader: &mocks.FakeProjectUploader{
+			UploadStreamFn: func(filename, description string, reader io.Reader) (storage.Item, error) {
+				started.Done()
+				done := make(chan struct{})
+				go func() {
+					started.Wait()
+					close(done)
+				}()
+				select {
+				case <-done:
+				case <-time.After(5 * time.Second):
+					return storage.Item{}, errors.New("uploads are not concurrent")
+				}
+				return storage.Item{ID: strings.TrimSuffix(filename, ".zip")}, nil
+			},
+		},
+	}
+
+	got, err := r.uploadArchives(map[uploadType]string{
+		projectUpload:      dir.Join("app.zip"),
+		nodeModulesUpload:  dir.Join("node_modules.zip"),
+		runnerConfigUpload: dir.Join("config.zip"),
+	}, nil, false)
+	assert.NoError(t, err)
+	assert.Equal(t, map[uploadType]string{
+		projectUpload:      "storage:app",
+		nodeModulesUpload:  "storage:node_modules",
+		runnerConfigUpload: "storage:config",
+	}, got)
+
+	r.ProjectUploader = &mocks.FakeProjectUploader{
+		UploadStreamFn: func(filename, description string, reader io.Reader) (storage.Item, error) {
+			if filename == "broken.zip" {
+				return storage.Item{}, errors.New("connection reset")
+			}
+			return storage.Item{ID: "app"}, nil
+		},
+	}
+	_, err = r.uploadArchives(map[uploadType]string{
+		projectUpload:      dir.Join("app.zip"),
+		runnerConfigUpload: dir.Join("broken.zip"),
+	}, nil, false)
+	assert.EqualError(t, err, "runner config upload: connection reset")
+}
+
+func TestCloudRunner_remoteArchiveSuiteApps(t *testing.T) {
+	dir := fs.NewDir(t, "project",
+		fs.WithDir("e2e", fs.WithFile("login.spec.js", "login")),
+		fs.WithDir("videos", fs.WithFile("login.mp4", "video")),
+		fs.WithFile(".sauceignore", "videos/\n"),
+		fs.WithFile("slim.sauceignore", "videos/\ne2e/\n"),
+	)
+	defer dir.Remove()
+
+	uploaded := map[string][]string{}
+	var mu sync.Mutex
+	r := &CloudRunner{
+		ProjectUploader: &mocks.FakeProjectUploader{
+			UploadStreamFn: func(filename, description string, reader io.Reader) (storage.Item, error) {
+				b, err := io.ReadAll(reader)
+				if err != nil {
+					return storage.Item{}, err
+				}
+				zr, err := stdzip.NewReader(bytes.NewReader(b), int64(len(b)))
+				if err != nil {
+					return storage.Item{}, err
+				}
+				var names []string
+				for _, f := range zr.File {
+					names = append(names, f.Name)
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				uploaded[filename] = names
+				return storage.Item{ID: strings.TrimSuffix(filename, ".zip")}, nil
+			},
+		},
+	}
+
+	projectIgnore := dir.Join(".sauceignore")
+	slimIgnore := dir.Join("slim.sauceignore")
+	err := r.remoteArchiveSuiteApps(dir.Path(), projectIgnore, []string{"", projectIgnore, slimIgnore, slimIgnore}, false)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "storage:app-0", r.appForSuite("storage:app", slimIgnore))
+	assert.Equal(t, "storage:app", r.appForSuite("storage:app", projectIgnore))
+	assert.Equal(t, "storage:app", r.appForSuite("storage:app", ""))
+	assert.Equal(t, map[string][]string{
+		"app-0.zip": {".sauceignore", "slim.sauceignore"},
+	}, uploaded)
+}
+
+func TestCloudRunner_uploadStream(t *testing.T) {
+	dir := fs.NewDir(t, "project",
+		fs.WithDir("e2e", fs.WithFile("login.spec.js", "login")),
+		fs.WithFile("package.json", "{}"),
+	)
+	defer dir.Remove()
+
+	s := projectStream{
+		name:      "app",
+		sourceDir: dir.Path(),
+		files:     []string{dir.Join("package.json"), dir.Join("e2e")},
+		matcher:   sauceignore.NewMatcher([]sauceignore.Pattern{}),
+	}
+
+	var stored []storage.Item
+	var uploads int
+	corrupt := false
+	r := &CloudRunner{
+		Archive: archive.Options{Format: archive.FormatTarZst},
+		ProjectUploader: &mocks.FakeProjectUploader{
+			ListFn: func(opts storage.ListOptions) (storage.List, error) {
+				var items []storage.Item
+				for _, item := range stored {
+					if item.SHA256 == opts.SHA256 {
+						items = append(items, item)
+					}
+				}
+				return storage.List{Items: items}, nil
+			},
+			UploadStreamFn: func(filename, description string, reader io.Reader) (storage.Item, error) {
+				uploads++
+				h := sha256.New()
+				zr, err := zstd.NewReader(io.TeeReader(reader, h))
+				if err != nil {
+					return storage.Item{}, err
+				}
+				defer zr.Close()
+
+				var names []string
+				tr := tar.NewReader(zr)
+				for {
+					header, err := tr.Next()
+					if err == io.EOF {
+						break
+					}
+					if err != nil {
+						return storage.Item{}, err
+					}
+					names = append(names, header.Name)
+				}
+				assert.Equal(t, []string{"e2e/", "e2e/login.spec.js", "package.json"}, names)
+
+				// Drain the remainder of the stream, so that the checksum covers all of it.
+				_, _ = io.Copy(io.Discard, reader)
+				item := storage.Item{ID: "app-id", Name: filename, SHA256: hex.EncodeToString(h.Sum(nil))}
+				if corrupt {
+					item.SHA256 = "corrupt"
+				}
+				stored = append(stored, item)
+				return item, nil
+			},
+		},
+	}
+
+	uri, err := r.uploadStream(s, "", projectUpload)
+	assert.NoError(t, err)
+	assert.Equal(t, "storage:app-id", uri)
+	assert.Equal(t, "app.tar.zst", stored[0].Name)
+
+	// An identical archive isn't uploaded again.
+	uri, err = r.uploadStream(s, "", projectUpload)
+	assert.NoError(t, err)
+	assert.Equal(t, "storage:app-id", uri)
+	assert.Equal(t, 1, uploads)
+
+	// The checksum computed by the storage must match the one of the archive that was sent.
+	stored = nil
+	corrupt = true
+	_, err = r.uploadStream(s, "", projectUpload)
+	assert.ErrorContains(t, err, "archive got corrupted while being uploaded")
+	assert.Equal(t, 2, uploads)
+}
+
+func TestCheckedWriter(t *testing.T) {
+	checksum := func(b string) string {
+		h := sha256.Sum256([]byte(b))
+		return hex.EncodeToString(h[:])
+	}
+
+	var buf bytes.Buffer
+	w := &checkedWriter{w: &buf, h: sha256.New(), size: 6}
+	_, err := io.WriteString(w, "abc")
+	assert.NoError(t, err)
+	_, err = io.WriteString(w, "def")
+	assert.NoError(t, err)
+	assert.NoError(t, w.check(checksum("abcdef")))
+	assert.Equal(t, "abcdef", buf.String())
+
+	// The project grew.
+	w = &checkedWriter{w: io.Discard, h: sha256.New(), size: 6}
+	_, err = io.WriteString(w, "abcdefg")
+	assert.ErrorIs(t, err, errProjectChanged)
+
+	// The project shrank, or changed without changing its size.
+	w = &checkedWriter{w: io.Discard, h: sha256.New(), size: 6}
+	_, _ = io.WriteString(w, "abc")
+	assert.ErrorIs(t, w.check(checksum("abc")), errProjectChanged)
+	w = &checkedWriter{w: io.Discard, h: sha256.New(), size: 6}
+	_, _ = io.WriteString(w, "abcxyz")
+	assert.ErrorIs(t, w.check(checksum("abcdef")), errProjectChanged)
+}
+
 func Test_arrayContains(t *testing.T) {
 	type args struct {
 		list []string
@@ -688,3 +1098,33 @@ func TestCloudRunner_loadJUnitReport(t *testing.T) {
 		})
 	}
 }
+
+func TestCloudRunner_uploadSauceConfig_Redacted(t *testing.T) {
+	dir := fs.NewDir(t, "redact", fs.WithFile("config.yml", "npm:\n  registries:\n    - url: https://registry.npmjs.org\n      authToken: abc123\n"))
+	defer dir.Remove()
+
+	redactor, err := redact.New(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uploaded := map[string][]byte{}
+	r := CloudRunner{
+		JobService: JobService{
+			VDCWriter: &mocks.FakeJobWriter{
+				UploadAssetFn: func(jobID string, fileName string, contentType string, content []byte) error {
+					uploaded[fileName] = content
+					return nil
+				},
+			},
+		},
+		Redactor: redactor,
+	}
+
+	r.uploadSauceConfig("fake-id", false, dir.Join("config.yml"))
+	r.uploadCLIFlags("fake-id", false, map[string]interface{}{"tunnel-name": "my-tunnel"})
+
+	assert.NotContains(t, string(uploaded["config.yml"]), "abc123")
+	assert.Contains(t, string(uploaded["config.yml"]), redact.Mask)
+	assert.NotContains(t, string(uploaded["flags.json"]), "my-tunnel")
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/rs/zerolog/log"
+	"github.com/saucelabs/saucectl/internal/config"
 	"github.com/saucelabs/saucectl/internal/cucumber"
 	"github.com/saucelabs/saucectl/internal/framework"
 	"github.com/saucelabs/saucectl/internal/msg"
@@ -93,7 +94,6 @@ func (r *CucumberRunner) runSuites(app string, otherApps []string) bool {
 	if err != nil {
 		return false
 	}
-	defer close(results)
 
 	suites := r.Project.Suites
 	if r.Project.Sauce.LaunchOrder != "" {
@@ -107,10 +107,14 @@ func (r *CucumberRunner) runSuites(app string, otherApps []string) bool {
 
 	// Submit suites to work on
 	go func() {
+		defer close(jobOpts)
 		for _, s := range suites {
 			jobOpts <- job.StartOptions{
 				ConfigFilePath:   r.Project.ConfigFilePath,
 				DisplayName:      s.Name,
+				SuiteName:        s.Name,
+				Priority:         s.Priority,
+				DependsOn:        s.DependsOn,
 				App:              app,
 				OtherApps:        otherApps,
 				Suite:            s.Name,
@@ -130,7 +134,7 @@ func (r *CucumberRunner) runSuites(app string, otherApps []string) bool {
 				RunnerVersion:    r.Project.RunnerVersion,
 				Experiments:      r.Project.Sauce.Experiments,
 				Attempt:          0,
-				Retries:          r.Project.Sauce.Retries,
+				Retries:          config.SuiteRetries(r.Project.Sauce.Retries, s.Retries),
 				Visibility:       r.Project.Sauce.Visibility,
 				PassThreshold:    s.PassThreshold,
 				SmartRetry: job.SmartRetry{
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/rs/zerolog/log"
+	"github.com/saucelabs/saucectl/internal/config"
 	"github.com/saucelabs/saucectl/internal/cypress"
 	"github.com/saucelabs/saucectl/internal/cypress/suite"
 	"github.com/saucelabs/saucectl/internal/framework"
@@ -60,6 +61,14 @@ func (r *CypressRunner) RunProject() (int, error) {
 		return exitCode, err
 	}
 
+	var sauceignores []string
+	for _, s := range r.Project.GetSuites() {
+		sauceignores = append(sauceignores, s.Sauceignore)
+	}
+	if err := r.remoteArchiveSuiteApps(r.Project.GetRootDir(), r.Project.GetSauceCfg().Sauceignore, sauceignores, r.Project.IsDryRun()); err != nil {
+		return exitCode, err
+	}
+
 	if r.Project.IsDryRun() {
 		log.Info().Msgf("The following test suites would have run: [%s].", r.Project.GetSuiteNames())
 		return 0, nil
@@ -92,7 +101,6 @@ func (r *CypressRunner) runSuites(app string, otherApps []string) bool {
 	if err != nil {
 		return false
 	}
-	defer close(results)
 
 	suites := r.Project.GetSuites()
 	if r.Project.GetSauceCfg().LaunchOrder != "" {
@@ -106,14 +114,18 @@ func (r *CypressRunner) runSuites(app string, otherApps []string) bool {
 
 	// Submit suites to work on.
 	go func() {
+		defer close(jobOpts)
 		for _, s := range suites {
 			smartRetry := r.Project.GetSmartRetry(s.Name)
 			jobOpts <- job.StartOptions{
 				ConfigFilePath:   r.Project.GetCfgPath(),
 				CLIFlags:         r.Project.GetCLIFlags(),
 				DisplayName:      s.Name,
+				SuiteName:        s.Name,
+				Priority:         s.Priority,
+				DependsOn:        s.DependsOn,
 				Timeout:          s.Timeout,
-				App:              app,
+				App:              r.appForSuite(app, s.Sauceignore),
 				OtherApps:        otherApps,
 				Suite:            s.Name,
 				Framework:        "cypress",
@@ -132,7 +144,7 @@ func (r *CypressRunner) runSuites(app string, otherApps []string) bool {
 				RunnerVersion:    r.Project.GetRunnerVersion(),
 				Experiments:      r.Project.GetSauceCfg().Experiments,
 				Attempt:          0,
-				Retries:          r.Project.GetSauceCfg().Retries,
+				Retries:          config.SuiteRetries(r.Project.GetSauceCfg().Retries, s.Retries),
 				TimeZone:         s.TimeZone,
 				Visibility:       r.Project.GetSauceCfg().Visibility,
 				PassThreshold:    s.PassThreshold,
@@ -86,7 +86,6 @@ func (r *EspressoRunner) runSuites() bool {
 	if err != nil {
 		return false
 	}
-	defer close(results)
 
 	suites := r.Project.Suites
 	if r.Project.Sauce.LaunchOrder != "" {
@@ -100,6 +99,7 @@ func (r *EspressoRunner) runSuites() bool {
 	// Submit suites to work on.
 	jobsCount := r.calculateJobsCount(suites)
 	go func() {
+		defer close(jobOpts)
 		for _, s := range suites {
 			numShards, _ := getNumShardsAndShardIndex(s.TestOptions)
 			// Automatically apply ShardIndex if numShards is defined
@@ -199,6 +199,9 @@ func (r *EspressoRunner) startJob(jobOpts chan<- job.StartOptions, s espresso.Su
 
 	jobOpts <- job.StartOptions{
 		DisplayName:       displayName,
+		SuiteName:         s.Name,
+		Priority:          s.Priority,
+		DependsOn:         s.DependsOn,
 		Timeout:           s.Timeout,
 		ConfigFilePath:    r.Project.ConfigFilePath,
 		CLIFlags:          r.Project.CLIFlags,
@@ -223,7 +226,7 @@ func (r *EspressoRunner) startJob(jobOpts chan<- job.StartOptions, s espresso.Su
 		Experiments:   r.Project.Sauce.Experiments,
 		TestOptions:   s.TestOptions,
 		Attempt:       0,
-		Retries:       r.Project.Sauce.Retries,
+		Retries:       config.SuiteRetries(r.Project.Sauce.Retries, s.Retries),
 		Visibility:    r.Project.Sauce.Visibility,
 		PassThreshold: s.PassThreshold,
 		SmartRetry: job.SmartRetry{
@@ -126,13 +126,6 @@ func (r *ImgRunner) runSuites(suites chan imagerunner.Suite, results chan<- exec
 		suite.Files = append(suite.Files, defaults.Files...)
 		suite.Artifacts = append(suite.Artifacts, defaults.Artifacts...)
 
-		if suite.Env == nil {
-			suite.Env = make(map[string]string)
-		}
-		for k, v := range defaults.Env {
-			suite.Env[k] = v
-		}
-
 		startTime := time.Now()
 
 		if r.ctx.Err() != nil {
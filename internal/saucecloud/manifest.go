@@ -0,0 +1,92 @@
+package saucecloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/saucelabs/saucectl/internal/job"
+)
+
+// Manifest records the outcome of the suites of a run, which allows an interrupted run to be resumed.
+type Manifest struct {
+	// Interrupted indicates that the run was interrupted before all suites finished.
+	Interrupted bool            `json:"interrupted"`
+	Suites      []ManifestSuite `json:"suites"`
+}
+
+// ManifestSuite records the outcome of a single suite. A suite is identified by its name, browser, platform and
+// device, since the same suite may run on several of them.
+type ManifestSuite struct {
+	Name            string `json:"name"`
+	Browser         string `json:"browser,omitempty"`
+	Platform        string `json:"platform,omitempty"`
+	PlatformVersion string `json:"platformVersion,omitempty"`
+	Device          string `json:"device,omitempty"`
+	Status          string `json:"status"`
+	Passed          bool   `json:"passed"`
+	JobID           string `json:"jobID,omitempty"`
+	RDC             bool   `json:"rdc,omitempty"`
+}
+
+func newManifestSuite(opts job.StartOptions) ManifestSuite {
+	return ManifestSuite{
+		Name:            opts.DisplayName,
+		Browser:         opts.BrowserName,
+		Platform:        opts.PlatformName,
+		PlatformVersion: opts.PlatformVersion,
+		Device:          opts.DeviceName,
+	}
+}
+
+// is reports whether s and o refer to the same suite.
+func (s ManifestSuite) is(o ManifestSuite) bool {
+	return s.Name == o.Name && s.Browser == o.Browser && s.Platform == o.Platform &&
+		s.PlatformVersion == o.PlatformVersion && s.Device == o.Device
+}
+
+// add records the final result of a suite.
+func (m *Manifest) add(res result) {
+	s := res.manifest
+	s.Status = res.job.TotalStatus()
+	if s.Status == "" {
+		s.Status = job.StateSkipped
+	}
+	s.Passed = res.job.Passed
+	s.JobID = res.job.ID
+	s.RDC = res.job.IsRDC
+	m.Suites = append(m.Suites, s)
+}
+
+// passed returns the record of a suite that passed in the run of the manifest.
+func (m Manifest) passed(opts job.StartOptions) (ManifestSuite, bool) {
+	id := newManifestSuite(opts)
+	for _, s := range m.Suites {
+		if s.Passed && s.is(id) {
+			return s, true
+		}
+	}
+	return ManifestSuite{}, false
+}
+
+// ReadManifest reads the run manifest from filename.
+func ReadManifest(filename string) (Manifest, error) {
+	var m Manifest
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return m, fmt.Errorf("failed to read run manifest: %w", err)
+	}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return m, fmt.Errorf("failed to parse run manifest: %w", err)
+	}
+	return m, nil
+}
+
+// Write writes the run manifest to filename.
+func (m Manifest) Write(filename string) error {
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, b, 0644)
+}
@@ -0,0 +1,178 @@
+package saucecloud
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/saucelabs/saucectl/internal/config"
+	"github.com/saucelabs/saucectl/internal/job"
+	"github.com/saucelabs/saucectl/internal/region"
+	"github.com/saucelabs/saucectl/internal/report"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeReporter struct {
+	results  []report.TestResult
+	rendered bool
+}
+
+func (r *fakeReporter) Add(t report.TestResult) {
+	r.results = append(r.results, t)
+}
+
+func (r *fakeReporter) Render() {
+	r.rendered = true
+}
+
+func (r *fakeReporter) Reset() {
+	r.results = nil
+}
+
+func (r *fakeReporter) ArtifactRequirements() []report.ArtifactType {
+	return nil
+}
+
+func TestManifest_passed(t *testing.T) {
+	chrome := job.StartOptions{DisplayName: "suite", BrowserName: "chrome", PlatformName: "Windows 11"}
+	firefox := job.StartOptions{DisplayName: "suite", BrowserName: "firefox", PlatformName: "Windows 11"}
+	edge := job.StartOptions{DisplayName: "suite", BrowserName: "edge", PlatformName: "Windows 11"}
+
+	var m Manifest
+	m.add(result{manifest: newManifestSuite(chrome), job: job.Job{ID: "1", Status: job.StateComplete, Passed: true}})
+	m.add(result{manifest: newManifestSuite(firefox), job: job.Job{ID: "2", Status: job.StateComplete}})
+	m.add(result{manifest: newManifestSuite(edge), skipped: true})
+
+	assert.Equal(t, job.StatePassed, m.Suites[0].Status)
+	assert.Equal(t, job.StateFailed, m.Suites[1].Status)
+	assert.Equal(t, job.StateSkipped, m.Suites[2].Status)
+
+	prev, ok := m.passed(chrome)
+	assert.True(t, ok)
+	assert.Equal(t, "1", prev.JobID)
+
+	_, ok = m.passed(firefox)
+	assert.False(t, ok)
+	_, ok = m.passed(edge)
+	assert.False(t, ok)
+}
+
+func TestManifest_WriteRead(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "manifest.json")
+	m := Manifest{
+		Interrupted: true,
+		Suites: []ManifestSuite{
+			{Name: "suite", Browser: "chrome", Status: job.StatePassed, Passed: true, JobID: "1"},
+		},
+	}
+
+	assert.NoError(t, m.Write(filename))
+	got, err := ReadManifest(filename)
+	assert.NoError(t, err)
+	assert.Equal(t, m, got)
+
+	_, err = ReadManifest(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestRunJobsResumed(t *testing.T) {
+	opts := job.StartOptions{DisplayName: "suite", SuiteName: "suite", BrowserName: "chrome"}
+	r := CloudRunner{
+		Resume: Manifest{Suites: []ManifestSuite{
+			{Name: "suite", Browser: "chrome", Status: job.StatePassed, Passed: true, JobID: "1"},
+		}},
+	}
+
+	jobOpts := make(chan job.StartOptions)
+	results := make(chan result)
+
+	go r.runJobs(jobOpts, results)
+	jobOpts <- opts
+	close(jobOpts)
+	res := <-results
+	assert.True(t, res.resumed)
+	assert.False(t, res.skipped)
+	assert.True(t, res.job.Passed)
+	assert.Equal(t, "1", res.job.ID)
+}
+
+func TestCollectResults_Interrupted(t *testing.T) {
+	rep := &fakeReporter{}
+	filename := filepath.Join(t.TempDir(), "manifest.json")
+	r := CloudRunner{
+		Region:       region.USWest1,
+		Reporters:    []report.Reporter{rep},
+		ManifestPath: filename,
+		graceExpired: make(chan struct{}),
+	}
+	r.signaled = true
+	r.interrupted = true
+
+	results := make(chan result, 2)
+	results <- result{
+		name:     "passed",
+		manifest: ManifestSuite{Name: "passed"},
+		job:      job.Job{ID: "1", Status: job.StatePassed, Passed: true},
+		resumed:  true,
+	}
+	results <- result{name: "not run", manifest: ManifestSuite{Name: "not run"}, skipped: true}
+	// The third suite never finishes, so only the grace period ends the collection.
+	close(r.graceExpired)
+
+	passed := r.collectResults(config.ArtifactDownload{}, results, 3)
+
+	assert.False(t, passed)
+	assert.True(t, rep.rendered)
+	assert.Len(t, rep.results, 1)
+
+	m, err := ReadManifest(filename)
+	assert.NoError(t, err)
+	assert.True(t, m.Interrupted)
+	assert.Equal(t, []ManifestSuite{
+		{Name: "passed", Status: job.StatePassed, Passed: true, JobID: "1"},
+		{Name: "not run", Status: job.StateSkipped},
+	}, m.Suites)
+}
+
+func TestCollectResults_FailFast(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "manifest.json")
+	r := CloudRunner{
+		Region:       region.USWest1,
+		ManifestPath: filename,
+		FailFast:     true,
+	}
+	// Fail-fast interrupts the run as well, but there's nothing to resume.
+	r.interrupted = true
+
+	results := make(chan result, 1)
+	results <- result{name: "not run", manifest: ManifestSuite{Name: "not run"}, skipped: true}
+
+	passed := r.collectResults(config.ArtifactDownload{}, results, 1)
+
+	assert.False(t, passed)
+	assert.NoFileExists(t, filename)
+}
+
+func TestCollectResults_Local(t *testing.T) {
+	rep := &fakeReporter{}
+	// Neither builds nor Insights are available, so the runner must not ask for them.
+	r := CloudRunner{
+		Region:    region.USWest1,
+		Reporters: []report.Reporter{rep},
+		Local:     true,
+	}
+
+	results := make(chan result, 1)
+	results <- result{
+		name:     "local",
+		manifest: ManifestSuite{Name: "local"},
+		job:      job.Job{ID: "c0ffee", Status: job.StateComplete, Passed: true},
+	}
+
+	passed := r.collectResults(config.ArtifactDownload{}, results, 1)
+
+	assert.True(t, passed)
+	assert.Len(t, rep.results, 1)
+	assert.Empty(t, rep.results[0].URL)
+	assert.Empty(t, rep.results[0].BuildURL)
+	assert.Equal(t, job.StatePassed, rep.results[0].Status)
+}
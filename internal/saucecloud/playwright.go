@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/rs/zerolog/log"
+	"github.com/saucelabs/saucectl/internal/config"
 	"github.com/saucelabs/saucectl/internal/framework"
 	"github.com/saucelabs/saucectl/internal/msg"
 
@@ -66,6 +67,14 @@ func (r *PlaywrightRunner) RunProject() (int, error) {
 		return exitCode, err
 	}
 
+	var sauceignores []string
+	for _, s := range r.Project.Suites {
+		sauceignores = append(sauceignores, s.Sauceignore)
+	}
+	if err := r.remoteArchiveSuiteApps(r.Project.RootDir, r.Project.Sauce.Sauceignore, sauceignores, r.Project.DryRun); err != nil {
+		return exitCode, err
+	}
+
 	if r.Project.DryRun {
 		printDryRunSuiteNames(r.getSuiteNames())
 		return 0, nil
@@ -99,7 +108,6 @@ func (r *PlaywrightRunner) runSuites(app string, otherApps []string) bool {
 	if err != nil {
 		return false
 	}
-	defer close(results)
 
 	suites := r.Project.Suites
 	if r.Project.Sauce.LaunchOrder != "" {
@@ -112,6 +120,7 @@ func (r *PlaywrightRunner) runSuites(app string, otherApps []string) bool {
 	}
 	// Submit suites to work on.
 	go func() {
+		defer close(jobOpts)
 		for _, s := range suites {
 			// Define frameworkVersion if not set at suite level
 			if s.PlaywrightVersion == "" {
@@ -121,8 +130,11 @@ func (r *PlaywrightRunner) runSuites(app string, otherApps []string) bool {
 				ConfigFilePath:   r.Project.ConfigFilePath,
 				CLIFlags:         r.Project.CLIFlags,
 				DisplayName:      s.Name,
+				SuiteName:        s.Name,
+				Priority:         s.Priority,
+				DependsOn:        s.DependsOn,
 				Timeout:          s.Timeout,
-				App:              app,
+				App:              r.appForSuite(app, s.Sauceignore),
 				OtherApps:        otherApps,
 				Suite:            s.Name,
 				Framework:        "playwright",
@@ -141,7 +153,7 @@ func (r *PlaywrightRunner) runSuites(app string, otherApps []string) bool {
 				RunnerVersion:    r.Project.RunnerVersion,
 				Experiments:      r.Project.Sauce.Experiments,
 				Attempt:          0,
-				Retries:          r.Project.Sauce.Retries,
+				Retries:          config.SuiteRetries(r.Project.Sauce.Retries, s.Retries),
 				TimeZone:         s.TimeZone,
 				Visibility:       r.Project.Sauce.Visibility,
 				PassThreshold:    s.PassThreshold,
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/rs/zerolog/log"
+	"github.com/saucelabs/saucectl/internal/config"
 	"github.com/saucelabs/saucectl/internal/framework"
 	"github.com/saucelabs/saucectl/internal/job"
 	"github.com/saucelabs/saucectl/internal/msg"
@@ -74,7 +75,6 @@ func (r *ReplayRunner) runSuites(fileURI string) bool {
 	if err != nil {
 		return false
 	}
-	defer close(results)
 
 	suites := r.Project.Suites
 	if r.Project.Sauce.LaunchOrder != "" {
@@ -87,10 +87,14 @@ func (r *ReplayRunner) runSuites(fileURI string) bool {
 	}
 	// Submit suites to work on.
 	go func() {
+		defer close(jobOpts)
 		for _, s := range suites {
 			jobOpts <- job.StartOptions{
 				ConfigFilePath:   r.Project.ConfigFilePath,
 				DisplayName:      s.Name,
+				SuiteName:        s.Name,
+				Priority:         s.Priority,
+				DependsOn:        s.DependsOn,
 				Timeout:          s.Timeout,
 				App:              fileURI,
 				Suite:            s.Name,
@@ -109,7 +113,7 @@ func (r *ReplayRunner) runSuites(fileURI string) bool {
 				},
 				Experiments:   r.Project.Sauce.Experiments,
 				Attempt:       0,
-				Retries:       r.Project.Sauce.Retries,
+				Retries:       config.SuiteRetries(r.Project.Sauce.Retries, s.Retries),
 				Visibility:    r.Project.Sauce.Visibility,
 				PassThreshold: s.PassThreshold,
 			}
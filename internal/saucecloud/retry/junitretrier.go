@@ -9,7 +9,6 @@ import (
 	"github.com/saucelabs/saucectl/internal/junit"
 	"github.com/saucelabs/saucectl/internal/msg"
 	"github.com/saucelabs/saucectl/internal/xcuitest"
-	"golang.org/x/exp/maps"
 )
 
 type JunitRetrier struct {
@@ -33,31 +32,34 @@ func (b *JunitRetrier) retryFailedTests(reader job.Reader, jobOpts chan<- job.St
 		return
 	}
 
-	setClassesToRetry(&opt, suites.TestCases())
+	setTestsToRetry(&opt, suites.TestCases(), previous.IsRDC)
 	jobOpts <- opt
 }
 
-// setClassesToRetry sets the correct filtering flag when retrying.
-// RDC API does not provide different endpoints (or identical values) for Espresso
-// and XCUITest. Thus, we need set the classes at the correct position depending the
-// framework that is being executed.
-func setClassesToRetry(opt *job.StartOptions, testcases []junit.TestCase) {
-	lg := log.Info().
+// setTestsToRetry narrows the job down to the failed tests, which are selected
+// in the same way as the suite's 'class' setting. Espresso always selects tests
+// by the 'class' test option. The RDC API does not provide different endpoints
+// (or identical values) for Espresso and XCUITest, so XCUITest selects tests by
+// TestsToRun on real devices and by the 'class' test option on simulators.
+func setTestsToRetry(opt *job.StartOptions, testcases []junit.TestCase, isRDC bool) {
+	tests := FailedTests(opt.Framework, testcases)
+
+	log.Info().
 		Str("suite", opt.DisplayName).
-		Str("attempt", fmt.Sprintf("%d of %d", opt.Attempt+1, opt.Retries+1))
+		Str("attempt", fmt.Sprintf("%d of %d", opt.Attempt+1, opt.Retries+1)).
+		Msgf(msg.RetryWithTests, tests)
 
 	if opt.Framework == xcuitest.Kind {
-		opt.TestsToRun = getFailedXCUITests(testcases)
-		lg.Msgf(msg.RetryWithTests, opt.TestsToRun)
-		return
+		opt.TestsToRun = tests
+		if isRDC {
+			return
+		}
 	}
 
 	if opt.TestOptions == nil {
 		opt.TestOptions = map[string]interface{}{}
 	}
-	tests := getFailedEspressoTests(testcases)
 	opt.TestOptions["class"] = tests
-	lg.Msgf(msg.RetryWithTests, tests)
 }
 
 func (b *JunitRetrier) Retry(jobOpts chan<- job.StartOptions, opt job.StartOptions, previous job.Job) {
@@ -77,36 +79,32 @@ func (b *JunitRetrier) Retry(jobOpts chan<- job.StartOptions, opt job.StartOptio
 	jobOpts <- opt
 }
 
-// getFailedXCUITests returns a list of failed XCUITest tests from the given
-// test cases. The format is "<className>/<testMethodName>", with the test
-// method name being optional.
-func getFailedXCUITests(testCases []junit.TestCase) []string {
-	classes := map[string]bool{}
-	for _, tc := range testCases {
-		if tc.Error != nil || tc.Failure != nil {
-			if tc.Name != "" {
-				classes[fmt.Sprintf("%s/%s", tc.ClassName, tc.Name)] = true
-			} else {
-				classes[tc.ClassName] = true
-			}
-		}
+// FailedTests returns the failed tests from the given test cases in the
+// format that the framework uses to select individual tests:
+// "<className>/<testMethodName>" for XCUITest and "<className>#<testMethodName>"
+// for Espresso. The test method name is optional, in which case the whole class
+// is selected.
+func FailedTests(framework string, testCases []junit.TestCase) []string {
+	sep := "#"
+	if framework == xcuitest.Kind {
+		sep = "/"
 	}
-	return maps.Keys(classes)
-}
 
-// getFailedEspressoTests returns a list of failed Espresso tests from the given
-// test cases. The format is "<className>#<testMethodName>", with the test
-// method name being optional.
-func getFailedEspressoTests(testCases []junit.TestCase) []string {
-	classes := map[string]bool{}
+	seen := map[string]bool{}
+	var tests []string
 	for _, tc := range testCases {
-		if tc.Error != nil || tc.Failure != nil {
-			if tc.Name != "" {
-				classes[fmt.Sprintf("%s#%s", tc.ClassName, tc.Name)] = true
-			} else {
-				classes[tc.ClassName] = true
-			}
+		if tc.Error == nil && tc.Failure == nil {
+			continue
+		}
+
+		test := tc.ClassName
+		if tc.Name != "" {
+			test = tc.ClassName + sep + tc.Name
+		}
+		if !seen[test] {
+			seen[test] = true
+			tests = append(tests, test)
 		}
 	}
-	return maps.Keys(classes)
+	return tests
 }
@@ -200,6 +200,49 @@ func TestAppsRetrier_Retry(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "XCUITest: Job retrying only failed tests if VDC + SmartRetry",
+			init: init{
+				VDCReader: &mocks.FakeJobReader{
+					GetJobAssetFileContentFn: func(ctx context.Context, jobID, fileName string) ([]byte, error) {
+						if jobID == "fake-job-id" && fileName == junit.FileName {
+							return []byte("<?xml version=\"1.0\" encoding=\"UTF-8\" standalone=\"yes\"?>\n<testsuite>\n    <testcase name=\"demoTest\" classname=\"Demo.Class1\">\n        <failure>ERROR</failure>\n    </testcase>\n    <testcase name=\"otherTest\" classname=\"Demo.Class1\"/>\n    <testcase classname=\"Demo.Class2\"/>\n</testsuite>\n"), nil
+						}
+						return []byte{}, errors.New("unknown file")
+					},
+				},
+				RetryVDC: true,
+			},
+			args: args{
+				jobOpts: make(chan job.StartOptions),
+				opt: job.StartOptions{
+					Framework:   xcuitest.Kind,
+					DisplayName: "Dummy Test",
+					SmartRetry: job.SmartRetry{
+						FailedOnly: true,
+					},
+					TestsToRun: []string{"Demo.Class1", "Demo.Class2"},
+					TestOptions: map[string]interface{}{
+						"class": []string{"Demo.Class1", "Demo.Class2"},
+					},
+				},
+				previous: job.Job{
+					ID:    "fake-job-id",
+					IsRDC: false,
+				},
+			},
+			expected: job.StartOptions{
+				Framework:   xcuitest.Kind,
+				DisplayName: "Dummy Test",
+				TestsToRun:  []string{"Demo.Class1/demoTest"},
+				TestOptions: map[string]interface{}{
+					"class": []string{"Demo.Class1/demoTest"},
+				},
+				SmartRetry: job.SmartRetry{
+					FailedOnly: true,
+				},
+			},
+		},
 		{
 			name: "Job not retrying if RDC and config is VDC + SmartRetry",
 			init: init{
@@ -370,3 +413,23 @@ func TestAppsRetrier_Retry(t *testing.T) {
 		})
 	}
 }
+
+func TestFailedTests(t *testing.T) {
+	testCases := []junit.TestCase{
+		{ClassName: "Demo.Class1", Name: "failingTest", Failure: &junit.Failure{}},
+		{ClassName: "Demo.Class1", Name: "failingTest", Failure: &junit.Failure{}},
+		{ClassName: "Demo.Class1", Name: "passingTest"},
+		{ClassName: "Demo.Class2", Name: "erroringTest", Error: &junit.Error{}},
+		{ClassName: "Demo.Class3", Failure: &junit.Failure{}},
+	}
+
+	assert.Equal(t,
+		[]string{"Demo.Class1#failingTest", "Demo.Class2#erroringTest", "Demo.Class3"},
+		FailedTests(espresso.Kind, testCases),
+	)
+	assert.Equal(t,
+		[]string{"Demo.Class1/failingTest", "Demo.Class2/erroringTest", "Demo.Class3"},
+		FailedTests(xcuitest.Kind, testCases),
+	)
+	assert.Empty(t, FailedTests(espresso.Kind, testCases[2:3]))
+}
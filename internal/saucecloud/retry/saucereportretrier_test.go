@@ -17,6 +17,13 @@ import (
 type StubProjectUploader struct {
 }
 
+func (f *StubProjectUploader) UploadStreamFunc(filename, description string, size int64, open func() (io.ReadCloser, error)) (storage.Item, error) {
+	return storage.Item{
+		ID:   "fakeid",
+		Name: "fake name",
+	}, nil
+}
+
 func (f *StubProjectUploader) UploadStream(filename, description string, reader io.Reader) (storage.Item, error) {
 	return storage.Item{
 		ID:   "fakeid",
@@ -40,6 +47,14 @@ func (f *StubProjectUploader) Delete(id string) error {
 	return nil
 }
 
+func (f *StubProjectUploader) Get(id string) (storage.Item, error) {
+	return storage.Item{}, nil
+}
+
+func (f *StubProjectUploader) Describe(id, description string) (storage.Item, error) {
+	return storage.Item{}, nil
+}
+
 type StubVDCJobReader struct {
 	SauceReport saucereport.SauceReport
 }
@@ -0,0 +1,137 @@
+package saucecloud
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"github.com/saucelabs/saucectl/internal/job"
+)
+
+// scheduler submits jobs to the workers in order of their priority. The jobs of a suite are held back until all
+// suites it depends on have passed, and are skipped if any of them didn't.
+type scheduler struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	// pending are the jobs that have yet to be submitted, in the order in which they're considered for submission.
+	pending []job.StartOptions
+	// remaining is the number of unfinished jobs per suite.
+	remaining map[string]int
+	// failed are the suites that have at least one job that didn't pass.
+	failed map[string]bool
+
+	// ignoreDependencies launches suites regardless of their dependencies, e.g. since their outcome isn't awaited.
+	ignoreDependencies bool
+}
+
+func newScheduler(ignoreDependencies bool) *scheduler {
+	s := &scheduler{
+		remaining:          make(map[string]int),
+		failed:             make(map[string]bool),
+		ignoreDependencies: ignoreDependencies,
+	}
+	s.cond = sync.NewCond(&s.mu)
+	return s
+}
+
+// run receives jobs from queue until it's closed and then submits them to the workers via jobOpts. Results of
+// skipped jobs are sent to results directly.
+func (s *scheduler) run(queue <-chan job.StartOptions, jobOpts chan<- job.StartOptions, results chan<- result) {
+	var hasDependencies bool
+	for opts := range queue {
+		if len(opts.DependsOn) > 0 {
+			hasDependencies = true
+			if s.ignoreDependencies {
+				opts.DependsOn = nil
+			}
+		}
+
+		s.mu.Lock()
+		s.pending = append(s.pending, opts)
+		s.remaining[opts.SuiteName]++
+		s.mu.Unlock()
+	}
+
+	if hasDependencies && s.ignoreDependencies {
+		log.Warn().Msg("Suite dependencies are ignored when running asynchronously.")
+	}
+
+	s.mu.Lock()
+	sort.SliceStable(s.pending, func(i, j int) bool {
+		return s.pending[i].Priority > s.pending[j].Priority
+	})
+	s.mu.Unlock()
+
+	for {
+		s.mu.Lock()
+		i, failedDep := s.next()
+		for i < 0 && len(s.pending) > 0 {
+			s.cond.Wait()
+			i, failedDep = s.next()
+		}
+		if i < 0 {
+			s.mu.Unlock()
+			return
+		}
+		opts := s.pending[i]
+		s.pending = append(s.pending[:i], s.pending[i+1:]...)
+		s.mu.Unlock()
+
+		if failedDep != "" {
+			log.Warn().Str("suite", opts.DisplayName).Str("dependency", failedDep).
+				Msg("Skipping suite, since a suite it depends on didn't pass.")
+			results <- result{
+				name:     opts.DisplayName,
+				browser:  opts.BrowserName,
+				suite:    opts.SuiteName,
+				manifest: newManifestSuite(opts),
+				job:      job.Job{Status: job.StateSkipped},
+				skipped:  true,
+				err:      fmt.Errorf("suite %q depends on suite %q, which didn't pass", opts.SuiteName, failedDep),
+				retries:  opts.Retries,
+			}
+			continue
+		}
+
+		jobOpts <- opts
+	}
+}
+
+// next returns the index of the first pending job that's ready to be submitted, or -1 if none is. A job is ready once
+// all suites it depends on have passed, or as soon as one of them didn't, in which case that suite's name is returned
+// as well. Must be called while holding s.mu.
+func (s *scheduler) next() (int, string) {
+	for i, opts := range s.pending {
+		ready := true
+		for _, d := range opts.DependsOn {
+			if s.failed[d] {
+				return i, d
+			}
+			if s.remaining[d] > 0 {
+				ready = false
+			}
+		}
+		if ready {
+			return i, ""
+		}
+	}
+	return -1, ""
+}
+
+// done records the final result of a job, which may release the jobs of the suites that depend on its suite.
+func (s *scheduler) done(res result) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.remaining[res.suite]--
+	if res.skipped || !res.job.Passed {
+		s.failed[res.suite] = true
+	}
+	s.cond.Broadcast()
+}
@@ -0,0 +1,98 @@
+package saucecloud
+
+import (
+	"testing"
+	"time"
+
+	"github.com/saucelabs/saucectl/internal/job"
+	"github.com/stretchr/testify/assert"
+)
+
+// startScheduler runs a scheduler for the given jobs and returns the channels it submits to.
+func startScheduler(s *scheduler, opts ...job.StartOptions) (chan job.StartOptions, chan result) {
+	queue := make(chan job.StartOptions)
+	jobOpts := make(chan job.StartOptions)
+	results := make(chan result)
+
+	go s.run(queue, jobOpts, results)
+	go func() {
+		defer close(queue)
+		for _, o := range opts {
+			queue <- o
+		}
+	}()
+
+	return jobOpts, results
+}
+
+func receive[T any](t *testing.T, c chan T) T {
+	t.Helper()
+	select {
+	case v := <-c:
+		return v
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the scheduler")
+	}
+	var zero T
+	return zero
+}
+
+func TestScheduler_Priority(t *testing.T) {
+	jobOpts, _ := startScheduler(newScheduler(false),
+		job.StartOptions{SuiteName: "a"},
+		job.StartOptions{SuiteName: "b", Priority: 5},
+		job.StartOptions{SuiteName: "c", Priority: 1},
+		job.StartOptions{SuiteName: "d"},
+	)
+
+	var got []string
+	for i := 0; i < 4; i++ {
+		got = append(got, receive(t, jobOpts).SuiteName)
+	}
+	assert.Equal(t, []string{"b", "c", "a", "d"}, got)
+}
+
+func TestScheduler_Dependencies(t *testing.T) {
+	s := newScheduler(false)
+	jobOpts, results := startScheduler(s,
+		job.StartOptions{SuiteName: "regression", DependsOn: []string{"smoke"}},
+		job.StartOptions{SuiteName: "e2e", DependsOn: []string{"regression"}},
+		job.StartOptions{SuiteName: "smoke", DisplayName: "smoke (1)"},
+		job.StartOptions{SuiteName: "smoke", DisplayName: "smoke (2)"},
+		job.StartOptions{SuiteName: "api", DependsOn: []string{"login"}},
+		job.StartOptions{SuiteName: "login"},
+	)
+
+	// Only suites without dependencies are launched right away.
+	assert.Equal(t, "smoke (1)", receive(t, jobOpts).DisplayName)
+	assert.Equal(t, "smoke (2)", receive(t, jobOpts).DisplayName)
+	assert.Equal(t, "login", receive(t, jobOpts).SuiteName)
+
+	// Dependents are launched once their dependencies passed.
+	s.done(result{suite: "login", job: job.Job{Passed: true}})
+	assert.Equal(t, "api", receive(t, jobOpts).SuiteName)
+
+	// Dependents are skipped, transitively, as soon as any job of a dependency didn't pass.
+	s.done(result{suite: "smoke", job: job.Job{Passed: true}})
+	s.done(result{suite: "smoke", job: job.Job{Passed: false}})
+	res := receive(t, results)
+	assert.Equal(t, "regression", res.suite)
+	assert.True(t, res.skipped)
+	assert.Equal(t, job.StateSkipped, res.job.Status)
+	assert.EqualError(t, res.err, `suite "regression" depends on suite "smoke", which didn't pass`)
+
+	s.done(res)
+	res = receive(t, results)
+	assert.Equal(t, "e2e", res.suite)
+	assert.True(t, res.skipped)
+}
+
+func TestScheduler_IgnoreDependencies(t *testing.T) {
+	jobOpts, _ := startScheduler(newScheduler(true),
+		job.StartOptions{SuiteName: "regression", DependsOn: []string{"smoke"}},
+		job.StartOptions{SuiteName: "smoke"},
+	)
+
+	assert.Equal(t, "regression", receive(t, jobOpts).SuiteName)
+	assert.Equal(t, "smoke", receive(t, jobOpts).SuiteName)
+}
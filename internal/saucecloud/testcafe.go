@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/rs/zerolog/log"
+	"github.com/saucelabs/saucectl/internal/config"
 	"github.com/saucelabs/saucectl/internal/framework"
 	"github.com/saucelabs/saucectl/internal/msg"
 
@@ -59,6 +60,14 @@ func (r *TestcafeRunner) RunProject() (int, error) {
 		return exitCode, err
 	}
 
+	var sauceignores []string
+	for _, s := range r.Project.Suites {
+		sauceignores = append(sauceignores, s.Sauceignore)
+	}
+	if err := r.remoteArchiveSuiteApps(r.Project.RootDir, r.Project.Sauce.Sauceignore, sauceignores, r.Project.DryRun); err != nil {
+		return exitCode, err
+	}
+
 	if r.Project.DryRun {
 		printDryRunSuiteNames(r.getSuiteNames())
 		return 0, nil
@@ -92,7 +101,6 @@ func (r *TestcafeRunner) runSuites(app string, otherApps []string) bool {
 	if err != nil {
 		return false
 	}
-	defer close(results)
 
 	suites := r.Project.Suites
 	if r.Project.Sauce.LaunchOrder != "" {
@@ -107,12 +115,13 @@ func (r *TestcafeRunner) runSuites(app string, otherApps []string) bool {
 	// Submit suites to work on
 	jobsCount := r.calcTestcafeJobsCount(r.Project.Suites)
 	go func() {
+		defer close(jobOpts)
 		for _, s := range suites {
 			if len(s.Simulators) > 0 {
 				for _, d := range s.Simulators {
 					for _, pv := range d.PlatformVersions {
 						opts := r.generateStartOpts(s)
-						opts.App = app
+						opts.App = r.appForSuite(app, s.Sauceignore)
 						opts.OtherApps = otherApps
 						opts.PlatformName = d.PlatformName
 						opts.DeviceName = d.Name
@@ -123,7 +132,7 @@ func (r *TestcafeRunner) runSuites(app string, otherApps []string) bool {
 				}
 			} else {
 				opts := r.generateStartOpts(s)
-				opts.App = app
+				opts.App = r.appForSuite(app, s.Sauceignore)
 				opts.OtherApps = otherApps
 				opts.PlatformName = s.PlatformName
 
@@ -140,6 +149,9 @@ func (r *TestcafeRunner) generateStartOpts(s testcafe.Suite) job.StartOptions {
 		ConfigFilePath:   r.Project.ConfigFilePath,
 		CLIFlags:         r.Project.CLIFlags,
 		DisplayName:      s.Name,
+		SuiteName:        s.Name,
+		Priority:         s.Priority,
+		DependsOn:        s.DependsOn,
 		Timeout:          s.Timeout,
 		Suite:            s.Name,
 		Framework:        "testcafe",
@@ -158,7 +170,7 @@ func (r *TestcafeRunner) generateStartOpts(s testcafe.Suite) job.StartOptions {
 		Experiments:      r.Project.Sauce.Experiments,
 		TimeZone:         s.TimeZone,
 		Visibility:       r.Project.Sauce.Visibility,
-		Retries:          r.Project.Sauce.Retries,
+		Retries:          config.SuiteRetries(r.Project.Sauce.Retries, s.Retries),
 		Attempt:          0,
 		PassThreshold:    s.PassThreshold,
 		SmartRetry: job.SmartRetry{
@@ -0,0 +1,83 @@
+package saucecloud
+
+import (
+	"sync"
+	"time"
+)
+
+// Freshly started jobs are routinely queued for a moment, so a job only counts as queued if it's still queued after a
+// grace period.
+const (
+	queuedGracePeriod   = 30 * time.Second
+	queuedCheckInterval = 5 * time.Second
+)
+
+// throttle limits the number of jobs that run at the same time in a cloud. The limit shrinks when jobs get queued,
+// since the account is out of concurrency then, and recovers with every job that starts right away.
+type throttle struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	// limit is the number of jobs that may currently run at the same time.
+	limit int
+	// max is the limit the throttle recovers to.
+	max int
+	// running is the number of jobs that are currently running.
+	running int
+
+	// grace is how long a job may be queued before the throttle backs off, and interval is how often it's checked.
+	grace    time.Duration
+	interval time.Duration
+}
+
+func newThrottle(limit int) *throttle {
+	t := &throttle{limit: limit, max: limit, grace: queuedGracePeriod, interval: queuedCheckInterval}
+	t.cond = sync.NewCond(&t.mu)
+	return t
+}
+
+// acquire blocks until another job may be started. A nil throttle doesn't limit anything.
+func (t *throttle) acquire() {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for t.running >= t.limit {
+		t.cond.Wait()
+	}
+	t.running++
+}
+
+// release frees up the slot of a job that is done.
+func (t *throttle) release() {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.running--
+	t.cond.Broadcast()
+}
+
+// started records whether a job got queued upon starting. The limit backs off to below the number of running jobs if
+// it did, and is otherwise raised by one, up to its maximum.
+func (t *throttle) started(queued bool) {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if queued {
+		t.limit = max(t.running-1, 1)
+	} else if t.limit < t.max {
+		t.limit++
+	}
+	t.cond.Broadcast()
+}
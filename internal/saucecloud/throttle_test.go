@@ -0,0 +1,141 @@
+package saucecloud
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/saucelabs/saucectl/internal/config"
+	"github.com/saucelabs/saucectl/internal/iam"
+	"github.com/saucelabs/saucectl/internal/job"
+	"github.com/saucelabs/saucectl/internal/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThrottle(t *testing.T) {
+	th := newThrottle(3)
+	for i := 0; i < 3; i++ {
+		th.acquire()
+	}
+
+	// The third job got queued, which leaves room for only one more job than are running after the next one is done.
+	th.started(true)
+	assert.Equal(t, 2, th.limit)
+
+	acquired := make(chan bool)
+	go func() {
+		th.acquire()
+		acquired <- true
+	}()
+
+	th.release()
+	select {
+	case <-acquired:
+		t.Fatal("throttle didn't back off")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	th.release()
+	receive(t, acquired)
+
+	// Jobs that start right away let the throttle recover, but not beyond its maximum.
+	th.started(false)
+	th.started(false)
+	assert.Equal(t, 3, th.limit)
+}
+
+func TestThrottle_Nil(t *testing.T) {
+	var th *throttle
+	th.acquire()
+	th.started(true)
+	th.release()
+}
+
+func TestCloudRunner_autoConcurrency(t *testing.T) {
+	ccy := iam.Concurrency{
+		Org: iam.OrgConcurrency{
+			Allowed: iam.CloudConcurrency{VDC: 20, RDC: 5},
+			Current: iam.CloudConcurrency{VDC: 4, RDC: 1},
+		},
+		Team: iam.TeamConcurrency{
+			Allowed: iam.CloudConcurrency{VDC: 10},
+			Current: iam.CloudConcurrency{VDC: 2},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		auto     config.AutoConcurrency
+		err      error
+		want     int
+		wantVDC  int
+		wantRDC  int
+		throttle bool
+	}{
+		{name: "limited by team", want: 8, wantVDC: 8, wantRDC: 4, throttle: true},
+		{name: "reserved", auto: config.AutoConcurrency{Reserve: 2}, want: 6, wantVDC: 6, wantRDC: 2, throttle: true},
+		{name: "capped", auto: config.AutoConcurrency{Max: 3}, want: 3, wantVDC: 3, wantRDC: 3, throttle: true},
+		{name: "unknown", err: errors.New("nope"), want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := CloudRunner{
+				AutoConcurrency: tt.auto,
+				UserService: &mocks.UserService{
+					ConcurrencyFn: func(ctx context.Context) (iam.Concurrency, error) {
+						return ccy, tt.err
+					},
+				},
+			}
+
+			assert.Equal(t, tt.want, r.autoConcurrency(2))
+			if !tt.throttle {
+				assert.Nil(t, r.throttleFor(false))
+				assert.Nil(t, r.throttleFor(true))
+				return
+			}
+			assert.Equal(t, tt.wantVDC, r.throttleFor(false).limit)
+			assert.Equal(t, tt.wantRDC, r.throttleFor(true).limit)
+		})
+	}
+}
+
+func TestCloudRunner_checkQueued(t *testing.T) {
+	tests := []struct {
+		name      string
+		statuses  []string
+		wantLimit int
+	}{
+		{name: "leaves the queue", statuses: []string{job.StateQueued, job.StateQueued, job.StateInProgress}, wantLimit: 3},
+		{name: "stays queued", statuses: []string{job.StateQueued}, wantLimit: 1},
+		{name: "starts slowly", statuses: []string{job.StateNew}, wantLimit: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reads int
+			r := CloudRunner{
+				JobService: JobService{
+					VDCReader: &mocks.FakeJobReader{
+						ReadJobFn: func(ctx context.Context, id string) (job.Job, error) {
+							status := tt.statuses[min(reads, len(tt.statuses)-1)]
+							reads++
+							return job.Job{ID: id, Status: status}, nil
+						},
+					},
+				},
+			}
+
+			th := newThrottle(3)
+			th.grace = 20 * time.Millisecond
+			th.interval = time.Millisecond
+			th.acquire()
+			th.acquire()
+			th.limit = 2
+
+			r.checkQueued("1", job.StartOptions{}, th)
+			assert.Equal(t, tt.wantLimit, th.limit)
+			assert.Greater(t, reads, 1)
+		})
+	}
+}
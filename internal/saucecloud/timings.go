@@ -0,0 +1,150 @@
+package saucecloud
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/rs/zerolog/log"
+	"github.com/saucelabs/saucectl/internal/config"
+	"github.com/saucelabs/saucectl/internal/insights"
+	"github.com/saucelabs/saucectl/internal/job"
+)
+
+// maxTimingRuns is the number of runs that the average duration of a suite is based on at most, so that recent runs
+// outweigh older ones.
+const maxTimingRuns = 10
+
+// Timings records the durations of suites across runs. It's kept locally, so that suites can be launched by their
+// duration even if Insights is not available.
+type Timings struct {
+	Suites map[string]SuiteTiming `json:"suites"`
+}
+
+// SuiteTiming records the duration of a single suite, which is identified by its name.
+type SuiteTiming struct {
+	// AvgDuration is the average duration of the suite in seconds.
+	AvgDuration float64 `json:"avgDuration"`
+	// Runs is the number of runs that the average is based on.
+	Runs int `json:"runs"`
+}
+
+// add records the final result of a suite. Only suites that ran to completion are recorded.
+func (t *Timings) add(res result) {
+	if res.skipped || res.resumed || res.job.TimedOut || !job.Done(res.job.Status) || res.duration <= 0 {
+		return
+	}
+	if t.Suites == nil {
+		t.Suites = make(map[string]SuiteTiming)
+	}
+
+	s := t.Suites[res.suite]
+	n := float64(min(s.Runs, maxTimingRuns-1))
+	s.AvgDuration = (s.AvgDuration*n + res.duration.Seconds()) / (n + 1)
+	s.Runs++
+	t.Suites[res.suite] = s
+}
+
+// history returns the timings as a job history, in descending order of duration.
+func (t Timings) history() insights.JobHistory {
+	var h insights.JobHistory
+	for name, s := range t.Suites {
+		h.TestCases = append(h.TestCases, insights.TestCase{Name: name, AvgDuration: s.AvgDuration})
+	}
+	sort.Slice(h.TestCases, func(i, j int) bool {
+		if h.TestCases[i].AvgDuration != h.TestCases[j].AvgDuration {
+			return h.TestCases[i].AvgDuration > h.TestCases[j].AvgDuration
+		}
+		return h.TestCases[i].Name < h.TestCases[j].Name
+	})
+	return h
+}
+
+// DefaultTimingsPath returns the file that the suite timings of the project in dir are recorded in by default. It's
+// kept in the home directory rather than in the project, so that it doesn't end up in the project archives.
+func DefaultTimingsPath(dir string) string {
+	homeDir, _ := os.UserHomeDir()
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	sum := sha256.Sum256([]byte(dir))
+	return filepath.Join(homeDir, ".sauce", "timings", hex.EncodeToString(sum[:8])+".json")
+}
+
+// ReadTimings reads the suite timings from filename. A file that doesn't exist yet yields no timings.
+func ReadTimings(filename string) (Timings, error) {
+	var t Timings
+	b, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return t, nil
+	}
+	if err != nil {
+		return t, fmt.Errorf("failed to read suite timings: %w", err)
+	}
+	if err := json.Unmarshal(b, &t); err != nil {
+		return t, fmt.Errorf("failed to parse suite timings: %w", err)
+	}
+	return t, nil
+}
+
+// Write writes the suite timings to filename.
+func (t Timings) Write(filename string) error {
+	b, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filename, b, 0644)
+}
+
+// durationHistory returns the historical durations of suites. They're retrieved from Insights, unless Insights is
+// unavailable or has no history for the project, in which case the local suite timings are used.
+func (r *CloudRunner) durationHistory() (insights.JobHistory, error) {
+	var insightsErr error
+	if !r.Local {
+		h, err := r.insightsHistory(config.LaunchOrderDuration)
+		if err == nil && len(h.TestCases) > 0 {
+			return h, nil
+		}
+		insightsErr = err
+	}
+	if r.TimingsPath == "" {
+		return insights.JobHistory{}, insightsErr
+	}
+
+	t, err := ReadTimings(r.TimingsPath)
+	if err != nil {
+		return insights.JobHistory{}, err
+	}
+	if insightsErr != nil {
+		log.Debug().Err(insightsErr).Str("timings", r.TimingsPath).
+			Msg("Failed to retrieve the suite durations from Insights. Using the local suite timings instead.")
+	}
+	return t.history(), nil
+}
+
+// recordTimings adds the durations of the suites of the run to the local suite timings.
+func (r *CloudRunner) recordTimings(results []result) {
+	if r.TimingsPath == "" || len(results) == 0 {
+		return
+	}
+
+	t, err := ReadTimings(r.TimingsPath)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to update the suite timings.")
+		return
+	}
+	for _, res := range results {
+		t.add(res)
+	}
+	if err := t.Write(r.TimingsPath); err != nil {
+		log.Warn().Err(err).Msg("Failed to update the suite timings.")
+	}
+}
@@ -0,0 +1,127 @@
+package saucecloud
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/saucelabs/saucectl/internal/config"
+	"github.com/saucelabs/saucectl/internal/iam"
+	"github.com/saucelabs/saucectl/internal/insights"
+	"github.com/saucelabs/saucectl/internal/job"
+	"github.com/saucelabs/saucectl/internal/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimings_add(t *testing.T) {
+	var timings Timings
+	done := job.Job{Status: job.StateComplete}
+
+	timings.add(result{suite: "a", job: done, duration: 10 * time.Second})
+	timings.add(result{suite: "a", job: done, duration: 20 * time.Second})
+	timings.add(result{suite: "b", job: done, duration: 30 * time.Second})
+	// Suites that didn't run to completion say nothing about their duration.
+	timings.add(result{suite: "c", job: job.Job{Status: job.StateComplete, TimedOut: true}, duration: time.Hour})
+	timings.add(result{suite: "c", skipped: true, duration: time.Second})
+	timings.add(result{suite: "c", resumed: true, job: job.Job{Status: job.StatePassed}, duration: time.Second})
+
+	assert.Equal(t, map[string]SuiteTiming{
+		"a": {AvgDuration: 15, Runs: 2},
+		"b": {AvgDuration: 30, Runs: 1},
+	}, timings.Suites)
+
+	assert.Equal(t, []insights.TestCase{
+		{Name: "b", AvgDuration: 30},
+		{Name: "a", AvgDuration: 15},
+	}, timings.history().TestCases)
+}
+
+func TestTimings_addRecent(t *testing.T) {
+	timings := Timings{Suites: map[string]SuiteTiming{"a": {AvgDuration: 10, Runs: 100}}}
+	timings.add(result{suite: "a", job: job.Job{Status: job.StateComplete}, duration: 20 * time.Second})
+
+	// Older runs weigh no more than maxTimingRuns-1 runs.
+	assert.Equal(t, SuiteTiming{AvgDuration: 11, Runs: 101}, timings.Suites["a"])
+}
+
+func TestCloudRunner_recordTimings(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), ".sauce", "timings.json")
+	r := CloudRunner{TimingsPath: filename}
+
+	timings, err := ReadTimings(filename)
+	assert.NoError(t, err)
+	assert.Empty(t, timings.Suites)
+
+	done := job.Job{Status: job.StateComplete}
+	r.recordTimings([]result{{suite: "a", job: done, duration: 10 * time.Second}})
+	r.recordTimings([]result{{suite: "a", job: done, duration: 20 * time.Second}})
+
+	timings, err = ReadTimings(filename)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]SuiteTiming{"a": {AvgDuration: 15, Runs: 2}}, timings.Suites)
+}
+
+func TestCloudRunner_durationHistory(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "timings.json")
+	local := Timings{Suites: map[string]SuiteTiming{"local": {AvgDuration: 60, Runs: 1}}}
+	assert.NoError(t, local.Write(filename))
+
+	fromInsights := insights.JobHistory{TestCases: []insights.TestCase{{Name: "insights", AvgDuration: 30}}}
+
+	tests := []struct {
+		name     string
+		history  insights.JobHistory
+		err      error
+		local    bool
+		timings  string
+		want     insights.JobHistory
+		wantErr  bool
+		wantCall bool
+	}{
+		{name: "insights", history: fromInsights, timings: filename, want: fromInsights, wantCall: true},
+		{name: "insights failed", err: errors.New("nope"), timings: filename, want: local.history(), wantCall: true},
+		{name: "no insights history", timings: filename, want: local.history(), wantCall: true},
+		{name: "local backend", local: true, timings: filename, want: local.history()},
+		{name: "no timings", err: errors.New("nope"), wantErr: true, wantCall: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var called bool
+			r := CloudRunner{
+				Local:       tt.local,
+				TimingsPath: tt.timings,
+				UserService: &mocks.UserService{
+					UserFn: func(ctx context.Context) (iam.User, error) {
+						return iam.User{}, nil
+					},
+				},
+				InsightsService: mocks.FakeInsightService{
+					GetHistoryFn: func(ctx context.Context, user iam.User, launchOrder config.LaunchOrder) (insights.JobHistory, error) {
+						called = true
+						assert.Equal(t, config.LaunchOrderDuration, launchOrder)
+						return tt.history, tt.err
+					},
+				},
+			}
+
+			got, err := r.getHistory(config.LaunchOrderDuration)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			assert.Equal(t, tt.wantCall, called)
+		})
+	}
+}
+
+func TestDefaultTimingsPath(t *testing.T) {
+	dir := t.TempDir()
+
+	assert.Equal(t, DefaultTimingsPath(dir), DefaultTimingsPath(filepath.Join(dir, "e2e", "..")))
+	assert.NotEqual(t, DefaultTimingsPath(dir), DefaultTimingsPath(t.TempDir()))
+	assert.Equal(t, "timings", filepath.Base(filepath.Dir(DefaultTimingsPath(dir))))
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/saucelabs/saucectl/internal/apps"
 	"github.com/saucelabs/saucectl/internal/archive/zip"
+	"github.com/saucelabs/saucectl/internal/config"
 	"github.com/saucelabs/saucectl/internal/job"
 	"github.com/saucelabs/saucectl/internal/msg"
 	"github.com/saucelabs/saucectl/internal/sauceignore"
@@ -72,7 +73,7 @@ func (r *XcuitestRunner) RunProject() (int, error) {
 				return app, nil
 			}
 
-			return archive(app, targetDir, archiveType)
+			return archiveApp(app, targetDir, archiveType)
 		})
 	}
 
@@ -163,7 +164,6 @@ func (r *XcuitestRunner) runSuites() bool {
 	if err != nil {
 		return false
 	}
-	defer close(results)
 
 	suites := r.Project.Suites
 	if r.Project.Sauce.LaunchOrder != "" {
@@ -178,6 +178,7 @@ func (r *XcuitestRunner) runSuites() bool {
 	// Submit suites to work on.
 	jobsCount := r.calculateJobsCount(suites)
 	go func() {
+		defer close(jobOpts)
 		for _, s := range suites {
 			for _, d := range enumerateDevices(s.Devices, s.Simulators) {
 				log.Debug().Str("suite", s.Name).Str("deviceName", d.name).Str("deviceID", d.ID).Str("platformVersion", d.platformVersion).Msg("Starting job")
@@ -190,10 +191,19 @@ func (r *XcuitestRunner) runSuites() bool {
 }
 
 func (r *XcuitestRunner) startJob(jobOpts chan<- job.StartOptions, appFileID, testAppFileID string, otherAppsIDs []string, s xcuitest.Suite, d deviceConfig) {
+	// Env vars are only honored by simulators. See xcuitest.Validate, which warns about them on real devices.
+	var env map[string]string
+	if !d.isRealDevice {
+		env = s.Env
+	}
+
 	jobOpts <- job.StartOptions{
 		ConfigFilePath:   r.Project.ConfigFilePath,
 		CLIFlags:         r.Project.CLIFlags,
 		DisplayName:      s.Name,
+		SuiteName:        s.Name,
+		Priority:         s.Priority,
+		DependsOn:        s.DependsOn,
 		Timeout:          s.Timeout,
 		App:              appFileID,
 		TestApp:          testAppFileID,
@@ -216,7 +226,7 @@ func (r *XcuitestRunner) startJob(jobOpts chan<- job.StartOptions, appFileID, te
 		TestsToRun:    s.TestOptions.Class,
 		TestsToSkip:   s.TestOptions.NotClass,
 		Attempt:       0,
-		Retries:       r.Project.Sauce.Retries,
+		Retries:       config.SuiteRetries(r.Project.Sauce.Retries, s.Retries),
 		PassThreshold: s.PassThreshold,
 		SmartRetry: job.SmartRetry{
 			FailedOnly: s.SmartRetry.IsRetryFailedOnly(),
@@ -230,7 +240,7 @@ func (r *XcuitestRunner) startJob(jobOpts chan<- job.StartOptions, appFileID, te
 		DevicePrivateOnly: d.privateOnly,
 
 		// VMD specific settings
-		Env: s.Env,
+		Env: env,
 
 		// Overwrite device settings
 		RealDeviceKind: strings.ToLower(xcuitest.IOS),
@@ -251,7 +261,7 @@ func (r *XcuitestRunner) calculateJobsCount(suites []xcuitest.Suite) int {
 	return jobsCount
 }
 
-func archive(src string, targetDir string, archiveType archiveType) (string, error) {
+func archiveApp(src string, targetDir string, archiveType archiveType) (string, error) {
 	switch archiveType {
 	case ipaArchive:
 		return archiveAppToIpa(src, targetDir)
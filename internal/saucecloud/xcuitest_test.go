@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/saucelabs/saucectl/internal/config"
+	"github.com/saucelabs/saucectl/internal/job"
 	"github.com/saucelabs/saucectl/internal/xcuitest"
 )
 
@@ -61,13 +62,13 @@ func TestXcuitestRunner_ensureAppsAreIpa(t *testing.T) {
 	originalAppPath := path.Join(dir.Path(), "my-app.app")
 	originalTestAppPath := path.Join(dir.Path(), "my-test-app.app")
 
-	appPath, err := archive(originalAppPath, tempDir.Path(), ipaArchive)
+	appPath, err := archiveApp(originalAppPath, tempDir.Path(), ipaArchive)
 	if err != nil {
 		t.Errorf("got error: %v", err)
 	}
 	defer os.Remove(appPath)
 
-	testAppPath, err := archive(originalTestAppPath, tempDir.Path(), ipaArchive)
+	testAppPath, err := archiveApp(originalTestAppPath, tempDir.Path(), ipaArchive)
 	if err != nil {
 		t.Errorf("got error: %v", err)
 	}
@@ -103,3 +104,16 @@ func checkFileFound(t *testing.T, archiveName, fileName, fileContent string) {
 		t.Errorf("%s was not found in archive", fileName)
 	}
 }
+
+func TestXcuitestRunner_startJob_Env(t *testing.T) {
+	r := &XcuitestRunner{}
+	s := xcuitest.Suite{Name: "suite", Env: map[string]string{"FOO": "bar"}}
+	jobOpts := make(chan job.StartOptions, 2)
+
+	r.startJob(jobOpts, "app", "testApp", nil, s, deviceConfig{name: "iPhone Simulator"})
+	r.startJob(jobOpts, "app", "testApp", nil, s, deviceConfig{name: "iPhone 14", isRealDevice: true})
+
+	assert.Equal(t, map[string]string{"FOO": "bar"}, (<-jobOpts).Env)
+	// Real devices don't honor env vars.
+	assert.Nil(t, (<-jobOpts).Env)
+}
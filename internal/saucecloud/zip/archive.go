@@ -2,6 +2,7 @@ package zip
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
@@ -9,6 +10,7 @@ import (
 	"time"
 
 	"github.com/rs/zerolog/log"
+	"github.com/saucelabs/saucectl/internal/archive"
 	"github.com/saucelabs/saucectl/internal/archive/zip"
 	"github.com/saucelabs/saucectl/internal/jsonio"
 	"github.com/saucelabs/saucectl/internal/msg"
@@ -50,67 +52,94 @@ func ArchiveRunnerConfig(project interface{}, tempDir string) (string, error) {
 	return zipName, nil
 }
 
-// ArchiveFiles walks through sourceDir, collects specified files, compresses them into target dir and returns the zip file path.
-func ArchiveFiles(targetFileName string, targetDir string, sourceDir string, files []string, matcher sauceignore.Matcher) (string, error) {
+// ArchiveFiles walks through sourceDir, collects specified files, archives them into target dir and returns the
+// archive path.
+func ArchiveFiles(targetFileName string, targetDir string, sourceDir string, files []string, matcher sauceignore.Matcher, opts archive.Options) (string, error) {
 	start := time.Now()
 
-	zipName := filepath.Join(targetDir, targetFileName+".zip")
-	z, err := zip.NewFileWriter(zipName, matcher)
+	archiveName := filepath.Join(targetDir, targetFileName+opts.Format.Extension())
+	f, err := os.Create(archiveName)
 	if err != nil {
 		return "", err
 	}
-	defer z.Close()
-
-	totalFileCount := 0
-	longestPathLength := 0
+	defer f.Close()
 
-	// Keep file order stable for consistent zip archives
-	sort.Strings(files)
-	for _, f := range files {
-		rel, err := filepath.Rel(sourceDir, filepath.Dir(f))
-		if err != nil {
-			return "", err
-		}
-		fileCount, length, err := z.Add(f, rel)
-		if err != nil {
-			return "", err
-		}
-		totalFileCount += fileCount
-		if length > longestPathLength {
-			longestPathLength = length
-		}
+	totalFileCount, longestPathLength, err := WriteFiles(f, sourceDir, files, matcher, opts)
+	if err != nil {
+		return "", err
 	}
 
-	err = z.Close()
-	if err != nil {
+	if err := f.Close(); err != nil {
 		return "", err
 	}
 
-	f, err := os.Stat(zipName)
+	finfo, err := os.Stat(archiveName)
 	if err != nil {
 		return "", err
 	}
 
 	log.Info().
 		Dur("durationMs", time.Since(start)).
-		Int64("size", f.Size()).
+		Int64("size", finfo.Size()).
 		Int("fileCount", totalFileCount).
 		Int("longestPathLength", longestPathLength).
 		Msg("Archive created.")
 
-	if totalFileCount >= ArchiveFileCountSoftLimit {
+	WarnLimits(totalFileCount, longestPathLength)
+
+	return archiveName, nil
+}
+
+// WriteFiles walks through sourceDir, collects specified files and archives them into w. Returns the count of the
+// archived files, as well as the length of the longest path. Archives of identical content are byte-identical, which
+// allows them to be created more than once, e.g. to compute their checksum and to upload them without ever writing
+// them to disk.
+func WriteFiles(w io.Writer, sourceDir string, files []string, matcher sauceignore.Matcher, opts archive.Options) (count int, length int, err error) {
+	opts.MaxPathLength = MaxFilepathLength - BaseFilepathLength
+	aw, err := archive.NewWriter(w, matcher, opts)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Keep file order stable for consistent archives
+	files = append([]string{}, files...)
+	sort.Strings(files)
+	for _, f := range files {
+		rel, err := filepath.Rel(sourceDir, filepath.Dir(f))
+		if err != nil {
+			return 0, 0, err
+		}
+		fileCount, pathLength, err := aw.Add(f, rel)
+		if err != nil {
+			return 0, 0, err
+		}
+		count += fileCount
+		if pathLength > length {
+			length = pathLength
+		}
+	}
+
+	if err := aw.Close(); err != nil {
+		return 0, 0, err
+	}
+
+	return count, length, nil
+}
+
+// WarnLimits warns if an archive with the given count of files and longest path length is likely to cause issues
+// when it's unpacked by the runner.
+func WarnLimits(fileCount int, longestPathLength int) {
+	if fileCount >= ArchiveFileCountSoftLimit {
 		msg.LogArchiveSizeWarning()
 	}
 
 	if longestPathLength+BaseFilepathLength > MaxFilepathLength {
 		msg.LogArchivePathLengthWarning(MaxFilepathLength - BaseFilepathLength)
 	}
-
-	return zipName, nil
 }
 
 // ArchiveNodeModules collects npm dependencies from sourceDir and compresses them into targetDir.
-func ArchiveNodeModules(targetDir string, sourceDir string, matcher sauceignore.Matcher, dependencies []string) (string, error) {
+func ArchiveNodeModules(targetDir string, sourceDir string, matcher sauceignore.Matcher, dependencies []string, opts archive.Options) (string, error) {
 	modDir := filepath.Join(sourceDir, "node_modules")
 	ignored := matcher.Match(strings.Split(modDir, string(os.PathSeparator)), true)
 
@@ -153,5 +182,5 @@ func ArchiveNodeModules(targetDir string, sourceDir string, matcher sauceignore.
 		files = append(files, filepath.Join(sourceDir, "node_modules"))
 	}
 
-	return ArchiveFiles("node_modules", targetDir, sourceDir, files, matcher)
+	return ArchiveFiles("node_modules", targetDir, sourceDir, files, matcher, opts)
 }
@@ -0,0 +1,203 @@
+package zip
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/saucelabs/saucectl/internal/archive"
+	"github.com/saucelabs/saucectl/internal/sauceignore"
+)
+
+// cacheVersion is part of every cache key. Changing it invalidates all previously cached archives, e.g. when the
+// archive layout changes.
+const cacheVersion = "1"
+
+// maxCachedNodeModules is the number of node_modules archives that are kept in the local cache. Every change to a
+// lockfile adds a new archive, so the least recently used ones are evicted beyond that.
+const maxCachedNodeModules = 5
+
+// lockfiles are the package manager lockfiles that pin the content of node_modules.
+var lockfiles = []string{"package-lock.json", "npm-shrinkwrap.json", "yarn.lock", "pnpm-lock.yaml"}
+
+// CacheDir returns the directory in which archives are cached locally.
+func CacheDir() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".sauce", "cache")
+}
+
+// NodeModulesCacheKey returns a key that identifies the node_modules archive of sourceDir, without having to archive
+// it. The key is derived from the lockfiles in sourceDir, the selected dependencies and the content of the
+// sauceignore file. Returns an empty key if the node_modules archive can't be identified this way, i.e. if there's no
+// lockfile, or if there's no node_modules folder to archive in the first place.
+func NodeModulesCacheKey(sourceDir string, matcher sauceignore.Matcher, dependencies []string, sauceignoreFile string) (string, error) {
+	modDir := filepath.Join(sourceDir, "node_modules")
+	if _, err := os.Stat(modDir); err != nil {
+		return "", nil
+	}
+	if matcher.Match(strings.Split(modDir, string(os.PathSeparator)), true) {
+		return "", nil
+	}
+
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "v%s %s/%s\n", cacheVersion, runtime.GOOS, runtime.GOARCH)
+
+	found := false
+	for _, name := range lockfiles {
+		ok, err := hashFile(h, filepath.Join(sourceDir, name))
+		if err != nil {
+			return "", err
+		}
+		found = found || ok
+	}
+	if !found {
+		return "", nil
+	}
+
+	deps := append([]string{}, dependencies...)
+	sort.Strings(deps)
+	_, _ = fmt.Fprintf(h, "dependencies %s\n", strings.Join(deps, ","))
+
+	if _, err := hashFile(h, sauceignoreFile); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// NodeModulesArchiveName returns the file name of the node_modules archive in the given format that's identified by
+// key.
+func NodeModulesArchiveName(key string, format archive.Format) string {
+	return fmt.Sprintf("node_modules-%s%s", key, format.Extension())
+}
+
+// CachedNodeModules returns the path of the locally cached node_modules archive that's identified by key. Returns
+// false if there's no such archive.
+func CachedNodeModules(key string, format archive.Format) (string, bool) {
+	name := filepath.Join(CacheDir(), NodeModulesArchiveName(key, format))
+	if _, err := os.Stat(name); err != nil {
+		return "", false
+	}
+
+	// Mark the archive as recently used, so that it's evicted last.
+	now := time.Now()
+	_ = os.Chtimes(name, now, now)
+
+	return name, true
+}
+
+// CacheNodeModules moves the node_modules archive into the local cache, identified by key, and returns its new path.
+// The least recently used archives are evicted from the cache, so that it keeps at most maxCachedNodeModules.
+func CacheNodeModules(archiveName, key string, format archive.Format) (string, error) {
+	name, err := cacheNodeModules(archiveName, key, format)
+	if err != nil {
+		return "", err
+	}
+
+	if err := pruneNodeModulesCache(maxCachedNodeModules); err != nil {
+		log.Warn().Err(err).Msg("Failed to evict old node_modules archives from the cache.")
+	}
+	return name, nil
+}
+
+func cacheNodeModules(archiveName, key string, format archive.Format) (string, error) {
+	if err := os.MkdirAll(CacheDir(), 0700); err != nil {
+		return "", err
+	}
+
+	name := filepath.Join(CacheDir(), NodeModulesArchiveName(key, format))
+	if err := os.Rename(archiveName, name); err == nil {
+		return name, nil
+	}
+
+	// Renaming fails if the archive is on a different device, in which case we have to copy it instead.
+	src, err := os.Open(archiveName)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	tmp, err := os.CreateTemp(CacheDir(), "node_modules-*.tmp")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, src); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+
+	return name, os.Rename(tmp.Name(), name)
+}
+
+// pruneNodeModulesCache removes all but the keep most recently used node_modules archives from the local cache.
+func pruneNodeModulesCache(keep int) error {
+	matches, err := filepath.Glob(filepath.Join(CacheDir(), "node_modules-*"))
+	if err != nil {
+		return err
+	}
+
+	type entry struct {
+		name    string
+		modTime time.Time
+	}
+	var entries []entry
+	for _, m := range matches {
+		// Skip archives that are still being copied into the cache.
+		if strings.HasSuffix(m, ".tmp") {
+			continue
+		}
+		info, err := os.Stat(m)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, entry{name: m, modTime: info.ModTime()})
+	}
+	if len(entries) <= keep {
+		return nil
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].modTime.After(entries[j].modTime)
+	})
+	for _, e := range entries[keep:] {
+		if err := os.Remove(e.name); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// hashFile writes the name and content of the file into h. Returns false if the file doesn't exist.
+func hashFile(h io.Writer, name string) (bool, error) {
+	if name == "" {
+		return false, nil
+	}
+
+	f, err := os.Open(name)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	_, _ = fmt.Fprintf(h, "%s\n", filepath.Base(name))
+	if _, err := io.Copy(h, f); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,102 @@
+package zip
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gotest.tools/v3/fs"
+
+	"github.com/saucelabs/saucectl/internal/archive"
+	"github.com/saucelabs/saucectl/internal/sauceignore"
+)
+
+func TestNodeModulesCacheKey(t *testing.T) {
+	matcher := sauceignore.NewMatcher([]sauceignore.Pattern{})
+	newProject := func(ops ...fs.PathOp) string {
+		dir := fs.NewDir(t, "project", append(ops, fs.WithDir("node_modules"))...)
+		t.Cleanup(dir.Remove)
+		return dir.Path()
+	}
+
+	npm := newProject(fs.WithFile("package-lock.json", "v1"))
+	key, err := NodeModulesCacheKey(npm, matcher, []string{"b", "a"}, "")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, key)
+
+	// The key only depends on content, not on the location of the project or the order of dependencies.
+	sameKey, err := NodeModulesCacheKey(newProject(fs.WithFile("package-lock.json", "v1")), matcher, []string{"a", "b"}, "")
+	assert.NoError(t, err)
+	assert.Equal(t, key, sameKey)
+
+	changedLock, _ := NodeModulesCacheKey(newProject(fs.WithFile("package-lock.json", "v2")), matcher, []string{"a", "b"}, "")
+	assert.NotEqual(t, key, changedLock)
+
+	changedDeps, _ := NodeModulesCacheKey(npm, matcher, []string{"a"}, "")
+	assert.NotEqual(t, key, changedDeps)
+
+	yarn, _ := NodeModulesCacheKey(newProject(fs.WithFile("yarn.lock", "v1")), matcher, []string{"a", "b"}, "")
+	assert.NotEqual(t, key, yarn)
+
+	ignoreFile := fs.NewFile(t, "sauceignore", fs.WithContent("*.mp4"))
+	defer ignoreFile.Remove()
+	withIgnore, _ := NodeModulesCacheKey(npm, matcher, []string{"a", "b"}, ignoreFile.Path())
+	assert.NotEqual(t, key, withIgnore)
+
+	noLock, err := NodeModulesCacheKey(newProject(), matcher, nil, "")
+	assert.NoError(t, err)
+	assert.Empty(t, noLock)
+
+	noMods := fs.NewDir(t, "project", fs.WithFile("package-lock.json", "v1"))
+	defer noMods.Remove()
+	noModsKey, err := NodeModulesCacheKey(noMods.Path(), matcher, nil, "")
+	assert.NoError(t, err)
+	assert.Empty(t, noModsKey)
+}
+
+func TestCacheNodeModules(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", os.Getenv("HOME"))
+
+	_, ok := CachedNodeModules("key", archive.FormatZip)
+	assert.False(t, ok)
+
+	modZip := fs.NewFile(t, "node_modules.zip", fs.WithContent("zip"))
+	cached, err := CacheNodeModules(modZip.Path(), "key", archive.FormatZip)
+	assert.NoError(t, err)
+
+	got, ok := CachedNodeModules("key", archive.FormatZip)
+	assert.True(t, ok)
+	assert.Equal(t, cached, got)
+}
+
+func TestCacheNodeModules_Evict(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", os.Getenv("HOME"))
+
+	start := time.Now().Add(-time.Hour)
+	for i := 0; i < maxCachedNodeModules; i++ {
+		modZip := fs.NewFile(t, "node_modules.zip", fs.WithContent("zip"))
+		cached, err := CacheNodeModules(modZip.Path(), fmt.Sprintf("key%d", i), archive.FormatZip)
+		assert.NoError(t, err)
+		modTime := start.Add(time.Duration(i) * time.Minute)
+		assert.NoError(t, os.Chtimes(cached, modTime, modTime))
+	}
+
+	// Using the oldest archive makes the second oldest one the least recently used.
+	_, ok := CachedNodeModules("key0", archive.FormatZip)
+	assert.True(t, ok)
+
+	modZip := fs.NewFile(t, "node_modules.zip", fs.WithContent("zip"))
+	_, err := CacheNodeModules(modZip.Path(), "new", archive.FormatZip)
+	assert.NoError(t, err)
+
+	_, ok = CachedNodeModules("key1", archive.FormatZip)
+	assert.False(t, ok)
+	for _, key := range []string{"key0", "key2", "key3", "key4", "new"} {
+		_, ok = CachedNodeModules(key, archive.FormatZip)
+		assert.True(t, ok, key)
+	}
+}
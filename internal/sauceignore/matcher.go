@@ -2,7 +2,10 @@ package sauceignore
 
 import (
 	"bufio"
+	"fmt"
 	"os"
+	"path"
+	"path/filepath"
 	"strings"
 
 	"github.com/saucelabs/saucectl/internal/msg"
@@ -10,40 +13,83 @@ import (
 	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
 )
 
-const commentPrefix = "#"
+const (
+	commentPrefix = "#"
+	includePrefix = "#include "
+	negatePrefix  = "!"
+)
 
 // PatternsFromFile reads .sauceignore file and creates ignore patters if .sauceignore file is exists.
+// Other files can be included with the '#include <path>' directive, where path is relative to the including file.
+// Patterns of an included file are inserted in place of the directive.
 func PatternsFromFile(path string) ([]Pattern, error) {
 	if path == "" {
 		return []Pattern{}, nil
 	}
 
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		// In case .sauceignore file doesn't exists.
+		msg.LogSauceIgnoreNotExist()
+		return []Pattern{}, nil
+	}
+
+	return readPatterns(path, map[string]bool{})
+}
+
+// readPatterns reads the patterns from the file at path, resolving any includes. Files that are currently being read
+// are tracked by visiting, in order to detect include cycles.
+func readPatterns(path string, visiting map[string]bool) ([]Pattern, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if visiting[abs] {
+		return nil, fmt.Errorf("sauceignore: include cycle detected at %s", path)
+	}
+	visiting[abs] = true
+	defer delete(visiting, abs)
+
 	f, err := os.Open(path)
 	if err != nil {
-		// In case .sauceignore file doesn't exists.
-		if os.IsNotExist(err) {
-			msg.LogSauceIgnoreNotExist()
-			return []Pattern{}, nil
-		}
 		return []Pattern{}, err
 	}
 	defer f.Close()
 
-	var ps []Pattern
+	ps := []Pattern{}
 	scanner := bufio.NewScanner(f)
+	line := 0
 	for scanner.Scan() {
+		line++
 		s := scanner.Text()
+
+		if include, ok := strings.CutPrefix(s, includePrefix); ok {
+			include = strings.TrimSpace(include)
+			if !filepath.IsAbs(include) {
+				include = filepath.Join(filepath.Dir(path), include)
+			}
+			included, err := readPatterns(include, visiting)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+			}
+			ps = append(ps, included...)
+			continue
+		}
+
 		if !strings.HasPrefix(s, commentPrefix) && len(strings.TrimSpace(s)) > 0 {
-			ps = append(ps, NewPattern(s))
+			ps = append(ps, Pattern{P: s, Source: path, Line: line})
 		}
 	}
 
-	return ps, nil
+	return ps, scanner.Err()
 }
 
 // Pattern defines a single sauceignore pattern.
 type Pattern struct {
 	P string
+	// Source is the file the pattern was read from. Empty if the pattern wasn't read from a file.
+	Source string
+	// Line is the line number of the pattern within Source.
+	Line int
 }
 
 // NewPattern create new Pattern.
@@ -51,6 +97,19 @@ func NewPattern(p string) Pattern {
 	return Pattern{P: p}
 }
 
+// IsNegated returns true if the pattern re-includes the paths it matches.
+func (p Pattern) IsNegated() bool {
+	return strings.HasPrefix(p.P, negatePrefix)
+}
+
+// String returns the pattern, prefixed by its location if it was read from a file.
+func (p Pattern) String() string {
+	if p.Source == "" {
+		return p.P
+	}
+	return fmt.Sprintf("%s:%d: %s", p.Source, p.Line, p.P)
+}
+
 func convPtrnsToGitignorePtrns(pp []Pattern) []gitignore.Pattern {
 	res := make([]gitignore.Pattern, len(pp))
 	for i := 0; i < len(pp); i++ {
@@ -62,22 +121,79 @@ func convPtrnsToGitignorePtrns(pp []Pattern) []gitignore.Pattern {
 
 // Matcher defines matcher for sauceignore patterns.
 type Matcher interface {
+	// Match returns true if path is ignored.
 	Match(path []string, isDir bool) bool
+	// Explain returns the pattern that decides whether path is ignored, which is the last pattern that matches it.
+	// Returns false if no pattern matches path.
+	Explain(path []string, isDir bool) (Pattern, bool)
+	// MayReinclude returns true if a negated pattern may re-include paths within the directory dir, even if dir
+	// itself is ignored.
+	MayReinclude(dir []string) bool
 }
 
 type matcher struct {
-	matcher gitignore.Matcher
+	patterns []Pattern
+	parsed   []gitignore.Pattern
 }
 
 // Match matches patterns.
 func (m *matcher) Match(path []string, isDir bool) bool {
-	return m.matcher.Match(path, isDir)
+	p, ok := m.Explain(path, isDir)
+	return ok && !p.IsNegated()
+}
+
+// Explain returns the pattern that decides whether path is ignored.
+func (m *matcher) Explain(path []string, isDir bool) (Pattern, bool) {
+	for i := len(m.parsed) - 1; i >= 0; i-- {
+		if m.parsed[i].Match(path, isDir) != gitignore.NoMatch {
+			return m.patterns[i], true
+		}
+	}
+	return Pattern{}, false
+}
+
+// MayReinclude returns true if any negated pattern could match a path within dir. Only negated patterns with a slash
+// are considered, of which those with a ** segment match within any dir that precedes it.
+func (m *matcher) MayReinclude(dir []string) bool {
+	for _, p := range m.patterns {
+		if p.IsNegated() && mayMatchWithin(strings.TrimPrefix(p.P, negatePrefix), dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// mayMatchWithin returns true if the (non-negated) pattern p could match a path within dir.
+func mayMatchWithin(p string, dir []string) bool {
+	p = strings.TrimSuffix(strings.TrimSpace(p), "/")
+
+	// Patterns without a slash match names at any depth, but like in gitignore they don't re-include anything within
+	// ignored directories. Otherwise, a pattern like !*.md would have every ignored directory, node_modules and .git
+	// included, walked through.
+	if !strings.Contains(p, "/") {
+		return false
+	}
+
+	segments := strings.Split(strings.TrimPrefix(p, "/"), "/")
+	for i, d := range dir {
+		if i >= len(segments) {
+			// The pattern matches dir or one of its parents, which has already been considered when matching dir.
+			return false
+		}
+		if segments[i] == "**" {
+			return true
+		}
+		if ok, _ := path.Match(segments[i], d); !ok {
+			return false
+		}
+	}
+
+	return len(segments) > len(dir)
 }
 
 // NewMatcher constructs a new matcher.
 func NewMatcher(ps []Pattern) Matcher {
-	gps := convPtrnsToGitignorePtrns(ps)
-	return &matcher{matcher: gitignore.NewMatcher(gps)}
+	return &matcher{patterns: ps, parsed: convPtrnsToGitignorePtrns(ps)}
 }
 
 // NewMatcherFromFile constructs a new matcher from file.
@@ -90,13 +206,14 @@ func NewMatcherFromFile(path string) (Matcher, error) {
 	return NewMatcher(ps), nil
 }
 
-// Dedupe takes a list of patterns and returns them back sans any duplicates.
+// Dedupe takes a list of patterns and returns them back sans any duplicates. Patterns are duplicates if they're the
+// same, regardless of where they were read from. The first occurrence is kept.
 func Dedupe(patterns []Pattern) []Pattern {
-	hash := make(map[Pattern]struct{})
+	hash := make(map[string]struct{})
 	var list []Pattern
 	for _, p := range patterns {
-		if _, ok := hash[p]; !ok {
-			hash[p] = struct{}{}
+		if _, ok := hash[p.P]; !ok {
+			hash[p.P] = struct{}{}
 			list = append(list, p)
 		}
 	}
@@ -184,6 +184,15 @@ func TestDedupe(t *testing.T) {
 			args: args{[]Pattern{NewPattern("a"), NewPattern("b"), NewPattern("c")}},
 			want: []Pattern{NewPattern("a"), NewPattern("b"), NewPattern("c")},
 		},
+		{
+			name: "remove duplicate from another source",
+			args: args{[]Pattern{
+				{P: "a", Source: ".sauceignore", Line: 1},
+				{P: "a", Source: "suite/.sauceignore", Line: 3},
+				{P: "b", Source: ".sauceignore", Line: 2},
+			}},
+			want: []Pattern{{P: "a", Source: ".sauceignore", Line: 1}, {P: "b", Source: ".sauceignore", Line: 2}},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -193,3 +202,144 @@ func TestDedupe(t *testing.T) {
 		})
 	}
 }
+
+func TestPatternsFromFile_Include(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	write("shared/common.ignore", "node_modules/\n#include nested.ignore\n")
+	write("shared/nested.ignore", "*.log\n")
+	root := write(".sauceignore", "videos/\n#include shared/common.ignore\n!keep.log\n")
+
+	got, err := PatternsFromFile(root)
+	if err != nil {
+		t.Fatalf("PatternsFromFile() error = %v", err)
+	}
+	want := []Pattern{
+		{P: "videos/", Source: root, Line: 1},
+		{P: "node_modules/", Source: filepath.Join(dir, "shared", "common.ignore"), Line: 1},
+		{P: "*.log", Source: filepath.Join(dir, "shared", "nested.ignore"), Line: 1},
+		{P: "!keep.log", Source: root, Line: 3},
+	}
+	assert.DeepEqual(t, got, want)
+
+	cyclic := write("cyclic.ignore", "#include cyclic.ignore\n")
+	if _, err := PatternsFromFile(cyclic); err == nil {
+		t.Error("PatternsFromFile() expected error for include cycle")
+	}
+
+	missing := write("missing.ignore", "#include does-not-exist.ignore\n")
+	if _, err := PatternsFromFile(missing); err == nil {
+		t.Error("PatternsFromFile() expected error for missing include")
+	}
+}
+
+func TestMatcher_Explain(t *testing.T) {
+	ignore := Pattern{P: "cypress/", Source: ".sauceignore", Line: 1}
+	reinclude := Pattern{P: "!cypress/e2e/", Source: ".sauceignore", Line: 2}
+	m := NewMatcher([]Pattern{ignore, reinclude})
+
+	testCases := []struct {
+		name        string
+		path        []string
+		isDir       bool
+		wantPattern Pattern
+		wantOK      bool
+	}{
+		{
+			name:        "ignored",
+			path:        []string{"cypress", "videos", "login.mp4"},
+			wantPattern: ignore,
+			wantOK:      true,
+		},
+		{
+			name:        "re-included",
+			path:        []string{"cypress", "e2e", "login.cy.js"},
+			wantPattern: reinclude,
+			wantOK:      true,
+		},
+		{
+			name: "no match",
+			path: []string{"package.json"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, ok := m.Explain(tc.path, tc.isDir)
+			assert.Equal(t, ok, tc.wantOK)
+			assert.Equal(t, p, tc.wantPattern)
+		})
+	}
+}
+
+func TestMatcher_MayReinclude(t *testing.T) {
+	testCases := []struct {
+		name     string
+		patterns []Pattern
+		dir      []string
+		want     bool
+	}{
+		{
+			name:     "no negation",
+			patterns: []Pattern{NewPattern("cypress/")},
+			dir:      []string{"cypress"},
+			want:     false,
+		},
+		{
+			name:     "negated subdirectory",
+			patterns: []Pattern{NewPattern("cypress/"), NewPattern("!cypress/e2e/")},
+			dir:      []string{"cypress"},
+			want:     true,
+		},
+		{
+			name:     "negated glob in subdirectory",
+			patterns: []Pattern{NewPattern("cypress/"), NewPattern("!cypress/*/login.cy.js")},
+			dir:      []string{"cypress", "e2e"},
+			want:     true,
+		},
+		{
+			name:     "negation in other directory",
+			patterns: []Pattern{NewPattern("cypress/"), NewPattern("!tests/e2e/")},
+			dir:      []string{"cypress"},
+			want:     false,
+		},
+		{
+			name:     "negated parent",
+			patterns: []Pattern{NewPattern("!/cypress/"), NewPattern("cypress/videos/")},
+			dir:      []string{"cypress", "videos"},
+			want:     false,
+		},
+		{
+			name:     "negated file name",
+			patterns: []Pattern{NewPattern("cypress/"), NewPattern("!*.json")},
+			dir:      []string{"cypress"},
+			want:     false,
+		},
+		{
+			name:     "negated name in ignored dependencies",
+			patterns: []Pattern{NewPattern("node_modules/"), NewPattern("!README")},
+			dir:      []string{"node_modules"},
+			want:     false,
+		},
+		{
+			name:     "negated double star",
+			patterns: []Pattern{NewPattern("cypress/"), NewPattern("!**/fixtures/")},
+			dir:      []string{"cypress"},
+			want:     true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, NewMatcher(tc.patterns).MayReinclude(tc.dir), tc.want)
+		})
+	}
+}
@@ -84,24 +84,29 @@ func GetFailedTests(report SauceReport) []string {
 }
 
 func collectFailedTests(suite Suite) []string {
-	if len(suite.Suites) == 0 && len(suite.Tests) == 0 {
-		return []string{}
+	var failedTests []string
+	for _, t := range suite.FailedTests() {
+		failedTests = append(failedTests, t.Name)
 	}
-	if suite.Status == StatusPassed || suite.Status == StatusSkipped {
-		return []string{}
+	return failedTests
+}
+
+// FailedTests returns the failed tests of the suite, including those of nested suites.
+func (s Suite) FailedTests() []Test {
+	if s.Status == StatusPassed || s.Status == StatusSkipped {
+		return nil
 	}
 
-	var failedTests []string
-	for _, s := range suite.Suites {
-		if s.Status == StatusFailed {
-			failedTests = append(failedTests, collectFailedTests(s)...)
+	var failedTests []Test
+	for _, ss := range s.Suites {
+		if ss.Status == StatusFailed {
+			failedTests = append(failedTests, ss.FailedTests()...)
 		}
 	}
-	for _, t := range suite.Tests {
+	for _, t := range s.Tests {
 		if t.Status == StatusFailed {
-			failedTests = append(failedTests, t.Name)
+			failedTests = append(failedTests, t)
 		}
 	}
-
 	return failedTests
 }
@@ -0,0 +1,76 @@
+package secret
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ReadEnvFile parses the env file name. Each line is expected to be in the form of KEY=VALUE. Empty lines, comments
+// (lines starting with #) and an optional leading 'export' are ignored. Values may be single or double-quoted.
+func ReadEnvFile(name string) (map[string]string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	env := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	n := 0
+	for scanner.Scan() {
+		n++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		k, v, ok := strings.Cut(line, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return nil, fmt.Errorf("%s:%d: invalid line, expected KEY=VALUE", name, n)
+		}
+		env[k] = unquote(strings.TrimSpace(v))
+	}
+
+	return env, scanner.Err()
+}
+
+// LoadEnvFile reads the env file name and sets its variables in the environment of the current process. Variables
+// that are already set take precedence. Loaded variables are treated as secrets once they are expanded.
+func LoadEnvFile(name string) error {
+	env, err := ReadEnvFile(name)
+	if err != nil {
+		return fmt.Errorf("failed to load env file: %w", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for k, v := range env {
+		if _, ok := os.LookupEnv(k); ok {
+			continue
+		}
+		if err := os.Setenv(k, v); err != nil {
+			return err
+		}
+		envKeys[k] = true
+	}
+
+	return nil
+}
+
+func unquote(v string) string {
+	if len(v) < 2 {
+		return v
+	}
+	if (v[0] == '"' && v[len(v)-1] == '"') || (v[0] == '\'' && v[len(v)-1] == '\'') {
+		return v[1 : len(v)-1]
+	}
+	// Strip trailing inline comments of unquoted values.
+	if i := strings.Index(v, " #"); i >= 0 {
+		return strings.TrimSpace(v[:i])
+	}
+	return v
+}
@@ -0,0 +1,154 @@
+// Package secret resolves secret references in configuration values and keeps track of the resolved values, so that
+// they can be masked in any output that saucectl produces.
+package secret
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Mask is the replacement for secret values.
+const Mask = "****"
+
+// minLength is the minimum length of a value to be considered for masking. Shorter values would otherwise lead to
+// overly aggressive masking of unrelated output.
+const minLength = 4
+
+// Reference prefixes.
+const (
+	filePrefix = "file:"
+	cmdPrefix  = "cmd:"
+)
+
+var (
+	mu       sync.RWMutex
+	values   = map[string]bool{}
+	resolved = map[string]string{}
+	envKeys  = map[string]bool{}
+)
+
+// Register marks v as a secret value that is to be masked.
+func Register(v string) {
+	v = strings.TrimSpace(v)
+	if len(v) < minLength {
+		return
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	values[v] = true
+}
+
+// MaskString replaces all registered secret values in s.
+func MaskString(s string) string {
+	for _, v := range sorted() {
+		s = strings.ReplaceAll(s, v, Mask)
+	}
+	return s
+}
+
+// MaskBytes replaces all registered secret values in b.
+func MaskBytes(b []byte) []byte {
+	for _, v := range sorted() {
+		b = bytes.ReplaceAll(b, []byte(v), []byte(Mask))
+	}
+	return b
+}
+
+// sorted returns the registered values, longest first, so that values that contain other values are masked in full.
+func sorted() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	vv := make([]string, 0, len(values))
+	for v := range values {
+		vv = append(vv, v)
+	}
+	sort.Slice(vv, func(i, j int) bool {
+		return len(vv[i]) > len(vv[j])
+	})
+	return vv
+}
+
+// Expand replaces $var and ${var} in s. Besides environment variables, the following secret references are supported:
+//
+//	${file:path}    the contents of the file at path
+//	${cmd:command}  the output of command
+//
+// Resolved secret references and environment variables that were loaded from an env file are registered as secrets.
+func Expand(s string) (string, error) {
+	var errs []error
+	expanded := os.Expand(s, func(name string) string {
+		v, err := lookup(name)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		return v
+	})
+
+	return expanded, errors.Join(errs...)
+}
+
+func lookup(name string) (string, error) {
+	if !strings.HasPrefix(name, filePrefix) && !strings.HasPrefix(name, cmdPrefix) {
+		v := os.Getenv(name)
+
+		mu.RLock()
+		fromEnvFile := envKeys[name]
+		mu.RUnlock()
+		if fromEnvFile {
+			Register(v)
+		}
+		return v, nil
+	}
+
+	mu.RLock()
+	v, ok := resolved[name]
+	mu.RUnlock()
+	if ok {
+		return v, nil
+	}
+
+	v, err := resolve(name)
+	if err != nil {
+		return "", err
+	}
+
+	mu.Lock()
+	resolved[name] = v
+	mu.Unlock()
+	Register(v)
+
+	return v, nil
+}
+
+func resolve(ref string) (string, error) {
+	if path, ok := strings.CutPrefix(ref, filePrefix); ok {
+		b, err := os.ReadFile(strings.TrimSpace(path))
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve secret reference '%s': %w", ref, err)
+		}
+		return strings.TrimRight(string(b), "\r\n"), nil
+	}
+
+	command, _ := strings.CutPrefix(ref, cmdPrefix)
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.Command("cmd", "/C", command)
+	} else {
+		c = exec.Command("sh", "-c", command)
+	}
+	c.Stderr = os.Stderr
+	out, err := c.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve secret reference '%s': %w", ref, err)
+	}
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
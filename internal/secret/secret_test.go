@@ -0,0 +1,185 @@
+package secret
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+
+	"gotest.tools/v3/fs"
+)
+
+func reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	values = map[string]bool{}
+	resolved = map[string]string{}
+	envKeys = map[string]bool{}
+}
+
+func TestExpand(t *testing.T) {
+	dir := fs.NewDir(t, "secrets", fs.WithFile("token", "s3cr3t-token\n"))
+	defer dir.Remove()
+
+	t.Setenv("SAUCE_TEST_VAR", "plain-value")
+
+	tests := []struct {
+		name       string
+		input      string
+		want       string
+		wantMasked string
+		wantErr    bool
+	}{
+		{
+			name:       "env var is not a secret",
+			input:      "$SAUCE_TEST_VAR",
+			want:       "plain-value",
+			wantMasked: "plain-value",
+		},
+		{
+			name:       "file reference",
+			input:      "token: ${file:" + dir.Join("token") + "}",
+			want:       "token: s3cr3t-token",
+			wantMasked: "token: ****",
+		},
+		{
+			name:       "command reference",
+			input:      "${cmd:echo from-command}",
+			want:       "from-command",
+			wantMasked: "****",
+		},
+		{
+			name:    "missing file",
+			input:   "${file:" + dir.Join("missing") + "}",
+			wantErr: true,
+		},
+		{
+			name:    "failing command",
+			input:   "${cmd:exit 1}",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reset()
+
+			got, err := Expand(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Expand() got = %q, want %q", got, tt.want)
+			}
+			if masked := MaskString(got); masked != tt.wantMasked {
+				t.Errorf("MaskString() got = %q, want %q", masked, tt.wantMasked)
+			}
+		})
+	}
+}
+
+func TestMaskBytes(t *testing.T) {
+	reset()
+	Register("secret")
+	Register("secret-but-longer")
+	Register("abc")
+
+	got := MaskBytes([]byte("secret, secret-but-longer, abc"))
+	want := []byte("****, ****, abc")
+	if !bytes.Equal(got, want) {
+		t.Errorf("MaskBytes() got = %q, want %q", got, want)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	reset()
+	Register("hunter2")
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	n, err := w.Write([]byte("password=hunter2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len("password=hunter2") {
+		t.Errorf("Write() got n = %d", n)
+	}
+	if buf.String() != "password=****" {
+		t.Errorf("Write() got = %q", buf.String())
+	}
+}
+
+func TestReadEnvFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "all syntax variants",
+			content: `# comment
+PLAIN=value
+export EXPORTED=yes
+DOUBLE="quoted value"
+SINGLE='single # not a comment'
+INLINE=value # comment
+
+EMPTY=
+`,
+			want: map[string]string{
+				"PLAIN":    "value",
+				"EXPORTED": "yes",
+				"DOUBLE":   "quoted value",
+				"SINGLE":   "single # not a comment",
+				"INLINE":   "value",
+				"EMPTY":    "",
+			},
+		},
+		{
+			name:    "invalid line",
+			content: "NOVALUE",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := fs.NewDir(t, "envfile", fs.WithFile(".env", tt.content))
+			defer dir.Remove()
+
+			got, err := ReadEnvFile(dir.Join(".env"))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadEnvFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadEnvFile() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadEnvFile(t *testing.T) {
+	reset()
+	dir := fs.NewDir(t, "envfile", fs.WithFile(".env", "SAUCE_TEST_LOADED=from-file\nSAUCE_TEST_PRESET=from-file\n"))
+	defer dir.Remove()
+
+	t.Setenv("SAUCE_TEST_PRESET", "from-env")
+	t.Cleanup(func() { os.Unsetenv("SAUCE_TEST_LOADED") })
+
+	if err := LoadEnvFile(dir.Join(".env")); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Expand("$SAUCE_TEST_LOADED $SAUCE_TEST_PRESET")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "from-file from-env" {
+		t.Errorf("Expand() got = %q", got)
+	}
+	if masked := MaskString(got); masked != "**** from-env" {
+		t.Errorf("MaskString() got = %q", masked)
+	}
+}
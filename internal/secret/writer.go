@@ -0,0 +1,22 @@
+package secret
+
+import "io"
+
+// Writer masks secret values before writing to the underlying writer.
+type Writer struct {
+	W io.Writer
+}
+
+// NewWriter returns a Writer that masks secrets written to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{W: w}
+}
+
+// Write writes p with all secrets masked. The returned length refers to p rather than the masked output, in order to
+// satisfy the io.Writer contract.
+func (w *Writer) Write(p []byte) (int, error) {
+	if _, err := w.W.Write(MaskBytes(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PruneFilter selects files for deletion. Files are selected if they match all criteria that are set.
+type PruneFilter struct {
+	// OlderThan selects files that have been uploaded longer ago than this.
+	OlderThan time.Duration
+	// Name is a glob pattern (case-insensitive) that selects files by name, e.g. 'app-*.zip'.
+	Name string
+	// KeepLatest retains the latest N files of each name, regardless of any other criteria.
+	KeepLatest int
+	// Generated selects files that saucectl has generated and uploaded on its own. See GeneratedTag.
+	Generated bool
+	// Tags selects files that have any of the tags.
+	Tags []string
+}
+
+// IsZero returns true if no criteria are set, i.e. the filter would select all files.
+func (f PruneFilter) IsZero() bool {
+	return f.OlderThan == 0 && f.Name == "" && f.KeepLatest == 0 && !f.Generated && len(f.Tags) == 0
+}
+
+// Select returns the items that match the filter, sorted from the oldest to the newest.
+func (f PruneFilter) Select(items []Item, now time.Time) ([]Item, error) {
+	if _, err := path.Match(f.Name, ""); err != nil {
+		return nil, fmt.Errorf("invalid name pattern '%s': %w", f.Name, err)
+	}
+
+	var candidates []Item
+	for _, it := range items {
+		if f.Name != "" {
+			if ok, _ := path.Match(strings.ToLower(f.Name), strings.ToLower(it.Name)); !ok {
+				continue
+			}
+		}
+		if f.Generated && !it.HasTag(GeneratedTag) {
+			continue
+		}
+		if len(f.Tags) > 0 && !hasAnyTag(it, f.Tags) {
+			continue
+		}
+		candidates = append(candidates, it)
+	}
+
+	// Newest first, so that the first files of each name are the ones to keep.
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Uploaded.After(candidates[j].Uploaded)
+	})
+
+	seen := map[string]int{}
+	var selected []Item
+	for _, it := range candidates {
+		name := strings.ToLower(it.Name)
+		seen[name]++
+		if seen[name] <= f.KeepLatest {
+			continue
+		}
+		if f.OlderThan > 0 && now.Sub(it.Uploaded) <= f.OlderThan {
+			continue
+		}
+		selected = append(selected, it)
+	}
+
+	sort.SliceStable(selected, func(i, j int) bool {
+		return selected[i].Uploaded.Before(selected[j].Uploaded)
+	})
+
+	return selected, nil
+}
+
+func hasAnyTag(it Item, tags []string) bool {
+	for _, t := range tags {
+		if it.HasTag(t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPruneFilter_Select(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(d int) time.Time {
+		return now.Add(-time.Duration(d) * 24 * time.Hour)
+	}
+
+	items := []Item{
+		{ID: "1", Name: "app.zip", Uploaded: daysAgo(1), Tags: []string{GeneratedTag}},
+		{ID: "2", Name: "app.zip", Uploaded: daysAgo(10), Tags: []string{GeneratedTag}},
+		{ID: "3", Name: "App.zip", Uploaded: daysAgo(20), Tags: []string{GeneratedTag}},
+		{ID: "4", Name: "runner-config.zip", Uploaded: daysAgo(30), Tags: []string{GeneratedTag}},
+		{ID: "5", Name: "my-app.apk", Uploaded: daysAgo(40), Tags: []string{"release"}},
+		{ID: "6", Name: "my-app.apk", Uploaded: daysAgo(2)},
+	}
+
+	ids := func(items []Item) []string {
+		var res []string
+		for _, it := range items {
+			res = append(res, it.ID)
+		}
+		return res
+	}
+
+	tests := []struct {
+		name    string
+		filter  PruneFilter
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "older than",
+			filter: PruneFilter{OlderThan: 15 * 24 * time.Hour},
+			want:   []string{"5", "4", "3"},
+		},
+		{
+			name:   "name glob is case-insensitive",
+			filter: PruneFilter{Name: "APP.*"},
+			want:   []string{"3", "2", "1"},
+		},
+		{
+			name:   "keep latest per name",
+			filter: PruneFilter{KeepLatest: 1},
+			want:   []string{"5", "3", "2"},
+		},
+		{
+			name:   "generated and older than",
+			filter: PruneFilter{Generated: true, OlderThan: 5 * 24 * time.Hour},
+			want:   []string{"4", "3", "2"},
+		},
+		{
+			name:   "keep latest takes precedence over age",
+			filter: PruneFilter{Generated: true, KeepLatest: 2, OlderThan: 5 * 24 * time.Hour},
+			want:   []string{"3"},
+		},
+		{
+			name:   "tags",
+			filter: PruneFilter{Tags: []string{"release"}},
+			want:   []string{"5"},
+		},
+		{
+			name:    "invalid name pattern",
+			filter:  PruneFilter{Name: "[app"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.filter.Select(items, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, ids(got))
+		})
+	}
+}
@@ -20,19 +20,46 @@ type ListOptions struct {
 
 	// Limits the number of results returned.
 	MaxResults int
+
+	// Page is the page of results to return, starting at 1. Returns the first page if not set.
+	Page int
+
+	// Kind is the kind of file by which you want to filter. Options: android, ios, other.
+	Kind string
 }
 
 type List struct {
 	Items     []Item `json:"items"`
 	Truncated bool   `json:"truncated"`
+	// Page is the page of results that Items belong to.
+	Page int `json:"page,omitempty"`
+	// TotalItems is the number of files across all pages.
+	TotalItems int `json:"totalItems,omitempty"`
 }
 
+// Kinds of files in storage.
+const (
+	KindAndroid = "android"
+	KindIOS     = "ios"
+	KindOther   = "other"
+)
+
 // Item represents the file in storage.
 type Item struct {
-	ID       string    `json:"id"`
-	Name     string    `json:"name"`
-	Size     int       `json:"size"`
-	Uploaded time.Time `json:"uploaded"`
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Size        int       `json:"size"`
+	Uploaded    time.Time `json:"uploaded"`
+	Description string    `json:"description,omitempty"`
+	// Tags are derived from the description. See ParseTags.
+	Tags []string `json:"tags,omitempty"`
+	// Kind is the kind of file, i.e. android, ios or other.
+	Kind string `json:"kind,omitempty"`
+	// Version is the version of the app, if the file is an app.
+	Version string `json:"version,omitempty"`
+	// BundleID is the bundle identifier (iOS) or package name (Android) of the app, if the file is an app.
+	BundleID string `json:"bundleId,omitempty"`
+	SHA256   string `json:"sha256,omitempty"`
 }
 
 // ErrFileNotFound is returned when the requested file does not exist.
@@ -59,8 +86,15 @@ func (s *ServerError) Error() string {
 type AppService interface {
 	// UploadStream uploads the contents of reader and stores them under the given filename.
 	UploadStream(filename, description string, reader io.Reader) (Item, error)
+	// UploadStreamFunc uploads the data served by open and stores it under the given filename. open is called for
+	// every upload attempt. size is the number of bytes served by open, or -1 if unknown.
+	UploadStreamFunc(filename, description string, size int64, open func() (io.ReadCloser, error)) (Item, error)
 	Download(id string) (io.ReadCloser, int64, error)
 	Delete(id string) error
 	DownloadURL(url string) (io.ReadCloser, int64, error)
 	List(opts ListOptions) (List, error)
+	// Get returns the file with the given id.
+	Get(id string) (Item, error)
+	// Describe replaces the description of the file with the given id.
+	Describe(id, description string) (Item, error)
 }
@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"slices"
+	"strings"
+)
+
+// The storage API doesn't support tags natively. Instead, tags are stored as hashtags within the file description,
+// e.g. 'Nightly build #release #android'.
+const tagPrefix = "#"
+
+// GeneratedTag is the tag of files that saucectl generates and uploads on its own, such as project archives. These
+// files are safe to delete, since saucectl recreates them whenever they are needed.
+const GeneratedTag = "saucectl"
+
+// GeneratedDescription is the description of files that saucectl generates and uploads on its own.
+var GeneratedDescription = "Uploaded by saucectl " + tagPrefix + GeneratedTag
+
+// ParseTags returns the tags within the description.
+func ParseTags(description string) []string {
+	var tags []string
+	for _, word := range strings.Fields(description) {
+		tag, ok := strings.CutPrefix(word, tagPrefix)
+		if ok && tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// SetTags returns the description with the tags in add appended, and the tags in remove removed. Tags that are
+// already present aren't added again.
+func SetTags(description string, add []string, remove []string) string {
+	var words []string
+	for _, word := range strings.Fields(description) {
+		if tag, ok := strings.CutPrefix(word, tagPrefix); ok && slices.Contains(remove, tag) {
+			continue
+		}
+		words = append(words, word)
+	}
+
+	present := ParseTags(strings.Join(words, " "))
+	for _, tag := range add {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), tagPrefix)
+		if tag == "" || slices.Contains(present, tag) || slices.Contains(remove, tag) {
+			continue
+		}
+		words = append(words, tagPrefix+tag)
+		present = append(present, tag)
+	}
+
+	return strings.Join(words, " ")
+}
+
+// HasTag returns true if the item has the given tag.
+func (i Item) HasTag(tag string) bool {
+	return slices.Contains(i.Tags, tag)
+}
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTags(t *testing.T) {
+	tests := []struct {
+		description string
+		want        []string
+	}{
+		{description: "", want: nil},
+		{description: "Nightly build", want: nil},
+		{description: "Nightly build #release #android #release", want: []string{"release", "android"}},
+		{description: "Issue # 42 #", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			if got := ParseTags(tt.description); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		add         []string
+		remove      []string
+		want        string
+	}{
+		{
+			name:        "add to empty description",
+			description: "",
+			add:         []string{"release"},
+			want:        "#release",
+		},
+		{
+			name:        "add existing and new tags",
+			description: "Nightly build #release",
+			add:         []string{"#release", "android"},
+			want:        "Nightly build #release #android",
+		},
+		{
+			name:        "remove tags",
+			description: "Nightly build #release #android",
+			remove:      []string{"release"},
+			want:        "Nightly build #android",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SetTags(tt.description, tt.add, tt.remove); got != tt.want {
+				t.Errorf("SetTags() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
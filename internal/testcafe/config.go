@@ -51,6 +51,7 @@ type Project struct {
 	Reporters     config.Reporters     `yaml:"reporters,omitempty" json:"-"`
 	Defaults      config.Defaults      `yaml:"defaults,omitempty" json:"defaults"`
 	Env           map[string]string    `yaml:"env,omitempty" json:"env"`
+	EnvFile       []string             `yaml:"envFile,omitempty" json:"-"`
 	EnvFlag       map[string]string    `yaml:"-" json:"-"`
 	Notifications config.Notifications `yaml:"notifications,omitempty" json:"-"`
 }
@@ -80,6 +81,8 @@ type TypescriptCompilerOptions struct {
 // Suite represents the testcafe test suite configuration.
 type Suite struct {
 	Name              string            `yaml:"name,omitempty" json:"name"`
+	Tags              []string          `yaml:"tags,omitempty" json:"-"`
+	Sauceignore       string            `yaml:"sauceignore,omitempty" json:"-"`
 	BrowserName       string            `yaml:"browserName,omitempty" json:"browserName"`
 	BrowserVersion    string            `yaml:"browserVersion,omitempty" json:"browserVersion"`
 	BrowserArgs       []string          `yaml:"browserArgs,omitempty" json:"browserArgs"`
@@ -88,6 +91,7 @@ type Suite struct {
 	PlatformName      string            `yaml:"platformName,omitempty" json:"platformName"`
 	ScreenResolution  string            `yaml:"screenResolution,omitempty" json:"screenResolution"`
 	Env               map[string]string `yaml:"env,omitempty" json:"env"`
+	EnvFile           []string          `yaml:"envFile,omitempty" json:"-"`
 	Timeout           time.Duration     `yaml:"timeout,omitempty" json:"timeout"`
 	PreExec           []string          `yaml:"preExec,omitempty" json:"preExec"`
 	ExcludedTestFiles []string          `yaml:"excludedTestFiles,omitempty" json:"-"`
@@ -116,6 +120,9 @@ type Suite struct {
 	Headless             bool                   `yaml:"headless,omitempty" json:"headless"`
 	TimeZone             string                 `yaml:"timeZone,omitempty" json:"timeZone"`
 	PassThreshold        int                    `yaml:"passThreshold,omitempty" json:"-"`
+	Retries              *int                   `yaml:"retries,omitempty" json:"-"`
+	Priority             int                    `yaml:"priority,omitempty" json:"-"`
+	DependsOn            []string               `yaml:"dependsOn,omitempty" json:"-"`
 	SmartRetry           config.SmartRetry      `yaml:"smartRetry,omitempty" json:"-"`
 	// TypeScript compiling options
 	CompilerOptions CompilerOptions `yaml:"compilerOptions,omitempty" json:"compilerOptions"`
@@ -144,6 +151,17 @@ func FromFile(cfgPath string) (Project, error) {
 		return p, err
 	}
 
+	var err error
+	if p.Env, err = config.ResolveEnvFiles(p.Env, p.EnvFile); err != nil {
+		return p, err
+	}
+	for i := range p.Suites {
+		s := &p.Suites[i]
+		if s.Env, err = config.ResolveEnvFiles(s.Env, s.EnvFile); err != nil {
+			return p, fmt.Errorf("suite '%s': %w", s.Name, err)
+		}
+	}
+
 	p.ConfigFilePath = cfgPath
 
 	return p, nil
@@ -224,16 +242,9 @@ func SetDefaults(p *Project) {
 
 	// Apply global env vars onto every suite.
 	// Precedence: --env flag > root-level env vars > suite-level env vars.
-	for _, env := range []map[string]string{p.Env, p.EnvFlag} {
-		for k, v := range env {
-			for ks := range p.Suites {
-				s := &p.Suites[ks]
-				if s.Env == nil {
-					s.Env = map[string]string{}
-				}
-				s.Env[k] = v
-			}
-		}
+	// Env vars from an envFile have lower precedence than env vars on the same level.
+	for i := range p.Suites {
+		p.Suites[i].Env = config.MergeEnv(p.Suites[i].Env, p.Env, p.EnvFlag)
 	}
 }
 
@@ -250,6 +261,18 @@ func Validate(p *Project) error {
 		return fmt.Errorf(msg.InvalidVisibility, p.Sauce.Visibility, strings.Join(config.ValidVisibilityValues, ","))
 	}
 
+	if err := p.Sauce.Archive.Validate(); err != nil {
+		return fmt.Errorf("invalid archive settings: %w", err)
+	}
+
+	if err := p.Sauce.RetryPolicy.Validate(); err != nil {
+		return fmt.Errorf("invalid retry policy: %w", err)
+	}
+
+	if err := p.Sauce.AutoConcurrency.Validate(); err != nil {
+		return fmt.Errorf("invalid auto concurrency: %w", err)
+	}
+
 	err := config.ValidateRegistries(p.Npm.Registries)
 	if err != nil {
 		return err
@@ -260,8 +283,8 @@ func Validate(p *Project) error {
 		return errors.New(msg.MissingFrameworkVersionConfig)
 	}
 
-	if p.Sauce.LaunchOrder != "" && p.Sauce.LaunchOrder != config.LaunchOrderFailRate {
-		return fmt.Errorf(msg.InvalidLaunchingOption, p.Sauce.LaunchOrder, string(config.LaunchOrderFailRate))
+	if err := config.ValidateLaunchOrder(p.Sauce.LaunchOrder); err != nil {
+		return err
 	}
 
 	if len(p.Suites) == 0 {
@@ -308,10 +331,17 @@ func Validate(p *Project) error {
 		if len(v.Simulators) == 0 && v.BrowserName == "" {
 			return fmt.Errorf(msg.MissingBrowserInSuite, v.Name)
 		}
-		if p.Sauce.Retries < v.PassThreshold-1 {
+		if p.Sauce.RetryPolicy.Budget(config.RetryOnFailure, config.SuiteRetries(p.Sauce.Retries, v.Retries)) < v.PassThreshold-1 {
 			return fmt.Errorf(msg.InvalidPassThreshold)
 		}
 	}
+	var deps []config.SuiteDependencies
+	for _, s := range p.Suites {
+		deps = append(deps, config.SuiteDependencies{Suite: s.Name, DependsOn: s.DependsOn})
+	}
+	if err := config.ValidateDependencies(deps); err != nil {
+		return err
+	}
 	if p.Sauce.Retries < 0 {
 		log.Warn().Int("retries", p.Sauce.Retries).Msg(msg.InvalidReries)
 	}
@@ -324,6 +354,7 @@ func Validate(p *Project) error {
 // shardSuites divides suites into shards based on the pattern.
 func shardSuites(rootDir string, suites []Suite, ccy int, sauceignoreFile string) ([]Suite, error) {
 	var shardedSuites []Suite
+	shards := make(map[string][]string)
 
 	for _, s := range suites {
 		if s.Shard != "spec" && s.Shard != "concurrency" {
@@ -343,13 +374,14 @@ func shardSuites(rootDir string, suites []Suite, ccy int, sauceignoreFile string
 			return []Suite{}, err
 		}
 
-		files = sauceignore.ExcludeSauceIgnorePatterns(files, sauceignoreFile)
+		files = sauceignore.ExcludeSauceIgnorePatterns(files, config.SuiteSauceignore(sauceignoreFile, s.Sauceignore))
 		testFiles := fpath.ExcludeFiles(files, excludedFiles)
 
 		if s.Shard == "spec" {
 			for _, f := range testFiles {
 				replica := s
 				replica.Name = fmt.Sprintf("%s - %s", s.Name, f)
+				shards[s.Name] = append(shards[s.Name], replica.Name)
 				replica.Src = []string{f}
 				shardedSuites = append(shardedSuites, replica)
 			}
@@ -359,24 +391,33 @@ func shardSuites(rootDir string, suites []Suite, ccy int, sauceignoreFile string
 			for i, group := range groups {
 				replica := s
 				replica.Name = fmt.Sprintf("%s - %d/%d", s.Name, i+1, len(groups))
+				shards[s.Name] = append(shards[s.Name], replica.Name)
 				replica.Src = group
 				shardedSuites = append(shardedSuites, replica)
 			}
 		}
 	}
 
+	for i := range shardedSuites {
+		shardedSuites[i].DependsOn = config.ShardedDependencies(shardedSuites[i].DependsOn, shards)
+	}
+
 	return shardedSuites, nil
 }
 
-// FilterSuites filters out suites in the project that don't match the given suite name.
-func FilterSuites(p *Project, suiteName string) error {
+// FilterSuites filters out suites in the project that aren't matched by the given selector.
+func FilterSuites(p *Project, sel config.SuiteSelector) error {
+	var suites []Suite
 	for _, s := range p.Suites {
-		if s.Name == suiteName {
-			p.Suites = []Suite{s}
-			return nil
+		if sel.Match(s.Name, s.Tags) {
+			suites = append(suites, s)
 		}
 	}
-	return fmt.Errorf("no suite named '%s' found", suiteName)
+	if len(suites) == 0 {
+		return sel.NoMatchError()
+	}
+	p.Suites = suites
+	return nil
 }
 
 func IsSharded(suites []Suite) bool {
@@ -390,23 +431,7 @@ func IsSharded(suites []Suite) bool {
 
 // SortByHistory sorts the suites in the order of job history
 func SortByHistory(suites []Suite, history insights.JobHistory) []Suite {
-	hash := map[string]Suite{}
-	for _, s := range suites {
-		hash[s.Name] = s
-	}
-	var res []Suite
-	for _, s := range history.TestCases {
-		if v, ok := hash[s.Name]; ok {
-			res = append(res, v)
-			delete(hash, s.Name)
-		}
-	}
-	for _, v := range suites {
-		if _, ok := hash[v.Name]; ok {
-			res = append(res, v)
-		}
-	}
-	return res
+	return insights.SortByHistory(suites, history, func(s Suite) string { return s.Name })
 }
 
 // FilterFailedTests takes the failed tests in the report and sets them as a test filter in the suite.
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/saucelabs/saucectl/internal/config"
 	"github.com/saucelabs/saucectl/internal/insights"
 	"github.com/saucelabs/saucectl/internal/saucereport"
 	"github.com/stretchr/testify/assert"
@@ -126,7 +127,8 @@ func TestFilterSuites(t *testing.T) {
 
 	for _, tc := range testCase {
 		t.Run(tc.name, func(t *testing.T) {
-			err := FilterSuites(tc.config, tc.suiteName)
+			sel, _ := config.NewSuiteSelector([]string{tc.suiteName}, nil, nil)
+			err := FilterSuites(tc.config, sel)
 			if err != nil {
 				assert.Equal(t, tc.expErr, err.Error())
 			}
@@ -44,6 +44,7 @@ type Project struct {
 	Reporters     config.Reporters     `yaml:"reporters,omitempty" json:"-"`
 	Notifications config.Notifications `yaml:"notifications,omitempty" json:"-"`
 	Env           map[string]string    `yaml:"env,omitempty" json:"-"`
+	EnvFile       []string             `yaml:"envFile,omitempty" json:"-"`
 	EnvFlag       map[string]string    `yaml:"-" json:"-"`
 }
 
@@ -103,6 +104,7 @@ func (t TestOptions) ToMap() map[string]interface{} {
 // Suite represents the xcuitest test suite configuration.
 type Suite struct {
 	Name               string             `yaml:"name,omitempty" json:"name"`
+	Tags               []string           `yaml:"tags,omitempty" json:"-"`
 	App                string             `yaml:"app,omitempty" json:"app"`
 	AppDescription     string             `yaml:"appDescription,omitempty" json:"appDescription"`
 	TestApp            string             `yaml:"testApp,omitempty" json:"testApp"`
@@ -114,10 +116,14 @@ type Suite struct {
 	TestOptions        TestOptions        `yaml:"testOptions,omitempty" json:"testOptions"`
 	AppSettings        config.AppSettings `yaml:"appSettings,omitempty" json:"appSettings"`
 	PassThreshold      int                `yaml:"passThreshold,omitempty" json:"-"`
+	Retries            *int               `yaml:"retries,omitempty" json:"-"`
+	Priority           int                `yaml:"priority,omitempty" json:"-"`
+	DependsOn          []string           `yaml:"dependsOn,omitempty" json:"-"`
 	SmartRetry         config.SmartRetry  `yaml:"smartRetry,omitempty" json:"-"`
 	Shard              string             `yaml:"shard,omitempty" json:"-"`
 	TestListFile       string             `yaml:"testListFile,omitempty" json:"-"`
 	Env                map[string]string  `yaml:"env,omitempty" json:"-"`
+	EnvFile            []string           `yaml:"envFile,omitempty" json:"-"`
 }
 
 // IOS constant
@@ -131,6 +137,17 @@ func FromFile(cfgPath string) (Project, error) {
 		return p, err
 	}
 
+	var err error
+	if p.Env, err = config.ResolveEnvFiles(p.Env, p.EnvFile); err != nil {
+		return p, err
+	}
+	for i := range p.Suites {
+		s := &p.Suites[i]
+		if s.Env, err = config.ResolveEnvFiles(s.Env, s.EnvFile); err != nil {
+			return p, fmt.Errorf("suite '%s': %w", s.Name, err)
+		}
+	}
+
 	p.ConfigFilePath = cfgPath
 
 	return p, nil
@@ -190,14 +207,8 @@ func SetDefaults(p *Project) {
 		}
 
 		// Precedence: --env flag > root-level env vars > suite-level env vars.
-		for _, env := range []map[string]string{p.Env, p.EnvFlag} {
-			for k, v := range env {
-				if suite.Env == nil {
-					suite.Env = map[string]string{}
-				}
-				suite.Env[k] = v
-			}
-		}
+		// Env vars from an envFile have lower precedence than env vars on the same level.
+		suite.Env = config.MergeEnv(suite.Env, p.Env, p.EnvFlag)
 	}
 }
 
@@ -214,8 +225,16 @@ func Validate(p Project) error {
 		return errors.New(msg.NoTunnelSupport)
 	}
 
-	if p.Sauce.LaunchOrder != "" && p.Sauce.LaunchOrder != config.LaunchOrderFailRate {
-		return fmt.Errorf(msg.InvalidLaunchingOption, p.Sauce.LaunchOrder, string(config.LaunchOrderFailRate))
+	if err := config.ValidateLaunchOrder(p.Sauce.LaunchOrder); err != nil {
+		return err
+	}
+
+	if err := p.Sauce.RetryPolicy.Validate(); err != nil {
+		return fmt.Errorf("invalid retry policy: %w", err)
+	}
+
+	if err := p.Sauce.AutoConcurrency.Validate(); err != nil {
+		return fmt.Errorf("invalid auto concurrency: %w", err)
 	}
 
 	if len(p.Suites) == 0 {
@@ -232,6 +251,7 @@ func Validate(p Project) error {
 
 		validAppExt := []string{".app"}
 		if len(suite.Devices) > 0 {
+			config.WarnUnsupportedEnv(suite.Name, "real devices", suite.Env)
 			validAppExt = append(validAppExt, ".ipa")
 		} else if len(suite.Simulators) > 0 {
 			validAppExt = append(validAppExt, ".zip")
@@ -266,11 +286,18 @@ func Validate(p Project) error {
 					device.Options.DeviceType, suite.Name, didx, strings.Join(config.SupportedDeviceTypes, ","))
 			}
 		}
-		if p.Sauce.Retries < suite.PassThreshold-1 {
+		if p.Sauce.RetryPolicy.Budget(config.RetryOnFailure, config.SuiteRetries(p.Sauce.Retries, suite.Retries)) < suite.PassThreshold-1 {
 			return fmt.Errorf(msg.InvalidPassThreshold)
 		}
 		config.ValidateSmartRetry(suite.SmartRetry)
 	}
+	var deps []config.SuiteDependencies
+	for _, s := range p.Suites {
+		deps = append(deps, config.SuiteDependencies{Suite: s.Name, DependsOn: s.DependsOn})
+	}
+	if err := config.ValidateDependencies(deps); err != nil {
+		return err
+	}
 	if p.Sauce.Retries < 0 {
 		log.Warn().Int("retries", p.Sauce.Retries).Msg(msg.InvalidReries)
 	}
@@ -278,41 +305,30 @@ func Validate(p Project) error {
 	return nil
 }
 
-// FilterSuites filters out suites in the project that don't match the given suite name.
-func FilterSuites(p *Project, suiteName string) error {
+// FilterSuites filters out suites in the project that aren't matched by the given selector.
+func FilterSuites(p *Project, sel config.SuiteSelector) error {
+	var suites []Suite
 	for _, s := range p.Suites {
-		if s.Name == suiteName {
-			p.Suites = []Suite{s}
-			return nil
+		if sel.Match(s.Name, s.Tags) {
+			suites = append(suites, s)
 		}
 	}
-	return fmt.Errorf(msg.SuiteNameNotFound, suiteName)
+	if len(suites) == 0 {
+		return sel.NoMatchError()
+	}
+	p.Suites = suites
+	return nil
 }
 
 // SortByHistory sorts the suites in the order of job history
 func SortByHistory(suites []Suite, history insights.JobHistory) []Suite {
-	hash := map[string]Suite{}
-	for _, s := range suites {
-		hash[s.Name] = s
-	}
-	var res []Suite
-	for _, s := range history.TestCases {
-		if v, ok := hash[s.Name]; ok {
-			res = append(res, v)
-			delete(hash, s.Name)
-		}
-	}
-	for _, v := range suites {
-		if _, ok := hash[v.Name]; ok {
-			res = append(res, v)
-		}
-	}
-	return res
+	return insights.SortByHistory(suites, history, func(s Suite) string { return s.Name })
 }
 
 // ShardSuites applies sharding by provided testListFile.
 func ShardSuites(p *Project) error {
 	var suites []Suite
+	shards := make(map[string][]string)
 	for _, s := range p.Suites {
 		if s.Shard != "concurrency" {
 			suites = append(suites, s)
@@ -322,8 +338,14 @@ func ShardSuites(p *Project) error {
 		if err != nil {
 			return fmt.Errorf("failed to get tests from testListFile(%q): %v", s.TestListFile, err)
 		}
+		for _, ss := range shardedSuites {
+			shards[s.Name] = append(shards[s.Name], ss.Name)
+		}
 		suites = append(suites, shardedSuites...)
 	}
+	for i := range suites {
+		suites[i].DependsOn = config.ShardedDependencies(suites[i].DependsOn, shards)
+	}
 	p.Suites = suites
 
 	return nil
@@ -0,0 +1,177 @@
+package yaml
+
+import (
+	"bytes"
+	"errors"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// Node is an alias for a YAML document node. Unlike a plain unmarshal, a node
+// tree retains comments, key order and formatting hints, which makes it
+// suitable for editing user-authored files in place.
+type Node = yamlv3.Node
+
+// ParseDocument parses the given YAML content into a document node.
+func ParseDocument(content []byte) (*Node, error) {
+	var doc Node
+	if err := yamlv3.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind != yamlv3.DocumentNode || len(doc.Content) == 0 {
+		return nil, errors.New("empty yaml document")
+	}
+
+	return &doc, nil
+}
+
+// EncodeDocument serializes the given document node, using an indentation of
+// two spaces.
+func EncodeDocument(doc *Node) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yamlv3.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Root returns the top level node of the document.
+func Root(doc *Node) *Node {
+	if doc.Kind == yamlv3.DocumentNode && len(doc.Content) > 0 {
+		return doc.Content[0]
+	}
+	return doc
+}
+
+// Lookup walks the mapping node along the given keys and returns the value
+// node of the last key. Returns nil if any key along the way doesn't exist.
+func Lookup(n *Node, keys ...string) *Node {
+	for _, k := range keys {
+		if n == nil || n.Kind != yamlv3.MappingNode {
+			return nil
+		}
+		_, n = entry(n, k)
+	}
+	return n
+}
+
+// LookupString is like Lookup, but returns the scalar value of the node.
+// Returns an empty string if the node doesn't exist or isn't a scalar.
+func LookupString(n *Node, keys ...string) string {
+	v := Lookup(n, keys...)
+	if v == nil || v.Kind != yamlv3.ScalarNode {
+		return ""
+	}
+	return v.Value
+}
+
+// SetString sets the scalar value of key in the mapping node n. The key is
+// appended if it doesn't exist yet.
+func SetString(n *Node, key, value string) {
+	_, v := entry(n, key)
+	if v == nil {
+		n.Content = append(n.Content,
+			&Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: key},
+			&Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: value},
+		)
+		return
+	}
+	v.Kind = yamlv3.ScalarNode
+	v.Tag = "!!str"
+	v.Value = value
+	v.Content = nil
+}
+
+// SetStrings sets the value of key in the mapping node n to a sequence of
+// strings. The key is appended if it doesn't exist yet.
+func SetStrings(n *Node, key string, values []string) {
+	seq := &Node{Kind: yamlv3.SequenceNode, Tag: "!!seq"}
+	for _, s := range values {
+		seq.Content = append(seq.Content, &Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: s})
+	}
+
+	k, v := entry(n, key)
+	if v == nil {
+		n.Content = append(n.Content, &Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: key}, seq)
+		return
+	}
+	// A line comment on a block sequence would be rendered after its last
+	// item, so it's moved onto the key instead.
+	if k.LineComment == "" {
+		k.LineComment = v.LineComment
+	}
+	seq.HeadComment = v.HeadComment
+	seq.FootComment = v.FootComment
+	n.Content[indexOf(n, k)+1] = seq
+}
+
+// Rename renames the key oldKey in the mapping node n to newKey, retaining its
+// value and comments. Returns false if oldKey doesn't exist.
+func Rename(n *Node, oldKey, newKey string) bool {
+	k, _ := entry(n, oldKey)
+	if k == nil {
+		return false
+	}
+	k.Value = newKey
+	return true
+}
+
+// Strings returns the scalar values of the sequence node n.
+func Strings(n *Node) []string {
+	if n == nil {
+		return nil
+	}
+	if n.Kind == yamlv3.ScalarNode {
+		return []string{n.Value}
+	}
+
+	var ss []string
+	for _, c := range n.Content {
+		if c.Kind == yamlv3.ScalarNode {
+			ss = append(ss, c.Value)
+		}
+	}
+	return ss
+}
+
+// IsSequence returns true if n is a sequence node.
+func IsSequence(n *Node) bool {
+	return n != nil && n.Kind == yamlv3.SequenceNode
+}
+
+// IsMapping returns true if n is a mapping node.
+func IsMapping(n *Node) bool {
+	return n != nil && n.Kind == yamlv3.MappingNode
+}
+
+func entry(n *Node, key string) (k *Node, v *Node) {
+	if n == nil || n.Kind != yamlv3.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i], n.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+func indexOf(n *Node, k *Node) int {
+	for i, c := range n.Content {
+		if c == k {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsScalar returns true if n is a scalar node.
+func IsScalar(n *Node) bool {
+	return n != nil && n.Kind == yamlv3.ScalarNode
+}